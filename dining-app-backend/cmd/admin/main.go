@@ -0,0 +1,78 @@
+// Command admin runs administrative tasks that can't go through the API.
+//
+// bootstrap promotes the first administrator. Register the account through
+// POST /auth/register first, then run:
+//
+//	go run ./cmd/admin bootstrap -email admin@example.com
+//
+// It refuses to run once any user can manage roles; further administrators are
+// invited through /role-invites.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/services"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/database"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/repositories/postgres"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin bootstrap -email <email> [-role <role>]")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "bootstrap" {
+		usage()
+	}
+
+	flags := flag.NewFlagSet("bootstrap", flag.ExitOnError)
+	email := flags.String("email", "", "email of the registered account to promote")
+	role := flags.String("role", "admin", "role to give the account; it must grant role:manage")
+	flags.Parse(os.Args[2:])
+
+	if *email == "" {
+		usage()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	logger, err := logger.NewLogger(cfg.Server.Environment)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	db, err := database.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Bootstrapping sends no invites, so the role service needs no notifications
+	roleService := services.NewRoleService(
+		postgres.NewRoleRepository(db.DB),
+		postgres.NewUserRepository(db.DB),
+		nil,
+		postgres.NewTransactor(db.DB),
+		cfg.Authorization,
+		logger,
+	)
+
+	user, err := roleService.BootstrapAdmin(context.Background(), *email, *role)
+	if err != nil {
+		log.Fatalf("Failed to bootstrap administrator: %v", err)
+	}
+
+	fmt.Printf("%s (user %d) now has the %s role\n", user.Email, user.ID, user.Role)
+}
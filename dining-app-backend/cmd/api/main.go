@@ -1,13 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // restaurant time zones must resolve in minimal container images
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/services"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/database"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/eventbus"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/geocoding"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/memory"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/notification"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/oidc"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/storage"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/webhook"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/middleware"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/repositories/postgres"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
@@ -17,6 +34,9 @@ import (
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -45,24 +65,191 @@ func main() {
 	restaurantRepo := postgres.NewRestaurantRepository(db.DB)
 	tableRepo := postgres.NewTableRepository(db.DB)
 	bookingRepo := postgres.NewBookingRepository(db.DB)
+	bookingSeriesRepo := postgres.NewBookingSeriesRepository(db.DB)
+	calendarFeedRepo := postgres.NewCalendarFeedRepository(db.DB)
+	notificationRepo := postgres.NewNotificationRepository(db.DB)
+	notificationPreferenceRepo := postgres.NewNotificationPreferenceRepository(db.DB)
+	webhookRepo := postgres.NewWebhookRepository(db.DB)
+	outboxRepo := postgres.NewOutboxRepository(db.DB)
+	reviewRepo := postgres.NewReviewRepository(db.DB)
+	menuRepo := postgres.NewMenuRepository(db.DB)
+	dealRepo := postgres.NewDealRepository(db.DB)
+	loyaltyRepo := postgres.NewLoyaltyRepository(db.DB)
+	restaurantImageRepo := postgres.NewRestaurantImageRepository(db.DB)
+	membershipRepo := postgres.NewMembershipRepository(db.DB)
+	roleRepo := postgres.NewRoleRepository(db.DB)
+	sessionRepo := postgres.NewSessionRepository(db.DB)
+	passwordResetRepo := postgres.NewPasswordResetRepository(db.DB)
+	identityRepo := postgres.NewIdentityRepository(db.DB)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db.DB)
+	transactor := postgres.NewTransactor(db.DB)
 
 	// Initialize auth service
-	authService := auth.NewAuthService(&cfg.JWT)
+	authService, err := auth.NewAuthService(&cfg.JWT)
+	if err != nil {
+		logger.Fatal("Failed to initialize auth service", zap.Error(err))
+	}
+
+	// Initialize notification channels
+	renderer, err := notification.NewTemplateRenderer(cfg.Notification.DefaultLocale)
+	if err != nil {
+		logger.Fatal("Failed to load notification templates", zap.Error(err))
+	}
+
+	var smsProvider ports.SMSProvider
+	switch cfg.Notification.SMS.Provider {
+	case "log":
+		smsProvider = notification.NewLogSMSProvider(logger)
+	default:
+		logger.Fatal("Unknown SMS provider", zap.String("provider", cfg.Notification.SMS.Provider))
+	}
+
+	notifiers := []ports.Notifier{
+		notification.NewSMTPNotifier(cfg.Notification.SMTP, cfg.Calendar.UIDDomain),
+		notification.NewSMSNotifier(smsProvider),
+	}
+
+	webhookSender := webhook.NewHTTPSender(time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second, cfg.Webhook.UserAgent)
+
+	var geocoder ports.Geocoder
+	if cfg.Geo.Geocode {
+		gazetteer, err := geocoding.NewGazetteer()
+		if err != nil {
+			logger.Fatal("Failed to load gazetteer", zap.Error(err))
+		}
+		geocoder = gazetteer
+	}
+
+	identityProviders := make([]ports.IdentityProvider, len(cfg.OIDC.Providers))
+	for i, providerConfig := range cfg.OIDC.Providers {
+		if providerConfig.Name == "" || providerConfig.IssuerURL == "" || providerConfig.ClientID == "" {
+			logger.Fatal("OIDC provider needs a name, issuerURL and clientID", zap.Int("index", i))
+		}
+		identityProviders[i] = oidc.NewProvider(providerConfig, time.Duration(cfg.OIDC.TimeoutSeconds)*time.Second)
+	}
+
+	var loginAttempts ports.LoginAttemptStore
+	switch cfg.Login.Store {
+	case "memory":
+		loginAttempts = memory.NewLoginAttemptStore()
+	case "postgres":
+		loginAttempts = postgres.NewLoginAttemptRepository(db.DB)
+	default:
+		logger.Fatal("Unknown login attempt store", zap.String("store", cfg.Login.Store))
+	}
+
+	var rateLimits ports.RateLimitStore
+	switch cfg.RateLimit.Store {
+	case "memory":
+		rateLimits = memory.NewRateLimitStore()
+	case "postgres":
+		rateLimits = postgres.NewRateLimitRepository(db.DB)
+	default:
+		logger.Fatal("Unknown rate limit store", zap.String("store", cfg.RateLimit.Store))
+	}
+
+	rateLimiter, err := middleware.NewRateLimiter(rateLimits, cfg.RateLimit)
+	if err != nil {
+		logger.Fatal("Invalid rate limit config", zap.Error(err))
+	}
+
+	var blobStore ports.BlobStore
+	switch cfg.Media.Storage.Driver {
+	case "local":
+		blobStore, err = storage.NewLocalStore(cfg.Media.Storage.Dir, cfg.Media.Storage.BaseURL)
+		if err != nil {
+			logger.Fatal("Failed to initialize blob store", zap.Error(err))
+		}
+	default:
+		logger.Fatal("Unknown storage driver", zap.String("driver", cfg.Media.Storage.Driver))
+	}
 
 	// Initialize services
-	userService := services.NewUserService(userRepo, authService, logger)
-	restaurantService := services.NewRestaurantService(restaurantRepo, logger)
-	tableService := services.NewTableService(tableRepo, restaurantRepo, logger)
-	bookingService := services.NewBookingService(bookingRepo, tableRepo, restaurantRepo, logger)
+	webhookService := services.NewWebhookService(webhookRepo, webhookSender, cfg.Webhook, logger)
+	sessionService := services.NewSessionService(sessionRepo, userRepo, transactor, authService, cfg.JWT, logger)
+	restaurantService := services.NewRestaurantService(restaurantRepo, restaurantImageRepo, geocoder, blobStore, outboxRepo, transactor, cfg.Geo, logger)
+	tableService := services.NewTableService(tableRepo, restaurantRepo, outboxRepo, transactor, logger)
+	notificationService := services.NewNotificationService(
+		notificationRepo,
+		notificationPreferenceRepo,
+		userRepo,
+		bookingRepo,
+		renderer,
+		notifiers,
+		cfg.Notification,
+		logger,
+	)
+	verificationService := services.NewVerificationService(userRepo, notificationService, authService, cfg.Verification, logger)
+	loginGuard := services.NewLoginGuard(loginAttempts, cfg.Login, logger)
+	userService := services.NewUserService(userRepo, passwordResetRepo, outboxRepo, transactor, sessionService, verificationService, loginGuard, notificationService, cfg.Password, logger)
+	oidcService := services.NewOIDCService(identityProviders, identityRepo, userRepo, outboxRepo, transactor, sessionService, verificationService, cfg.OIDC, logger)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo, cfg.APIKeys, logger)
+	bookingService := services.NewBookingService(bookingRepo, bookingSeriesRepo, tableRepo, restaurantRepo, dealRepo, outboxRepo, transactor, logger)
+	calendarService := services.NewCalendarService(bookingRepo, calendarFeedRepo, logger)
+	reviewService := services.NewReviewService(reviewRepo, bookingRepo, logger)
+	menuService := services.NewMenuService(menuRepo, logger)
+	dealService := services.NewDealService(dealRepo, restaurantRepo, tableRepo, bookingRepo, cfg.Booking, logger)
+	loyaltyService := services.NewLoyaltyService(loyaltyRepo, bookingRepo, userRepo, restaurantRepo, transactor, cfg.Loyalty, logger)
+	mediaService := services.NewMediaService(restaurantImageRepo, restaurantRepo, blobStore, transactor, cfg.Media, logger)
+	roleService := services.NewRoleService(roleRepo, userRepo, notificationService, transactor, cfg.Authorization, logger)
+	membershipService := services.NewMembershipService(membershipRepo, userRepo, restaurantRepo, roleService, notificationService, transactor, cfg.Membership, logger)
+
+	// Subscribe to domain events
+	bus := eventbus.NewBus(logger)
+	services.SubscribeNotifications(bus, notificationService)
+	services.SubscribeWebhooks(bus, webhookService, bookingRepo, restaurantRepo)
+	services.SubscribeLoyalty(bus, loyaltyService)
+	services.SubscribeMedia(bus, mediaService)
+	streamService := services.NewStreamService(outboxRepo, restaurantRepo, bus, cfg.Stream, logger)
+	eventDispatcher := services.NewEventDispatcher(outboxRepo, bus, cfg.Events, logger)
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(userService, validator, logger.Logger)
 	restaurantHandler := handlers.NewRestaurantHandler(restaurantService, validator)
 	tableHandler := handlers.NewTableHandler(tableService, validator)
 	bookingHandler := handlers.NewBookingHandler(bookingService, validator)
+	calendarHandler := handlers.NewCalendarHandler(calendarService, cfg.Calendar)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, validator)
+	webhookHandler := handlers.NewWebhookHandler(webhookService, validator)
+	streamHandler := handlers.NewStreamHandler(streamService, cfg.Stream)
+	reviewHandler := handlers.NewReviewHandler(reviewService, validator)
+	menuHandler := handlers.NewMenuHandler(menuService, validator)
+	dealHandler := handlers.NewDealHandler(dealService, validator)
+	loyaltyHandler := handlers.NewLoyaltyHandler(loyaltyService, validator)
+	mediaHandler := handlers.NewMediaHandler(mediaService, cfg.Media)
+	membershipHandler := handlers.NewMembershipHandler(membershipService, validator)
+	roleHandler := handlers.NewRoleHandler(roleService, validator)
+	sessionHandler := handlers.NewSessionHandler(sessionService, validator)
+	verificationHandler := handlers.NewVerificationHandler(verificationService, validator)
+	jwksHandler := handlers.NewJWKSHandler(authService)
+	oidcHandler := handlers.NewOIDCHandler(oidcService, validator)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, validator)
+
+	// Start background workers. They get their own context, so they keep running
+	// while in-flight requests are drained on shutdown.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	startWorker := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workersCtx)
+		}()
+	}
+	startWorker(notificationService.Run)
+	startWorker(webhookService.Run)
+	startWorker(eventDispatcher.Run)
+	startWorker(services.NewAutoConfirmer(bookingService, time.Duration(cfg.Booking.AutoConfirmDelaySeconds)*time.Second, logger).Run)
+	if cfg.Events.Notify {
+		startWorker(eventbus.NewPGListener(database.DSN(cfg.Database), cfg.Events.Channel, outboxRepo, bus, logger).Run)
+	}
 
 	// Initialize router
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
 
 	// Apply global middleware
 	router.Use(middleware.CORS("*")) // Allow all origins in development
@@ -74,16 +261,79 @@ func main() {
 		restaurantHandler,
 		tableHandler,
 		bookingHandler,
+		calendarHandler,
+		notificationHandler,
+		webhookHandler,
+		streamHandler,
+		reviewHandler,
+		menuHandler,
+		dealHandler,
+		loyaltyHandler,
+		mediaHandler,
+		membershipHandler,
+		membershipService,
+		roleHandler,
+		roleService,
+		sessionHandler,
+		sessionService,
+		verificationHandler,
+		verificationService,
+		jwksHandler,
+		oidcHandler,
+		apiKeyHandler,
+		apiKeyService,
+		rateLimiter,
 		authService,
 	)
 
+	// Serve locally stored media
+	if cfg.Media.Storage.Driver == "local" {
+		router.Static("/media", cfg.Media.Storage.Dir)
+	}
+
 	// Start server
 	logger.Info("Server starting",
 		zap.String("port", cfg.Server.Port),
 		zap.String("environment", cfg.Server.Environment),
 	)
 
-	if err := router.Run(":" + cfg.Server.Port); err != nil {
-		logger.Fatal("Failed to start server", zap.Error(err))
+	// ReadHeaderTimeout falls back to ReadTimeout. Event streams lift the write
+	// timeout for themselves.
+	srv := &http.Server{
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      router,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+	}
+	srv.RegisterOnShutdown(streamHandler.Close)
+
+	listenErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			listenErr <- err
+		}
+	}()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-listenErr:
+	}
+	// Lets a second signal kill the process
+	stop()
+
+	// Stop taking requests and let in-flight ones finish before the workers wind down
+	logger.Info("Server shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownSeconds)*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Server did not shut down cleanly", zap.Error(err))
+	}
+
+	stopWorkers()
+	workers.Wait()
+	if serveErr != nil {
+		logger.Fatal("Failed to start server", zap.Error(serveErr))
 	}
+	logger.Info("Server stopped")
 }
@@ -2,6 +2,8 @@ package main
 
 import (
     "github.com/gin-gonic/gin"
+    "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+    "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/middleware"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
@@ -13,63 +15,253 @@ func setupRoutes(
     restaurantHandler *handlers.RestaurantHandler,
     tableHandler *handlers.TableHandler,
     bookingHandler *handlers.BookingHandler,
+    calendarHandler *handlers.CalendarHandler,
+    notificationHandler *handlers.NotificationHandler,
+    webhookHandler *handlers.WebhookHandler,
+    streamHandler *handlers.StreamHandler,
+    reviewHandler *handlers.ReviewHandler,
+    menuHandler *handlers.MenuHandler,
+    dealHandler *handlers.DealHandler,
+    loyaltyHandler *handlers.LoyaltyHandler,
+    mediaHandler *handlers.MediaHandler,
+    membershipHandler *handlers.MembershipHandler,
+    membershipService ports.MembershipService,
+    roleHandler *handlers.RoleHandler,
+    roleService ports.RoleService,
+    sessionHandler *handlers.SessionHandler,
+    sessionService ports.SessionService,
+    verificationHandler *handlers.VerificationHandler,
+    verificationService ports.VerificationService,
+    jwksHandler *handlers.JWKSHandler,
+    oidcHandler *handlers.OIDCHandler,
+    apiKeyHandler *handlers.APIKeyHandler,
+    apiKeyService ports.APIKeyService,
+    rateLimiter *middleware.RateLimiter,
     authService *auth.Service,
 ) {
+    // Public keys for other services to verify access tokens with
+    router.GET("/.well-known/jwks.json", rateLimiter.Limit("public"), jwksHandler.Keys)
+
     // API version group
     api := router.Group("/api/v1")
 
-    // Auth routes
+    // Some actions are kept from users until they verify their email
+    verified := func(action domain.VerifiedAction) gin.HandlerFunc {
+        return middleware.RequireVerifiedEmail(verificationService, action)
+    }
+
+    // Auth routes, limited more strictly since they are what credential guessing targets
     auth := api.Group("/auth")
+    auth.Use(rateLimiter.Limit("auth"))
     {
         auth.POST("/register", userHandler.Register)
         auth.POST("/login", userHandler.Login)
+        auth.POST("/refresh", sessionHandler.Refresh)
+        auth.POST("/password/forgot", userHandler.ForgotPassword)
+        auth.POST("/password/reset", userHandler.ResetPassword)
+        auth.POST("/email/verify", verificationHandler.VerifyEmail)
+        auth.GET("/oidc/providers", oidcHandler.ListProviders)
+        auth.POST("/oidc/:provider/start", oidcHandler.StartLogin)
+        auth.POST("/oidc/:provider/callback", oidcHandler.Callback)
     }
 
     // Public restaurant routes
     restaurants := api.Group("/restaurants")
+    restaurants.Use(rateLimiter.Limit("public"))
     {
         restaurants.GET("", restaurantHandler.List)
         restaurants.GET("/:id", restaurantHandler.GetByID)
+        restaurants.GET("/:id/stream", streamHandler.Availability)
+        restaurants.GET("/:id/reviews", reviewHandler.ListReviews)
+        restaurants.GET("/:id/menu", menuHandler.GetMenu)
+        restaurants.GET("/:id/deals", dealHandler.ListActiveDeals)
+        restaurants.GET("/:id/availability", dealHandler.SearchAvailability)
+        restaurants.GET("/:id/images", mediaHandler.ListImages)
+    }
+
+    // Booking status stream, which also takes the token from the query string
+    api.GET("/bookings/stream", middleware.StreamAuthMiddleware(authService, sessionService), rateLimiter.Limit("user"), streamHandler.Bookings)
+
+    // Calendar feed routes, authenticated by the feed token
+    api.GET("/calendar/feeds/:token", rateLimiter.Limit("public"), calendarHandler.Feed)
+
+    // Partner routes, authenticated by an API key and limited by the key's own rate
+    // limit and monthly quota. Bookings act as the key's user account, which must be
+    // verified like any other user's.
+    partner := api.Group("/partner")
+    partner.Use(middleware.APIKeyMiddleware(apiKeyService), rateLimiter.LimitAPIKey(), middleware.APIKeyQuota(apiKeyService))
+    {
+        partner.GET("/restaurants/:id/availability", middleware.RequireScope(domain.APIKeyScopeAvailabilityRead), dealHandler.SearchAvailability)
+        partner.GET("/bookings", middleware.RequireScope(domain.APIKeyScopeBookingsRead), bookingHandler.GetUserBookings)
+        partner.POST("/bookings", middleware.RequireScope(domain.APIKeyScopeBookingsWrite), verified(domain.VerifiedActionBookings), bookingHandler.CreateBooking)
+        partner.POST("/bookings/:id/cancel", middleware.RequireScope(domain.APIKeyScopeBookingsWrite), bookingHandler.CancelBooking)
     }
 
     // Protected routes
     protected := api.Group("")
-    protected.Use(middleware.AuthMiddleware(authService))
+    protected.Use(middleware.AuthMiddleware(authService, sessionService), rateLimiter.Limit("user"))
     {
+        // Sign out of this session, or of every session
+        protected.POST("/auth/logout", sessionHandler.Logout)
+        protected.POST("/auth/logout-all", sessionHandler.LogoutAll)
+
         // User profile routes
         profile := protected.Group("/profile")
         {
             profile.GET("", userHandler.GetProfile)
             profile.PUT("", userHandler.UpdateProfile)
+            profile.PUT("/password", userHandler.ChangePassword)
+            profile.POST("/email/verification", verificationHandler.ResendVerification)
+            profile.POST("/calendar-feed", calendarHandler.CreateFeed)
+            profile.DELETE("/calendar-feed", calendarHandler.RevokeFeed)
+            profile.GET("/notifications", notificationHandler.GetPreferences)
+            profile.PUT("/notifications", notificationHandler.UpdatePreferences)
+            profile.GET("/loyalty", loyaltyHandler.GetAccount)
+            profile.GET("/loyalty/ledger", loyaltyHandler.GetLedger)
+            profile.GET("/memberships", membershipHandler.GetUserMemberships)
+            profile.GET("/permissions", roleHandler.GetUserPermissions)
+            profile.GET("/sessions", sessionHandler.ListSessions)
+            profile.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+            profile.GET("/identities", oidcHandler.ListIdentities)
         }
 
+        // Invite acceptance, by the user the invite was emailed to
+        protected.POST("/memberships/invites/accept", membershipHandler.AcceptInvite)
+        protected.POST("/role-invites/accept", roleHandler.AcceptRoleInvite)
+
         // Protected booking routes
         bookings := protected.Group("/bookings")
         {
-            bookings.POST("", bookingHandler.CreateBooking)
+            bookings.POST("", verified(domain.VerifiedActionBookings), bookingHandler.CreateBooking)
             bookings.GET("", bookingHandler.GetUserBookings)
             bookings.PUT("/:id/status", bookingHandler.UpdateBookingStatus)
+            bookings.POST("/:id/cancel", bookingHandler.CancelBooking)
+            bookings.POST("/recurring", verified(domain.VerifiedActionBookings), bookingHandler.CreateRecurringBooking)
+            bookings.GET("/series/:id", bookingHandler.GetBookingSeries)
+            bookings.GET("/:id/ical", calendarHandler.ExportBooking)
+        }
+
+        // Protected review routes
+        protected.POST("/restaurants/:id/reviews", verified(domain.VerifiedActionReviews), reviewHandler.CreateReview)
+        reviews := protected.Group("/reviews")
+        {
+            reviews.PUT("/:id", reviewHandler.UpdateReview)
+            reviews.DELETE("/:id", reviewHandler.DeleteReview)
+        }
+
+        // Restaurant management routes require a role at the restaurant the resource
+        // belongs to, unless the user's platform role can manage every restaurant
+        staff := func(resource domain.RestaurantResource, param string, role domain.MembershipRole) gin.HandlerFunc {
+            return middleware.RestaurantRoleMiddleware(membershipService, resource, param, role)
+        }
+        host := staff(domain.ResourceRestaurant, "id", domain.MembershipRoleHost)
+        manager := staff(domain.ResourceRestaurant, "id", domain.MembershipRoleManager)
+        owner := staff(domain.ResourceRestaurant, "id", domain.MembershipRoleOwner)
+
+        managedRestaurants := protected.Group("/restaurants")
+        {
+            managedRestaurants.PUT("/:id", manager, restaurantHandler.Update)
+            managedRestaurants.DELETE("/:id", owner, restaurantHandler.Delete)
+            managedRestaurants.GET("/:id/menu/draft", manager, menuHandler.GetDraft)
+            managedRestaurants.PUT("/:id/menu/draft", manager, menuHandler.SaveDraft)
+            managedRestaurants.POST("/:id/menu/publish", manager, menuHandler.Publish)
+            managedRestaurants.GET("/:id/menu/versions", manager, menuHandler.ListVersions)
+            managedRestaurants.GET("/:id/menu/versions/:version", manager, menuHandler.GetVersion)
+            managedRestaurants.POST("/:id/menu/versions/:version/rollback", manager, menuHandler.Rollback)
+            managedRestaurants.GET("/:id/loyalty-rules", manager, loyaltyHandler.GetRules)
+            managedRestaurants.PUT("/:id/loyalty-rules", manager, loyaltyHandler.UpdateRules)
+            managedRestaurants.PUT("/:id/images/cover", manager, mediaHandler.UploadCover)
+            managedRestaurants.POST("/:id/images", manager, mediaHandler.UploadGalleryImage)
+            managedRestaurants.DELETE("/:id/images/:imageId", manager, mediaHandler.DeleteImage)
+            managedRestaurants.GET("/:id/bookings", host, bookingHandler.GetRestaurantBookings)
+            managedRestaurants.GET("/:id/members", manager, membershipHandler.ListMembers)
+            managedRestaurants.PUT("/:id/members/:userId", owner, membershipHandler.UpdateMember)
+            managedRestaurants.DELETE("/:id/members/:userId", owner, membershipHandler.RemoveMember)
+            managedRestaurants.POST("/:id/invites", owner, membershipHandler.InviteMember)
+            managedRestaurants.GET("/:id/invites", owner, membershipHandler.ListInvites)
+            managedRestaurants.DELETE("/:id/invites/:inviteId", owner, membershipHandler.RevokeInvite)
+        }
+
+        // Table management routes
+        tables := protected.Group("/tables")
+        {
+            tables.POST("/restaurant/:restaurantId", staff(domain.ResourceRestaurant, "restaurantId", domain.MembershipRoleManager), tableHandler.CreateTable)
+            tables.GET("/restaurant/:restaurantId", staff(domain.ResourceRestaurant, "restaurantId", domain.MembershipRoleHost), tableHandler.GetRestaurantTables)
+            tables.PUT("/:id/availability", staff(domain.ResourceTable, "id", domain.MembershipRoleHost), tableHandler.UpdateAvailability)
+        }
+
+        // Deal management routes
+        deals := protected.Group("/deals")
+        {
+            deals.POST("/restaurant/:restaurantId", staff(domain.ResourceRestaurant, "restaurantId", domain.MembershipRoleManager), dealHandler.CreateDeal)
+            deals.GET("/restaurant/:restaurantId", staff(domain.ResourceRestaurant, "restaurantId", domain.MembershipRoleManager), dealHandler.ListDeals)
+            deals.PUT("/:id", staff(domain.ResourceDeal, "id", domain.MembershipRoleManager), dealHandler.UpdateDeal)
+            deals.DELETE("/:id", staff(domain.ResourceDeal, "id", domain.MembershipRoleManager), dealHandler.DeleteDeal)
+        }
+
+        // Booking outcomes, recorded by the restaurant's staff
+        protected.PUT("/bookings/:id/outcome", staff(domain.ResourceBooking, "id", domain.MembershipRoleHost), bookingHandler.RecordOutcome)
+
+        // Platform routes require a permission of the user's current role
+        can := func(permission domain.Permission) gin.HandlerFunc {
+            return middleware.RequirePermission(roleService, permission)
+        }
+
+        // Restaurants are created by the platform, which then invites the owners
+        protected.POST("/restaurants", can(domain.PermissionRestaurantWrite), restaurantHandler.Create)
+
+        // Webhook routes
+        webhooks := protected.Group("/webhooks")
+        webhooks.Use(can(domain.PermissionWebhookManage))
+        {
+            webhooks.POST("", webhookHandler.CreateEndpoint)
+            webhooks.GET("", webhookHandler.ListEndpoints)
+            webhooks.PUT("/:id", webhookHandler.UpdateEndpoint)
+            webhooks.DELETE("/:id", webhookHandler.DeleteEndpoint)
+            webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+            webhooks.POST("/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+        }
+
+        // Partner API key routes
+        apiKeys := protected.Group("/api-keys")
+        apiKeys.Use(can(domain.PermissionAPIKeyManage))
+        {
+            apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+            apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+            apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+            apiKeys.GET("/:id/usage", apiKeyHandler.GetUsage)
+        }
+
+        // User administration routes
+        users := protected.Group("/users")
+        {
+            users.GET("/:id/loyalty", can(domain.PermissionUserRead), loyaltyHandler.GetUserAccount)
+            users.GET("/:id/loyalty/ledger", can(domain.PermissionUserRead), loyaltyHandler.GetUserLedger)
+            users.POST("/:id/loyalty/adjustments", can(domain.PermissionUserWrite), loyaltyHandler.Adjust)
+            users.PUT("/:id/role", can(domain.PermissionRoleManage), roleHandler.AssignRole)
+            users.GET("/:id/role-changes", can(domain.PermissionRoleManage), roleHandler.ListRoleChanges)
+        }
+
+        // Review moderation
+        protected.PUT("/reviews/:id/visibility", can(domain.PermissionReviewModerate), reviewHandler.SetVisibility)
+
+        // Role routes
+        roles := protected.Group("/roles")
+        roles.Use(can(domain.PermissionRoleManage))
+        {
+            roles.GET("", roleHandler.ListRoles)
+            roles.POST("", roleHandler.CreateRole)
+            roles.GET("/:name", roleHandler.GetRole)
+            roles.PUT("/:name", roleHandler.UpdateRole)
+            roles.DELETE("/:name", roleHandler.DeleteRole)
         }
 
-        // Admin routes
-        admin := protected.Group("")
-        admin.Use(middleware.AdminMiddleware())
+        // Role invites are how administrators are added after the first one
+        roleInvites := protected.Group("/role-invites")
         {
-            // Admin restaurant routes
-            adminRestaurants := admin.Group("/restaurants")
-            {
-                adminRestaurants.POST("", restaurantHandler.Create)
-                adminRestaurants.PUT("/:id", restaurantHandler.Update)
-                adminRestaurants.DELETE("/:id", restaurantHandler.Delete)
-            }
-
-            // Admin table routes
-            tables := admin.Group("/tables")
-            {
-                tables.POST("/restaurant/:restaurantId", tableHandler.CreateTable)
-                tables.GET("/restaurant/:restaurantId", tableHandler.GetRestaurantTables)
-                tables.PUT("/:id/availability", tableHandler.UpdateAvailability)
-            }
+            roleInvites.POST("", can(domain.PermissionRoleManage), roleHandler.InviteToRole)
+            roleInvites.GET("", can(domain.PermissionRoleManage), roleHandler.ListRoleInvites)
+            roleInvites.DELETE("/:id", can(domain.PermissionRoleManage), roleHandler.RevokeRoleInvite)
         }
     }
 }
\ No newline at end of file
@@ -1,10 +1,32 @@
+-- Enable trigram matching for restaurant search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create roles table; each role grants a set of permissions
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(30) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    system BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Seed the system roles
+INSERT INTO roles (name, description, permissions, system) VALUES
+    ('user', 'Diners; restaurant staff get their access from memberships', '{}', true),
+    ('admin', 'Platform administrators', ARRAY['restaurant:write', 'booking:manage', 'user:read', 'user:write', 'review:moderate', 'webhook:manage', 'role:manage', 'apikey:manage'], true)
+ON CONFLICT (name) DO NOTHING;
+
 -- Create users table
 CREATE TABLE IF NOT EXISTS users (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     email VARCHAR(100) UNIQUE NOT NULL,
     password VARCHAR(255) NOT NULL,
-    role VARCHAR(20) NOT NULL,
+    role VARCHAR(30) NOT NULL DEFAULT 'user' REFERENCES roles(name) ON UPDATE CASCADE,
+    email_verified_at TIMESTAMP WITH TIME ZONE,
+    pending_email VARCHAR(100),
+    verification_sent_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -18,6 +40,18 @@ CREATE TABLE IF NOT EXISTS restaurants (
     cuisine_type VARCHAR(100) NOT NULL,
     opening_time VARCHAR(50) NOT NULL,
     closing_time VARCHAR(50) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    -- Search document, weighted from name down to description
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(cuisine_type, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(address, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'D')
+    ) STORED,
+    -- Text for typo-tolerant trigram matching
+    search_text TEXT GENERATED ALWAYS AS (name || ' ' || cuisine_type || ' ' || address) STORED,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -34,11 +68,47 @@ CREATE TABLE IF NOT EXISTS tables (
     UNIQUE(restaurant_id, table_number)
 );
 
+-- Create booking series table
+CREATE TABLE IF NOT EXISTS booking_series (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    table_id INTEGER REFERENCES tables(id) ON DELETE CASCADE,
+    frequency VARCHAR(20) NOT NULL,
+    start_date DATE NOT NULL,
+    until_date DATE,
+    occurrence_count INTEGER NOT NULL DEFAULT 0,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    number_of_guests INTEGER NOT NULL,
+    special_requests TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create API keys table; keys partners call the partner routes with, stored hashed
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    partner VARCHAR(100) NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    prefix VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    requests_per_minute INTEGER NOT NULL,
+    burst INTEGER NOT NULL,
+    monthly_quota INTEGER NOT NULL DEFAULT 0,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
 -- Create bookings table
 CREATE TABLE IF NOT EXISTS bookings (
     id SERIAL PRIMARY KEY,
     user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
     table_id INTEGER REFERENCES tables(id) ON DELETE CASCADE,
+    series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL,
+    api_key_id INTEGER REFERENCES api_keys(id) ON DELETE SET NULL,
     booking_date DATE NOT NULL,
     start_time TIME NOT NULL,
     end_time TIME NOT NULL,
@@ -49,12 +119,371 @@ CREATE TABLE IF NOT EXISTS bookings (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Create calendar feed tokens table
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create notification preferences table
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    sms_enabled BOOLEAN NOT NULL DEFAULT false,
+    reminders_enabled BOOLEAN NOT NULL DEFAULT true,
+    phone VARCHAR(20),
+    locale VARCHAR(35) NOT NULL DEFAULT 'en',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    booking_id INTEGER REFERENCES bookings(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    dedup_key VARCHAR(255) UNIQUE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create webhook endpoints table
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id SERIAL PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    description VARCHAR(255),
+    secret VARCHAR(255) NOT NULL,
+    events TEXT[] NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create webhook deliveries table
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    endpoint_id INTEGER NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    redelivery BOOLEAN NOT NULL DEFAULT false,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    response_body TEXT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create outbox table for domain events
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create reviews table
+CREATE TABLE IF NOT EXISTS reviews (
+    id BIGSERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment TEXT NOT NULL DEFAULT '',
+    hidden BOOLEAN NOT NULL DEFAULT false,
+    hidden_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create menu versions table
+CREATE TABLE IF NOT EXISTS menu_versions (
+    id BIGSERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    content JSONB NOT NULL,
+    based_on_version INTEGER,
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create deals table
+CREATE TABLE IF NOT EXISTS deals (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    benefit_type VARCHAR(20) NOT NULL,
+    discount_percent INTEGER NOT NULL DEFAULT 0,
+    applies_to VARCHAR(20) NOT NULL DEFAULT '',
+    free_item VARCHAR(100) NOT NULL DEFAULT '',
+    days_of_week INTEGER[] NOT NULL DEFAULT '{}',
+    start_time TIME,
+    end_time TIME,
+    min_party_size INTEGER NOT NULL DEFAULT 0,
+    max_party_size INTEGER NOT NULL DEFAULT 0,
+    first_time_guests_only BOOLEAN NOT NULL DEFAULT false,
+    valid_from DATE,
+    valid_until DATE,
+    max_redemptions INTEGER NOT NULL DEFAULT 0,
+    max_per_guest INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create booking deals table, keeping a copy of each deal as it was when applied
+CREATE TABLE IF NOT EXISTS booking_deals (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    deal_id INTEGER REFERENCES deals(id) ON DELETE SET NULL,
+    title VARCHAR(100) NOT NULL,
+    benefit VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(booking_id, deal_id)
+);
+
+-- Create loyalty ledger table. Rows are append-only, so it keeps plain IDs rather
+-- than foreign keys that would cascade.
+CREATE TABLE IF NOT EXISTS loyalty_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    points INTEGER NOT NULL CHECK (points != 0),
+    booking_id INTEGER,
+    restaurant_id INTEGER,
+    reason TEXT NOT NULL,
+    created_by INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION loyalty_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'loyalty_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS loyalty_ledger_append_only ON loyalty_ledger;
+CREATE TRIGGER loyalty_ledger_append_only
+    BEFORE UPDATE OR DELETE ON loyalty_ledger
+    FOR EACH ROW EXECUTE FUNCTION loyalty_ledger_append_only();
+
+-- Create loyalty rules table; restaurants without a row use the configured defaults
+CREATE TABLE IF NOT EXISTS loyalty_rules (
+    restaurant_id INTEGER PRIMARY KEY REFERENCES restaurants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    points_per_booking INTEGER NOT NULL DEFAULT 0,
+    points_per_guest INTEGER NOT NULL DEFAULT 0,
+    no_show_penalty INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create restaurant images table; the blobs themselves live in the blob store
+CREATE TABLE IF NOT EXISTS restaurant_images (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('cover', 'gallery')),
+    blob_key VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create restaurant memberships table; staff roles are scoped to one restaurant
+CREATE TABLE IF NOT EXISTS restaurant_memberships (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'host')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (restaurant_id, user_id)
+);
+
+-- Create membership invites table; only token hashes are stored
+CREATE TABLE IF NOT EXISTS membership_invites (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'host')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create role invites table; only token hashes are stored
+CREATE TABLE IF NOT EXISTS role_invites (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(30) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create role changes table; an append-only audit of users' roles
+CREATE TABLE IF NOT EXISTS role_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_role VARCHAR(30) NOT NULL,
+    new_role VARCHAR(30) NOT NULL,
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('bootstrap', 'assignment', 'invite')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create sessions table; one row per signed-in device
+CREATE TABLE IF NOT EXISTS sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create refresh tokens table; used tokens are kept to detect reuse
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create password resets table; only token hashes are stored
+CREATE TABLE IF NOT EXISTS password_resets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create login attempts table; failed logins per account or client IP, used when
+-- login.store is postgres
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Create rate limit buckets table; token buckets per client and route group, used
+-- when rateLimit.store is postgres
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key VARCHAR(400) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create user identities table; accounts at OpenID Connect providers linked to users
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+-- Create OIDC logins table; sign-ins started with a provider, only state hashes are stored
+CREATE TABLE IF NOT EXISTS oidc_logins (
+    id SERIAL PRIMARY KEY,
+    state_hash VARCHAR(64) NOT NULL UNIQUE,
+    provider VARCHAR(50) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create API key usage table; requests per key and calendar month
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id INTEGER NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    requests BIGINT NOT NULL,
+    last_request_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (api_key_id, month)
+);
+
 -- Create indexes
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 CREATE INDEX IF NOT EXISTS idx_restaurants_cuisine_type ON restaurants(cuisine_type);
 CREATE INDEX IF NOT EXISTS idx_bookings_user ON bookings(user_id);
 CREATE INDEX IF NOT EXISTS idx_bookings_table ON bookings(table_id);
 CREATE INDEX IF NOT EXISTS idx_bookings_date ON bookings(booking_date);
+CREATE INDEX IF NOT EXISTS idx_bookings_series ON bookings(series_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_pending ON bookings(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_booking_series_user ON booking_series(user_id);
+CREATE INDEX IF NOT EXISTS idx_calendar_feed_tokens_user ON calendar_feed_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(endpoint_id, event_id) WHERE NOT redelivery;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events(id) WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_restaurant ON reviews(restaurant_id, created_at) WHERE NOT hidden;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_versions_version ON menu_versions(restaurant_id, version) WHERE status != 'draft';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_versions_draft ON menu_versions(restaurant_id) WHERE status = 'draft';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_versions_published ON menu_versions(restaurant_id) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS idx_deals_restaurant ON deals(restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_booking_deals_deal ON booking_deals(deal_id);
+CREATE INDEX IF NOT EXISTS idx_loyalty_ledger_user ON loyalty_ledger(user_id, id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loyalty_ledger_booking ON loyalty_ledger(booking_id, kind) WHERE booking_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_restaurants_search ON restaurants USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_restaurants_search_text ON restaurants USING GIN(search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_restaurants_location ON restaurants(latitude, longitude) WHERE latitude IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_restaurant_images_restaurant ON restaurant_images(restaurant_id, kind, position);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_restaurant_images_cover ON restaurant_images(restaurant_id) WHERE kind = 'cover';
+CREATE INDEX IF NOT EXISTS idx_restaurant_memberships_user ON restaurant_memberships(user_id);
+CREATE INDEX IF NOT EXISTS idx_membership_invites_restaurant ON membership_invites(restaurant_id, email) WHERE accepted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+CREATE INDEX IF NOT EXISTS idx_role_invites_email ON role_invites(email) WHERE accepted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_role_changes_user ON role_changes(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens(session_id);
+CREATE INDEX IF NOT EXISTS idx_password_resets_user ON password_resets(user_id) WHERE used_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys(user_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_api_key ON bookings(api_key_id);
 
 -- Insert restaurants
 INSERT INTO restaurants (name, description, address, cuisine_type, opening_time, closing_time)
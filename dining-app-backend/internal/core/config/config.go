@@ -5,16 +5,39 @@ import (
 )
 
 type Config struct {
-    Server   ServerConfig
-    Database DatabaseConfig
-    JWT      JWTConfig
+    Server        ServerConfig
+    Database      DatabaseConfig
+    JWT           JWTConfig
+    Calendar      CalendarConfig
+    Notification  NotificationConfig
+    Webhook       WebhookConfig
+    Events        EventsConfig
+    Booking       BookingConfig
+    Stream        StreamConfig
+    Loyalty       LoyaltyConfig
+    Geo           GeoConfig
+    Media         MediaConfig
+    Membership    MembershipConfig
+    Authorization AuthorizationConfig
+    Password      PasswordConfig
+    Verification  VerificationConfig
+    Login         LoginConfig
+    RateLimit     RateLimitConfig
+    OIDC          OIDCConfig
+    APIKeys       APIKeyConfig
 }
 
+// ServerConfig's ShutdownSeconds is how long in-flight requests get to finish once
+// the server is asked to stop. TrustedProxies lists the proxies (IPs or CIDRs) whose
+// X-Forwarded-For header is believed; without any, a client's IP is the address it
+// connected from.
 type ServerConfig struct {
-    Port         string
-    Environment  string
-    ReadTimeout  int
-    WriteTimeout int
+    Port            string
+    Environment     string
+    ReadTimeout     int
+    WriteTimeout    int
+    ShutdownSeconds int
+    TrustedProxies  []string
 }
 
 type DatabaseConfig struct {
@@ -26,9 +49,234 @@ type DatabaseConfig struct {
     SSLMode  string
 }
 
+// JWTConfig controls access and refresh tokens. Access tokens live AccessTokenMinutes;
+// a session lasts RefreshTokenDays after its last refresh. Revoked sessions are
+// rejected on other replicas within SessionCacheSeconds; zero checks every request.
+//
+// Access tokens are signed with Algorithm: HS256 with Secret, or RS256 or EdDSA with
+// the key in Keys whose ID is SigningKeyID. The other keys only verify. To rotate, add
+// the new key, make it the signing key once other services have refetched the public
+// keys at /.well-known/jwks.json (cached for five minutes), and drop the old key once
+// AccessTokenMinutes have passed. Secret also signs email verification links, so it
+// is required under every algorithm.
 type JWTConfig struct {
-    Secret      string
-    TokenExpiry int
+    Secret              string
+    Algorithm           string
+    Issuer              string
+    Audience            string
+    SigningKeyID        string
+    Keys                []JWTKeyConfig
+    AccessTokenMinutes  int
+    RefreshTokenDays    int
+    SessionCacheSeconds int
+}
+
+// JWTKeyConfig points at the PEM files of a key. A key that only verifies may have
+// just its public key.
+type JWTKeyConfig struct {
+    ID             string
+    PrivateKeyFile string
+    PublicKeyFile  string
+}
+
+type CalendarConfig struct {
+    ProductID   string
+    UIDDomain   string
+    FeedBaseURL string
+}
+
+type NotificationConfig struct {
+    DefaultLocale       string
+    ReminderLeadMinutes int
+    PollIntervalSeconds int
+    MaxAttempts         int
+    SMTP                SMTPConfig
+    SMS                 SMSConfig
+}
+
+type SMTPConfig struct {
+    Host     string
+    Port     int
+    Username string
+    Password string
+    From     string
+}
+
+type SMSConfig struct {
+    // Provider selects the SMS gateway; "log" only logs messages
+    Provider string
+}
+
+type WebhookConfig struct {
+    PollIntervalSeconds int
+    MaxAttempts         int
+    TimeoutSeconds      int
+    UserAgent           string
+}
+
+type EventsConfig struct {
+    PollIntervalMillis int
+    MaxAttempts        int
+    // Notify also publishes events with Postgres NOTIFY so every replica sees them.
+    // Without it, streams only get events dispatched by their own replica.
+    Notify  bool
+    Channel string
+}
+
+// BookingConfig's AutoConfirmDelaySeconds is how long bookings stay pending before the
+// system confirms them; pending bookings are swept that often
+type BookingConfig struct {
+    AutoConfirmDelaySeconds int
+    // Availability search offers a slot every SlotMinutes, each SlotDurationMinutes long
+    SlotMinutes         int
+    SlotDurationMinutes int
+}
+
+type StreamConfig struct {
+    // BufferSize is how many events a client may fall behind before it is disconnected
+    BufferSize       int
+    ReplayLimit      int
+    HeartbeatSeconds int
+    RetryMillis      int
+}
+
+// LoyaltyConfig holds the accrual rules of restaurants that haven't set their own, and
+// the tiers, ordered by MinPoints
+type LoyaltyConfig struct {
+    PointsPerBooking int
+    PointsPerGuest   int
+    NoShowPenalty    int
+    Tiers            []LoyaltyTierConfig
+}
+
+type LoyaltyTierConfig struct {
+    Name      string
+    MinPoints int
+    Perks     []string
+}
+
+// GeoConfig controls geocoding of restaurant addresses and the radius of near-me searches
+type GeoConfig struct {
+    Geocode         bool
+    DefaultRadiusKm float64
+    MaxRadiusKm     float64
+}
+
+// MediaConfig limits image uploads and chooses where they are stored
+type MediaConfig struct {
+    MaxUploadBytes   int64
+    MaxPixels        int
+    MaxGalleryImages int
+    ThumbnailSize    int
+    ThumbnailQuality int
+    Storage          StorageConfig
+}
+
+// StorageConfig selects the blob store. The local driver keeps files in Dir and the
+// API serves them at BaseURL, whose path must be /media.
+type StorageConfig struct {
+    Driver  string
+    Dir     string
+    BaseURL string
+}
+
+// MembershipConfig controls staff invites. The emailed link is InviteURL with the
+// invite token appended as the token query parameter.
+type MembershipConfig struct {
+    InviteTTLHours int
+    InviteURL      string
+}
+
+// AuthorizationConfig controls how long a user's role is cached and role invites. Role
+// changes take effect on other replicas within CacheSeconds; zero disables the cache.
+// The emailed invite link is InviteURL with the invite token appended as the token
+// query parameter.
+type AuthorizationConfig struct {
+    CacheSeconds   int
+    InviteTTLHours int
+    InviteURL      string
+}
+
+// PasswordConfig controls password resets. The emailed link is ResetURL with the reset
+// token appended as the token query parameter.
+type PasswordConfig struct {
+    ResetTTLMinutes int
+    ResetURL        string
+}
+
+// VerificationConfig controls email verification. The emailed link is VerifyURL with
+// the signed token appended as the token query parameter. Users with an unverified
+// email can't take the actions in RequiredFor, such as bookings.
+type VerificationConfig struct {
+    TokenTTLHours         int
+    ResendCooldownSeconds int
+    VerifyURL             string
+    RequiredFor           []string
+}
+
+// LoginConfig throttles failed logins, counted per account and per client IP. Each
+// failure doubles the wait before the next attempt, from DelaySeconds up to
+// MaxDelaySeconds, and reaching the limit locks the account or IP out for
+// LockoutMinutes. Failures are forgotten WindowMinutes after the last one. Store is
+// memory for a single instance, or postgres to share the counts between replicas.
+type LoginConfig struct {
+    Store              string
+    WindowMinutes      int
+    AccountMaxFailures int
+    IPMaxFailures      int
+    LockoutMinutes     int
+    DelaySeconds       int
+    MaxDelaySeconds    int
+}
+
+// RateLimitConfig limits requests with a token bucket per client and route group.
+// Groups are named by setupRoutes (auth, public and user); a group without a rule
+// isn't limited. Store is memory for a single instance, or postgres to share the
+// buckets between replicas.
+type RateLimitConfig struct {
+    Enabled bool
+    Store   string
+    Groups  map[string]RateLimitRule
+}
+
+// RateLimitRule lets Burst requests through at once, then RequestsPerMinute. KeyBy
+// picks what a client is: ip, user or apikey. Requests without a user or API key are
+// keyed by IP.
+type RateLimitRule struct {
+    RequestsPerMinute int
+    Burst             int
+    KeyBy             string
+}
+
+// OIDCConfig lists the OpenID Connect providers users can sign in with. A sign-in must
+// finish within StateTTLMinutes, and each request to a provider within TimeoutSeconds.
+type OIDCConfig struct {
+    StateTTLMinutes int
+    TimeoutSeconds  int
+    Providers       []OIDCProviderConfig
+}
+
+// OIDCProviderConfig registers the API as a client of a provider, whose endpoints are
+// discovered from IssuerURL. RedirectURL is the frontend page the provider sends users
+// back to; it passes the code and state on to /auth/oidc/{name}/callback. A provider
+// without a ClientSecret is used as a public client, relying on PKCE alone.
+type OIDCProviderConfig struct {
+    Name         string
+    DisplayName  string
+    IssuerURL    string
+    ClientID     string
+    ClientSecret string
+    RedirectURL  string
+    Scopes       []string
+}
+
+// APIKeyConfig covers the API keys partners integrate with. Keys created without
+// their own rate limit get the default one. A revoked key keeps working for up to
+// CacheSeconds on other replicas.
+type APIKeyConfig struct {
+    CacheSeconds             int
+    DefaultRequestsPerMinute int
+    DefaultBurst             int
 }
 
 func LoadConfig() (*Config, error) {
@@ -36,6 +284,90 @@ func LoadConfig() (*Config, error) {
     viper.SetConfigType("yaml")
     viper.AddConfigPath("./configs")
 
+    viper.SetDefault("server.shutdownSeconds", 20)
+    viper.SetDefault("server.trustedProxies", []string{})
+    viper.SetDefault("jwt.algorithm", "HS256")
+    viper.SetDefault("jwt.issuer", "dining-app")
+    viper.SetDefault("jwt.audience", "dining-app-api")
+    viper.SetDefault("jwt.accessTokenMinutes", 15)
+    viper.SetDefault("jwt.refreshTokenDays", 30)
+    viper.SetDefault("jwt.sessionCacheSeconds", 5)
+    viper.SetDefault("calendar.productID", "-//Dining App//Bookings//EN")
+    viper.SetDefault("calendar.uidDomain", "dining-app.local")
+    viper.SetDefault("calendar.feedBaseURL", "http://localhost:8080/api/v1/calendar/feeds")
+    viper.SetDefault("notification.defaultLocale", "en")
+    viper.SetDefault("notification.reminderLeadMinutes", 24*60)
+    viper.SetDefault("notification.pollIntervalSeconds", 30)
+    viper.SetDefault("notification.maxAttempts", 5)
+    viper.SetDefault("notification.smtp.host", "localhost")
+    viper.SetDefault("notification.smtp.port", 1025)
+    viper.SetDefault("notification.smtp.from", "Dining App <no-reply@dining-app.local>")
+    viper.SetDefault("notification.sms.provider", "log")
+    viper.SetDefault("webhook.pollIntervalSeconds", 10)
+    viper.SetDefault("webhook.maxAttempts", 8)
+    viper.SetDefault("webhook.timeoutSeconds", 10)
+    viper.SetDefault("webhook.userAgent", "DiningApp-Webhooks/1.0")
+    viper.SetDefault("events.pollIntervalMillis", 500)
+    viper.SetDefault("events.maxAttempts", 10)
+    viper.SetDefault("events.notify", true)
+    viper.SetDefault("events.channel", "domain_events")
+    viper.SetDefault("booking.autoConfirmDelaySeconds", 5)
+    viper.SetDefault("booking.slotMinutes", 30)
+    viper.SetDefault("booking.slotDurationMinutes", 90)
+    viper.SetDefault("stream.bufferSize", 64)
+    viper.SetDefault("stream.replayLimit", 500)
+    viper.SetDefault("stream.heartbeatSeconds", 15)
+    viper.SetDefault("stream.retryMillis", 3000)
+    viper.SetDefault("loyalty.pointsPerBooking", 100)
+    viper.SetDefault("loyalty.pointsPerGuest", 10)
+    viper.SetDefault("loyalty.noShowPenalty", 50)
+    viper.SetDefault("loyalty.tiers", []map[string]interface{}{
+        {"name": "bronze", "minPoints": 0, "perks": []string{}},
+        {"name": "silver", "minPoints": 1000, "perks": []string{"priority_waitlist"}},
+        {"name": "gold", "minPoints": 5000, "perks": []string{"priority_waitlist", "late_cancellation"}},
+    })
+    viper.SetDefault("geo.geocode", true)
+    viper.SetDefault("geo.defaultRadiusKm", 10)
+    viper.SetDefault("geo.maxRadiusKm", 100)
+    viper.SetDefault("media.maxUploadBytes", 5<<20)
+    viper.SetDefault("media.maxPixels", 25000000)
+    viper.SetDefault("media.maxGalleryImages", 20)
+    viper.SetDefault("media.thumbnailSize", 400)
+    viper.SetDefault("media.thumbnailQuality", 80)
+    viper.SetDefault("media.storage.driver", "local")
+    viper.SetDefault("media.storage.dir", "./uploads")
+    viper.SetDefault("media.storage.baseURL", "http://localhost:8080/media")
+    viper.SetDefault("membership.inviteTTLHours", 7*24)
+    viper.SetDefault("membership.inviteURL", "http://localhost:3000/invites/accept")
+    viper.SetDefault("authorization.cacheSeconds", 10)
+    viper.SetDefault("authorization.inviteTTLHours", 48)
+    viper.SetDefault("authorization.inviteURL", "http://localhost:3000/role-invites/accept")
+    viper.SetDefault("password.resetTTLMinutes", 60)
+    viper.SetDefault("password.resetURL", "http://localhost:3000/password/reset")
+    viper.SetDefault("verification.tokenTTLHours", 48)
+    viper.SetDefault("verification.resendCooldownSeconds", 60)
+    viper.SetDefault("verification.verifyURL", "http://localhost:3000/email/verify")
+    viper.SetDefault("verification.requiredFor", []string{"bookings"})
+    viper.SetDefault("login.store", "memory")
+    viper.SetDefault("login.windowMinutes", 15)
+    viper.SetDefault("login.accountMaxFailures", 5)
+    viper.SetDefault("login.ipMaxFailures", 20)
+    viper.SetDefault("login.lockoutMinutes", 15)
+    viper.SetDefault("login.delaySeconds", 1)
+    viper.SetDefault("login.maxDelaySeconds", 30)
+    viper.SetDefault("rateLimit.enabled", true)
+    viper.SetDefault("rateLimit.store", "memory")
+    viper.SetDefault("rateLimit.groups", map[string]interface{}{
+        "auth":   map[string]interface{}{"requestsPerMinute": 10, "burst": 5, "keyBy": "ip"},
+        "public": map[string]interface{}{"requestsPerMinute": 120, "burst": 60, "keyBy": "ip"},
+        "user":   map[string]interface{}{"requestsPerMinute": 300, "burst": 100, "keyBy": "user"},
+    })
+    viper.SetDefault("oidc.stateTTLMinutes", 10)
+    viper.SetDefault("oidc.timeoutSeconds", 10)
+    viper.SetDefault("apiKeys.cacheSeconds", 10)
+    viper.SetDefault("apiKeys.defaultRequestsPerMinute", 60)
+    viper.SetDefault("apiKeys.defaultBurst", 20)
+
     if err := viper.ReadInConfig(); err != nil {
         return nil, err
     }
@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// APIKeyScope grants a partner's API key access to one kind of partner route
+type APIKeyScope string
+
+const (
+	APIKeyScopeAvailabilityRead APIKeyScope = "availability:read"
+	APIKeyScopeBookingsRead     APIKeyScope = "bookings:read"
+	APIKeyScopeBookingsWrite    APIKeyScope = "bookings:write"
+)
+
+// APIKeyScopes lists the scopes a key can be granted
+var APIKeyScopes = []APIKeyScope{
+	APIKeyScopeAvailabilityRead,
+	APIKeyScopeBookingsRead,
+	APIKeyScopeBookingsWrite,
+}
+
+func (s APIKeyScope) IsValid() bool {
+	for _, scope := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey lets a partner call the partner routes on behalf of its user account. Only
+// a hash of the key is stored; Prefix is kept in the clear so administrators can
+// tell keys apart. A MonthlyQuota of 0 means the key has no quota.
+type APIKey struct {
+	ID                int64         `json:"id" db:"id"`
+	Partner           string        `json:"partner" db:"partner"`
+	UserID            int64         `json:"user_id" db:"user_id"`
+	Prefix            string        `json:"prefix" db:"prefix"`
+	KeyHash           string        `json:"-" db:"key_hash"`
+	Scopes            []APIKeyScope `json:"scopes" db:"-"`
+	RequestsPerMinute int           `json:"requests_per_minute" db:"requests_per_minute"`
+	Burst             int           `json:"burst" db:"burst"`
+	MonthlyQuota      int           `json:"monthly_quota" db:"monthly_quota"`
+	CreatedBy         *int64        `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+	RevokedAt         *time.Time    `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+func (k *APIKey) Has(scope APIKeyScope) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimit is the token bucket the key's requests are limited by
+func (k *APIKey) RateLimit() RateLimit {
+	return RateLimit{
+		Burst: k.Burst,
+		Rate:  float64(k.RequestsPerMinute) / 60,
+	}
+}
+
+// APIKeyUsage counts a key's requests in one calendar month (UTC)
+type APIKeyUsage struct {
+	APIKeyID      int64     `json:"api_key_id" db:"api_key_id"`
+	Month         time.Time `json:"month" db:"month"`
+	Requests      int64     `json:"requests" db:"requests"`
+	LastRequestAt time.Time `json:"last_request_at" db:"last_request_at"`
+}
+
+// UsageMonth returns the first day of t's month in UTC, which usage is counted by
+func UsageMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,10 @@
+package domain
+
+// AvailabilitySlot is a bookable start time with the tables free for its duration
+// and the deals a booking there would get
+type AvailabilitySlot struct {
+	StartTime       string  `json:"start_time"`
+	EndTime         string  `json:"end_time"`
+	AvailableTables int     `json:"available_tables"`
+	Deals           []*Deal `json:"deals"`
+}
@@ -10,12 +10,17 @@ const (
 	BookingStatusPending   BookingStatus = "pending"
 	BookingStatusConfirmed BookingStatus = "confirmed"
 	BookingStatusCancelled BookingStatus = "cancelled"
+	// Set by the restaurant once the booking has started
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusNoShow    BookingStatus = "no_show"
 )
 
 type Booking struct {
 	ID              int64         `json:"id" db:"id"`
 	UserID          int64         `json:"user_id" db:"user_id"`
 	TableID         int64         `json:"table_id" db:"table_id"`
+	SeriesID        *int64        `json:"series_id,omitempty" db:"series_id"`
+	APIKeyID        *int64        `json:"api_key_id,omitempty" db:"api_key_id"`
 	BookingDate     time.Time     `json:"booking_date" db:"booking_date"`
 	StartTime       string        `json:"start_time" db:"start_time"`
 	EndTime         string        `json:"end_time" db:"end_time"`
@@ -26,4 +31,40 @@ type Booking struct {
 	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
 	TableNumber     string        `json:"table_number" db:"table_number"`
 	RestaurantName  string        `json:"restaurant_name" db:"restaurant_name"`
+	// Populated from the table and restaurant when the booking is read back
+	RestaurantID       int64  `json:"restaurant_id" db:"restaurant_id"`
+	RestaurantAddress  string `json:"restaurant_address" db:"restaurant_address"`
+	RestaurantTimezone string `json:"restaurant_timezone" db:"restaurant_timezone"`
+	// Deals attached when the booking was made
+	Deals []*BookingDeal `json:"deals,omitempty" db:"-"`
+}
+
+// Location returns the restaurant's time zone, falling back to UTC
+func (b *Booking) Location() *time.Location {
+	loc, err := time.LoadLocation(b.RestaurantTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StartsAt combines the booking date and start time in the restaurant's time zone
+func (b *Booking) StartsAt() time.Time {
+	return b.at(b.StartTime)
+}
+
+// EndsAt combines the booking date and end time in the restaurant's time zone
+func (b *Booking) EndsAt() time.Time {
+	return b.at(b.EndTime)
+}
+
+func (b *Booking) at(clock string) time.Time {
+	t, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		t, _ = time.Parse("15:04", clock)
+	}
+	return time.Date(
+		b.BookingDate.Year(), b.BookingDate.Month(), b.BookingDate.Day(),
+		t.Hour(), t.Minute(), 0, 0, b.Location(),
+	)
 }
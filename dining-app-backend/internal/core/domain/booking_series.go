@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly   RecurrenceFrequency = "weekly"
+	RecurrenceBiweekly RecurrenceFrequency = "biweekly"
+	RecurrenceMonthly  RecurrenceFrequency = "monthly"
+)
+
+// MaxRecurrenceOccurrences caps how many bookings a single series may generate
+const MaxRecurrenceOccurrences = 52
+
+type BookingSeriesStatus string
+
+const (
+	BookingSeriesStatusActive    BookingSeriesStatus = "active"
+	BookingSeriesStatusCancelled BookingSeriesStatus = "cancelled"
+)
+
+// CancelScope controls how many occurrences of a series a cancellation affects
+type CancelScope string
+
+const (
+	CancelScopeOccurrence CancelScope = "occurrence"
+	CancelScopeFollowing  CancelScope = "following"
+	CancelScopeSeries     CancelScope = "series"
+)
+
+type BookingSeries struct {
+	ID              int64               `json:"id" db:"id"`
+	UserID          int64               `json:"user_id" db:"user_id"`
+	TableID         int64               `json:"table_id" db:"table_id"`
+	Frequency       RecurrenceFrequency `json:"frequency" db:"frequency"`
+	StartDate       time.Time           `json:"start_date" db:"start_date"`
+	UntilDate       *time.Time          `json:"until_date,omitempty" db:"until_date"`
+	OccurrenceCount int                 `json:"occurrence_count,omitempty" db:"occurrence_count"`
+	StartTime       string              `json:"start_time" db:"start_time"`
+	EndTime         string              `json:"end_time" db:"end_time"`
+	NumberOfGuests  int                 `json:"number_of_guests" db:"number_of_guests"`
+	SpecialRequests string              `json:"special_requests" db:"special_requests"`
+	Status          BookingSeriesStatus `json:"status" db:"status"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// BookingConflict describes an occurrence that could not be booked
+type BookingConflict struct {
+	Date   time.Time `json:"date"`
+	Reason string    `json:"reason"`
+}
+
+// RecurringBookingResult is returned when a series is created
+type RecurringBookingResult struct {
+	Series    *BookingSeries
+	Bookings  []*Booking
+	Conflicts []BookingConflict
+}
+
+// Validate checks that the recurrence has a known frequency and exactly one end
+// condition, and that it doesn't expand to more than MaxRecurrenceOccurrences bookings
+func (s *BookingSeries) Validate() error {
+	switch s.Frequency {
+	case RecurrenceWeekly, RecurrenceBiweekly, RecurrenceMonthly:
+	default:
+		return fmt.Errorf("unsupported recurrence frequency %q", s.Frequency)
+	}
+
+	if (s.UntilDate == nil) == (s.OccurrenceCount == 0) {
+		return errors.New("recurrence needs either an end date or an occurrence count")
+	}
+
+	// A count of 0 means the series runs until the end date
+	if s.OccurrenceCount < 0 || s.OccurrenceCount > MaxRecurrenceOccurrences {
+		return fmt.Errorf("occurrence count must be between 1 and %d, or 0 to use the end date", MaxRecurrenceOccurrences)
+	}
+
+	if s.UntilDate != nil {
+		if s.UntilDate.Before(s.StartDate) {
+			return errors.New("end date must not be before the start date")
+		}
+		// Occurrences stops at the cap, so an end date past it would cut the series short
+		if !s.occurrence(MaxRecurrenceOccurrences).After(*s.UntilDate) {
+			return fmt.Errorf("end date must be within %d occurrences of the start date", MaxRecurrenceOccurrences)
+		}
+	}
+
+	return nil
+}
+
+// Occurrences expands the recurrence into booking dates, starting with StartDate.
+// Monthly series repeat on the same weekday position as StartDate (e.g. 2nd Tuesday);
+// a start date in the fifth week of a month repeats on the last such weekday.
+func (s *BookingSeries) Occurrences() []time.Time {
+	var dates []time.Time
+
+	for i := 0; len(dates) < MaxRecurrenceOccurrences; i++ {
+		date := s.occurrence(i)
+		if s.UntilDate != nil && date.After(*s.UntilDate) {
+			break
+		}
+		dates = append(dates, date)
+		if s.OccurrenceCount > 0 && len(dates) == s.OccurrenceCount {
+			break
+		}
+	}
+
+	return dates
+}
+
+func (s *BookingSeries) occurrence(i int) time.Time {
+	switch s.Frequency {
+	case RecurrenceBiweekly:
+		return s.StartDate.AddDate(0, 0, 14*i)
+	case RecurrenceMonthly:
+		return nthWeekdayOfMonth(s.StartDate, i)
+	default:
+		return s.StartDate.AddDate(0, 0, 7*i)
+	}
+}
+
+// RRule renders the recurrence as an RFC 5545 RRULE value
+func (s *BookingSeries) RRule() string {
+	weekday := strings.ToUpper(s.StartDate.Weekday().String()[:2])
+
+	var parts []string
+	switch s.Frequency {
+	case RecurrenceWeekly:
+		parts = append(parts, "FREQ=WEEKLY", "INTERVAL=1", "BYDAY="+weekday)
+	case RecurrenceBiweekly:
+		parts = append(parts, "FREQ=WEEKLY", "INTERVAL=2", "BYDAY="+weekday)
+	case RecurrenceMonthly:
+		parts = append(parts, "FREQ=MONTHLY", fmt.Sprintf("BYDAY=%d%s", weekdayPosition(s.StartDate), weekday))
+	}
+
+	if s.OccurrenceCount > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", s.OccurrenceCount))
+	} else if s.UntilDate != nil {
+		parts = append(parts, "UNTIL="+s.UntilDate.Format("20060102"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// weekdayPosition returns 1-4 for the nth weekday of the month, or -1 for the fifth (last)
+func weekdayPosition(date time.Time) int {
+	position := (date.Day()-1)/7 + 1
+	if position > 4 {
+		return -1
+	}
+	return position
+}
+
+// nthWeekdayOfMonth returns the date monthOffset months after start that falls on the
+// same weekday position as start
+func nthWeekdayOfMonth(start time.Time, monthOffset int) time.Time {
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(monthOffset), 1, 0, 0, 0, 0, start.Location())
+	position := weekdayPosition(start)
+
+	if position == -1 {
+		lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
+		offset := (int(lastOfMonth.Weekday()) - int(start.Weekday()) + 7) % 7
+		return lastOfMonth.AddDate(0, 0, -offset)
+	}
+
+	offset := (int(start.Weekday()) - int(firstOfMonth.Weekday()) + 7) % 7
+	return firstOfMonth.AddDate(0, 0, offset+7*(position-1))
+}
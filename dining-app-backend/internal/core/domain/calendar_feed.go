@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// CalendarFeedToken grants read access to a user's booking calendar feed.
+// Only the SHA-256 hash of the token is stored.
+type CalendarFeedToken struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DealBenefitType string
+
+const (
+	DealBenefitPercentOff DealBenefitType = "percent_off"
+	DealBenefitFreeItem   DealBenefitType = "free_item"
+)
+
+// Deal is a restaurant promotion. Bookings that meet its eligibility rules get it
+// attached when they are made, until its usage caps are reached.
+type Deal struct {
+	ID           int64  `json:"id" db:"id"`
+	RestaurantID int64  `json:"restaurant_id" db:"restaurant_id"`
+	Title        string `json:"title" db:"title"`
+	Description  string `json:"description" db:"description"`
+
+	BenefitType     DealBenefitType `json:"benefit_type" db:"benefit_type"`
+	DiscountPercent int             `json:"discount_percent,omitempty" db:"discount_percent"`
+	// AppliesTo is what a discount covers: food, drinks or total
+	AppliesTo string `json:"applies_to,omitempty" db:"applies_to"`
+	FreeItem  string `json:"free_item,omitempty" db:"free_item"`
+
+	// Eligibility; zero values don't restrict. Days are 0 (Sunday) to 6 and the
+	// booking must start within [StartTime, EndTime).
+	DaysOfWeek          []int      `json:"days_of_week" db:"-"`
+	StartTime           string     `json:"start_time,omitempty" db:"start_time"`
+	EndTime             string     `json:"end_time,omitempty" db:"end_time"`
+	MinPartySize        int        `json:"min_party_size,omitempty" db:"min_party_size"`
+	MaxPartySize        int        `json:"max_party_size,omitempty" db:"max_party_size"`
+	FirstTimeGuestsOnly bool       `json:"first_time_guests_only" db:"first_time_guests_only"`
+	ValidFrom           *time.Time `json:"valid_from,omitempty" db:"valid_from"`
+	ValidUntil          *time.Time `json:"valid_until,omitempty" db:"valid_until"`
+
+	// Usage caps over bookings that aren't cancelled; 0 means unlimited
+	MaxRedemptions int `json:"max_redemptions,omitempty" db:"max_redemptions"`
+	MaxPerGuest    int `json:"max_per_guest,omitempty" db:"max_per_guest"`
+
+	Active    bool      `json:"active" db:"active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DealSlot describes a prospective booking for checking deal eligibility
+type DealSlot struct {
+	Date      time.Time
+	StartTime string
+	PartySize int
+	// FirstVisit is set when the guest hasn't booked the restaurant before; nil when
+	// the guest is unknown, as in availability searches
+	FirstVisit *bool
+}
+
+// DealUsage counts the bookings a deal is attached to
+type DealUsage struct {
+	Total    int
+	ForGuest int
+}
+
+// BookingDeal is a deal as it was when attached to a booking
+type BookingDeal struct {
+	BookingID int64  `json:"-" db:"booking_id"`
+	DealID    *int64 `json:"deal_id" db:"deal_id"`
+	Title     string `json:"title" db:"title"`
+	Benefit   string `json:"benefit" db:"benefit"`
+}
+
+// Validate checks the rules that tags can't express
+func (d *Deal) Validate() error {
+	switch d.BenefitType {
+	case DealBenefitPercentOff:
+		if d.DiscountPercent < 1 || d.DiscountPercent > 100 {
+			return fmt.Errorf("discount_percent must be between 1 and 100")
+		}
+	case DealBenefitFreeItem:
+		if d.FreeItem == "" {
+			return fmt.Errorf("free_item is required")
+		}
+	default:
+		return fmt.Errorf("unknown benefit type %q", d.BenefitType)
+	}
+
+	if (d.StartTime == "") != (d.EndTime == "") {
+		return fmt.Errorf("start_time and end_time must be set together")
+	}
+	if d.StartTime != "" {
+		start, ok := ParseClock(d.StartTime)
+		end, ok2 := ParseClock(d.EndTime)
+		if !ok || !ok2 || end <= start {
+			return fmt.Errorf("end_time must be after start_time")
+		}
+	}
+
+	if d.MaxPartySize > 0 && d.MaxPartySize < d.MinPartySize {
+		return fmt.Errorf("max_party_size must not be below min_party_size")
+	}
+	if d.ValidFrom != nil && d.ValidUntil != nil && d.ValidUntil.Before(*d.ValidFrom) {
+		return fmt.Errorf("valid_until must not be before valid_from")
+	}
+
+	return nil
+}
+
+// Matches reports whether a booking in the slot meets the deal's eligibility rules.
+// First-time-guest deals match unknown guests.
+func (d *Deal) Matches(slot DealSlot) bool {
+	if !d.Active {
+		return false
+	}
+
+	date := slot.Date.Format("2006-01-02")
+	if d.ValidFrom != nil && date < d.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	if d.ValidUntil != nil && date > d.ValidUntil.Format("2006-01-02") {
+		return false
+	}
+
+	if len(d.DaysOfWeek) > 0 && !containsInt(d.DaysOfWeek, int(slot.Date.Weekday())) {
+		return false
+	}
+
+	if d.StartTime != "" {
+		start, _ := ParseClock(d.StartTime)
+		end, _ := ParseClock(d.EndTime)
+		at, ok := ParseClock(slot.StartTime)
+		if !ok || at < start || at >= end {
+			return false
+		}
+	}
+
+	if slot.PartySize < d.MinPartySize || (d.MaxPartySize > 0 && slot.PartySize > d.MaxPartySize) {
+		return false
+	}
+
+	if d.FirstTimeGuestsOnly && slot.FirstVisit != nil && !*slot.FirstVisit {
+		return false
+	}
+
+	return true
+}
+
+// Available reports whether the usage caps leave room for another booking
+func (d *Deal) Available(usage DealUsage) bool {
+	if d.MaxRedemptions > 0 && usage.Total >= d.MaxRedemptions {
+		return false
+	}
+	if d.MaxPerGuest > 0 && usage.ForGuest >= d.MaxPerGuest {
+		return false
+	}
+	return true
+}
+
+// Benefit describes what the guest gets, as recorded on bookings
+func (d *Deal) Benefit() string {
+	switch d.BenefitType {
+	case DealBenefitPercentOff:
+		if d.AppliesTo == "" || d.AppliesTo == "total" {
+			return fmt.Sprintf("%d%% off", d.DiscountPercent)
+		}
+		return fmt.Sprintf("%d%% off %s", d.DiscountPercent, d.AppliesTo)
+	case DealBenefitFreeItem:
+		return "Free " + d.FreeItem
+	}
+	return d.Title
+}
+
+// clockLayouts are the time formats found in opening hours and bookings
+var clockLayouts = []string{"15:04:05", "15:04", "3:04 PM", "3:04PM"}
+
+// ParseClock returns the minutes after midnight of a time such as "18:30", "18:30:00"
+// or "6:30 PM"
+func ParseClock(value string) (int, bool) {
+	for _, layout := range clockLayouts {
+		if t, err := time.Parse(layout, strings.ToUpper(strings.TrimSpace(value))); err == nil {
+			return t.Hour()*60 + t.Minute(), true
+		}
+	}
+	return 0, false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
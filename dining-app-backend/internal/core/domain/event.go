@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventBookingCreated           EventType = "booking.created"
+	EventBookingConfirmed         EventType = "booking.confirmed"
+	EventBookingCancelled         EventType = "booking.cancelled"
+	EventBookingCompleted         EventType = "booking.completed"
+	EventBookingNoShow            EventType = "booking.no_show"
+	EventTableAvailabilityChanged EventType = "table.availability_changed"
+	EventUserRegistered           EventType = "user.registered"
+	EventRestaurantCreated        EventType = "restaurant.created"
+	EventRestaurantUpdated        EventType = "restaurant.updated"
+	EventRestaurantDeleted        EventType = "restaurant.deleted"
+)
+
+const (
+	AggregateBooking    = "booking"
+	AggregateTable      = "table"
+	AggregateUser       = "user"
+	AggregateRestaurant = "restaurant"
+)
+
+// Event is a domain event. It is written to the outbox in the same transaction as the
+// change it describes and dispatched to subscribers after commit.
+type Event struct {
+	ID            int64     `json:"id" db:"id"`
+	Type          EventType `json:"type" db:"event_type"`
+	AggregateType string    `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   int64     `json:"aggregate_id" db:"aggregate_id"`
+	Payload       string    `json:"payload" db:"payload"`
+	Attempts      int       `json:"attempts" db:"attempts"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+func newEvent(eventType EventType, aggregateType string, aggregateID int64, payload interface{}) *Event {
+	// Payloads are plain structs, so encoding them cannot fail
+	data, _ := json.Marshal(payload)
+	return &Event{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+	}
+}
+
+// Decode unmarshals the payload into v
+func (e *Event) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(e.Payload), v)
+}
+
+// EventFilter selects recorded events by type and by the restaurant or user named in
+// their payload. Zero values match everything.
+type EventFilter struct {
+	AfterID      int64
+	Types        []EventType
+	RestaurantID int64
+	UserID       int64
+	Limit        int
+}
+
+// BookingEventPayload describes a booking after the change. Occurrence is the
+// booking's position among those created or cancelled together by one recurring
+// booking operation, starting at 1; it is 0 for single bookings.
+type BookingEventPayload struct {
+	BookingID      int64         `json:"booking_id"`
+	UserID         int64         `json:"user_id"`
+	TableID        int64         `json:"table_id"`
+	RestaurantID   int64         `json:"restaurant_id"`
+	SeriesID       *int64        `json:"series_id,omitempty"`
+	Occurrence     int           `json:"occurrence,omitempty"`
+	BookingDate    time.Time     `json:"booking_date"`
+	StartTime      string        `json:"start_time"`
+	EndTime        string        `json:"end_time"`
+	Status         BookingStatus `json:"status"`
+	PreviousStatus BookingStatus `json:"previous_status,omitempty"`
+	// Automatic is set for changes made by the system rather than a user
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// NewBookingEventPayload describes the booking, which must already carry its new status
+func NewBookingEventPayload(booking *Booking, restaurantID int64, previous BookingStatus) *BookingEventPayload {
+	return &BookingEventPayload{
+		BookingID:      booking.ID,
+		UserID:         booking.UserID,
+		TableID:        booking.TableID,
+		RestaurantID:   restaurantID,
+		SeriesID:       booking.SeriesID,
+		BookingDate:    booking.BookingDate,
+		StartTime:      booking.StartTime,
+		EndTime:        booking.EndTime,
+		Status:         booking.Status,
+		PreviousStatus: previous,
+	}
+}
+
+func (p *BookingEventPayload) Event(eventType EventType) *Event {
+	return newEvent(eventType, AggregateBooking, p.BookingID, p)
+}
+
+// TableAvailabilityPayload reports a table becoming available or unavailable. Date is
+// set when the change was caused by a booking on that date.
+type TableAvailabilityPayload struct {
+	TableID      int64      `json:"table_id"`
+	RestaurantID int64      `json:"restaurant_id"`
+	IsAvailable  bool       `json:"is_available"`
+	Date         *time.Time `json:"date,omitempty"`
+}
+
+func NewTableAvailabilityEvent(tableID, restaurantID int64, isAvailable bool, date *time.Time) *Event {
+	return newEvent(EventTableAvailabilityChanged, AggregateTable, tableID, &TableAvailabilityPayload{
+		TableID:      tableID,
+		RestaurantID: restaurantID,
+		IsAvailable:  isAvailable,
+		Date:         date,
+	})
+}
+
+type UserRegisteredPayload struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+func NewUserRegisteredEvent(user *User) *Event {
+	return newEvent(EventUserRegistered, AggregateUser, user.ID, &UserRegisteredPayload{
+		UserID: user.ID,
+		Email:  user.Email,
+		Name:   user.Name,
+		Role:   user.Role,
+	})
+}
+
+type RestaurantEventPayload struct {
+	RestaurantID int64 `json:"restaurant_id"`
+}
+
+func NewRestaurantEvent(eventType EventType, restaurantID int64) *Event {
+	return newEvent(eventType, AggregateRestaurant, restaurantID, &RestaurantEventPayload{
+		RestaurantID: restaurantID,
+	})
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const earthRadiusKm = 6371.0
+
+// GeoPoint is a WGS84 coordinate in degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ParseGeoPoint parses a "lat,lng" pair
+func ParseGeoPoint(value string) (GeoPoint, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return GeoPoint{}, fmt.Errorf("expected lat,lng")
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("invalid latitude: %w", err)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("invalid longitude: %w", err)
+	}
+
+	point := GeoPoint{Latitude: lat, Longitude: lng}
+	return point, point.Validate()
+}
+
+func (p GeoPoint) Validate() error {
+	if math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// DistanceKm is the great-circle distance between the points, by the haversine formula
+func (p GeoPoint) DistanceKm(other GeoPoint) float64 {
+	dLat := radians(other.Latitude - p.Latitude)
+	dLng := radians(other.Longitude - p.Longitude)
+
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(p.Latitude))*math.Cos(radians(other.Latitude))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// GeoBounds is a latitude/longitude box
+type GeoBounds struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// Bounds returns a box that contains every point within radiusKm of p. Near the poles
+// or the antimeridian it spans all longitudes rather than wrapping around.
+func (p GeoPoint) Bounds(radiusKm float64) GeoBounds {
+	dLat := degrees(radiusKm / earthRadiusKm)
+	bounds := GeoBounds{
+		MinLatitude:  math.Max(-90, p.Latitude-dLat),
+		MaxLatitude:  math.Min(90, p.Latitude+dLat),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	if bounds.MinLatitude > -90 && bounds.MaxLatitude < 90 {
+		dLng := degrees(math.Asin(math.Min(1, math.Sin(radiusKm/earthRadiusKm)/math.Cos(radians(p.Latitude)))))
+		if p.Longitude-dLng >= -180 && p.Longitude+dLng <= 180 {
+			bounds.MinLongitude = p.Longitude - dLng
+			bounds.MaxLongitude = p.Longitude + dLng
+		}
+	}
+
+	return bounds
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
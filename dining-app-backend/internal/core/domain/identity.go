@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ExternalIdentity links a user to their account at an OpenID Connect provider. The
+// provider's subject identifies the account; the email is as last reported by it.
+type ExternalIdentity struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Provider    string    `json:"provider" db:"provider"`
+	Subject     string    `json:"subject" db:"subject"`
+	Email       string    `json:"email" db:"email"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at" db:"last_login_at"`
+}
+
+// OIDCLogin is a sign-in started with a provider and not finished yet. The callback
+// must bring back its state, and is checked against its PKCE code verifier and nonce.
+// Only a hash of the state is stored.
+type OIDCLogin struct {
+	ID           int64     `db:"id"`
+	StateHash    string    `db:"state_hash"`
+	Provider     string    `db:"provider"`
+	CodeVerifier string    `db:"code_verifier"`
+	Nonce        string    `db:"nonce"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// ExternalClaims is what a provider's verified ID token says about the user
+type ExternalClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// IdentityProviderInfo describes a provider users can sign in with
+type IdentityProviderInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ImageKind string
+
+const (
+	// ImageKindCover is the restaurant's single headline image
+	ImageKindCover   ImageKind = "cover"
+	ImageKindGallery ImageKind = "gallery"
+)
+
+// RestaurantImage is an uploaded photo. The original and its thumbnail are kept in
+// the blob store under the restaurant's prefix.
+type RestaurantImage struct {
+	ID           int64     `json:"id" db:"id"`
+	RestaurantID int64     `json:"restaurant_id" db:"restaurant_id"`
+	Kind         ImageKind `json:"kind" db:"kind"`
+	BlobKey      string    `json:"-" db:"blob_key"`
+	ThumbnailKey string    `json:"-" db:"thumbnail_key"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	Width        int       `json:"width" db:"width"`
+	Height       int       `json:"height" db:"height"`
+	SizeBytes    int64     `json:"size_bytes" db:"size_bytes"`
+	Position     int       `json:"position" db:"position"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	// Resolved from the keys by the blob store
+	URL          string `json:"url" db:"-"`
+	ThumbnailURL string `json:"thumbnail_url" db:"-"`
+}
+
+func (k ImageKind) IsValid() bool {
+	return k == ImageKindCover || k == ImageKindGallery
+}
+
+// RestaurantBlobPrefix is the blob key prefix under which a restaurant's images are stored
+func RestaurantBlobPrefix(restaurantID int64) string {
+	return fmt.Sprintf("restaurants/%d/", restaurantID)
+}
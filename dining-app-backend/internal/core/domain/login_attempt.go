@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// LoginAttempts counts the recent failed logins for an account or a client IP
+type LoginAttempts struct {
+	Key           string     `db:"key"`
+	Failures      int        `db:"failures"`
+	LastFailureAt time.Time  `db:"last_failure_at"`
+	LockedUntil   *time.Time `db:"locked_until"`
+}
+
+// Locked reports whether the key is locked out at the time
+func (a *LoginAttempts) Locked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// LockoutPolicy says when failed logins are forgotten and when they lock a key out
+type LockoutPolicy struct {
+	// Window is how long after the last failure the failures are forgotten
+	Window time.Duration
+	// MaxFailures locks the key out once reached
+	MaxFailures int
+	// Lockout is how long the key stays locked out
+	Lockout time.Duration
+}
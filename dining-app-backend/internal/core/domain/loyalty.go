@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+type LoyaltyEntryKind string
+
+const (
+	LoyaltyEarned     LoyaltyEntryKind = "earned"
+	LoyaltyRevoked    LoyaltyEntryKind = "revoked"
+	LoyaltyPenalty    LoyaltyEntryKind = "penalty"
+	LoyaltyAdjustment LoyaltyEntryKind = "adjustment"
+)
+
+// LoyaltyEntry is a line of a user's points ledger. Entries are never changed or
+// removed; corrections are new entries.
+type LoyaltyEntry struct {
+	ID           int64            `json:"id" db:"id"`
+	UserID       int64            `json:"user_id" db:"user_id"`
+	Kind         LoyaltyEntryKind `json:"kind" db:"kind"`
+	Points       int              `json:"points" db:"points"`
+	BookingID    *int64           `json:"booking_id,omitempty" db:"booking_id"`
+	RestaurantID *int64           `json:"restaurant_id,omitempty" db:"restaurant_id"`
+	Reason       string           `json:"reason" db:"reason"`
+	// CreatedBy is the admin who made an adjustment
+	CreatedBy *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// LoyaltyRules are a restaurant's accrual rules
+type LoyaltyRules struct {
+	RestaurantID     int64     `json:"restaurant_id" db:"restaurant_id"`
+	Enabled          bool      `json:"enabled" db:"enabled"`
+	PointsPerBooking int       `json:"points_per_booking" db:"points_per_booking"`
+	PointsPerGuest   int       `json:"points_per_guest" db:"points_per_guest"`
+	NoShowPenalty    int       `json:"no_show_penalty" db:"no_show_penalty"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PointsFor returns the points a completed booking for the party earns
+func (r *LoyaltyRules) PointsFor(guests int) int {
+	if !r.Enabled {
+		return 0
+	}
+	return r.PointsPerBooking + r.PointsPerGuest*guests
+}
+
+// LoyaltyTier is reached once a user's lifetime points get to MinPoints
+type LoyaltyTier struct {
+	Name      string   `json:"name"`
+	MinPoints int      `json:"min_points"`
+	Perks     []string `json:"perks"`
+}
+
+// LoyaltyAccount summarises a user's ledger. Lifetime points count points earned at
+// restaurants, net of revocations, so spending or adjustments don't lower the tier.
+type LoyaltyAccount struct {
+	UserID         int64        `json:"user_id"`
+	Balance        int          `json:"balance"`
+	LifetimePoints int          `json:"lifetime_points"`
+	Tier           *LoyaltyTier `json:"tier"`
+	NextTier       *LoyaltyTier `json:"next_tier,omitempty"`
+}
+
+// TierFor returns the highest tier the points reach and the one after it. Tiers must
+// be ordered by MinPoints.
+func TierFor(tiers []LoyaltyTier, points int) (current, next *LoyaltyTier) {
+	for i := range tiers {
+		if points >= tiers[i].MinPoints {
+			current = &tiers[i]
+			continue
+		}
+		return current, &tiers[i]
+	}
+	return current, nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MembershipRole is a user's role at one restaurant. Users whose platform role grants
+// the restaurant:write permission need none; they can manage every restaurant.
+type MembershipRole string
+
+const (
+	// MembershipRoleOwner manages the restaurant and its staff
+	MembershipRoleOwner MembershipRole = "owner"
+	// MembershipRoleManager manages the restaurant's details, tables, menu and deals
+	MembershipRoleManager MembershipRole = "manager"
+	// MembershipRoleHost works the floor: tables' availability and booking outcomes
+	MembershipRoleHost MembershipRole = "host"
+)
+
+var membershipRoleRanks = map[MembershipRole]int{
+	MembershipRoleHost:    1,
+	MembershipRoleManager: 2,
+	MembershipRoleOwner:   3,
+}
+
+func (r MembershipRole) IsValid() bool {
+	_, ok := membershipRoleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role grants everything the required role does
+func (r MembershipRole) Allows(required MembershipRole) bool {
+	rank, ok := membershipRoleRanks[r]
+	return ok && rank >= membershipRoleRanks[required]
+}
+
+// Membership links a user to a restaurant they work at
+type Membership struct {
+	ID             int64          `json:"id" db:"id"`
+	RestaurantID   int64          `json:"restaurant_id" db:"restaurant_id"`
+	UserID         int64          `json:"user_id" db:"user_id"`
+	Role           MembershipRole `json:"role" db:"role"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	UserName       string         `json:"user_name,omitempty" db:"user_name"`
+	UserEmail      string         `json:"user_email,omitempty" db:"user_email"`
+	RestaurantName string         `json:"restaurant_name,omitempty" db:"restaurant_name"`
+}
+
+// MembershipInvite offers a role at a restaurant to whoever signs in with the email.
+// Only the SHA-256 hash of the emailed token is stored.
+type MembershipInvite struct {
+	ID           int64          `json:"id" db:"id"`
+	RestaurantID int64          `json:"restaurant_id" db:"restaurant_id"`
+	Email        string         `json:"email" db:"email"`
+	Role         MembershipRole `json:"role" db:"role"`
+	TokenHash    string         `json:"-" db:"token_hash"`
+	InvitedBy    *int64         `json:"invited_by,omitempty" db:"invited_by"`
+	ExpiresAt    time.Time      `json:"expires_at" db:"expires_at"`
+	AcceptedAt   *time.Time     `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+}
+
+// NormalizeEmail is the form emails are compared and stored in for invites
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// RestaurantResource names a kind of record that belongs to a restaurant, so access to
+// it can be checked against the user's membership there
+type RestaurantResource string
+
+const (
+	ResourceRestaurant RestaurantResource = "restaurant"
+	ResourceTable      RestaurantResource = "table"
+	ResourceDeal       RestaurantResource = "deal"
+	ResourceBooking    RestaurantResource = "booking"
+)
+
+// ManagePermission is the permission that grants access to every resource of the kind,
+// whatever the user's memberships
+func (r RestaurantResource) ManagePermission() Permission {
+	if r == ResourceBooking {
+		return PermissionBookingManage
+	}
+	return PermissionRestaurantWrite
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type MenuStatus string
+
+const (
+	// MenuStatusDraft is the restaurant's working copy; there is at most one
+	MenuStatusDraft MenuStatus = "draft"
+	// MenuStatusPublished is the version guests see; there is at most one
+	MenuStatusPublished MenuStatus = "published"
+	// MenuStatusArchived versions were published before and can be rolled back to
+	MenuStatusArchived MenuStatus = "archived"
+)
+
+var DietaryFlags = []string{
+	"vegetarian", "vegan", "pescatarian", "gluten_free", "dairy_free", "nut_free", "halal", "kosher", "spicy",
+}
+
+// Allergens are the fourteen allergens that EU food law requires menus to declare
+var Allergens = []string{
+	"celery", "gluten", "crustaceans", "eggs", "fish", "lupin", "milk",
+	"molluscs", "mustard", "nuts", "peanuts", "sesame", "soy", "sulphites",
+}
+
+// Menu is one version of a restaurant's menu. Versions are immutable once published;
+// Version is 0 for the draft, which is numbered when it is published.
+type Menu struct {
+	ID           int64       `json:"id" db:"id"`
+	RestaurantID int64       `json:"restaurant_id" db:"restaurant_id"`
+	Version      int         `json:"version" db:"version"`
+	Status       MenuStatus  `json:"status" db:"status"`
+	Content      MenuContent `json:"content" db:"-"`
+	// BasedOnVersion is set on versions created by rolling back to an earlier one
+	BasedOnVersion *int       `json:"based_on_version,omitempty" db:"based_on_version"`
+	UpdatedBy      int64      `json:"updated_by" db:"updated_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	PublishedAt    *time.Time `json:"published_at,omitempty" db:"published_at"`
+}
+
+// MenuContent is the versioned part of a menu. Prices are in minor units of Currency.
+type MenuContent struct {
+	Currency string        `json:"currency"`
+	Dayparts []Daypart     `json:"dayparts"`
+	Sections []MenuSection `json:"sections"`
+}
+
+// Daypart is a named service period, such as lunch, in the restaurant's time zone
+type Daypart struct {
+	Name      string `json:"name"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type MenuSection struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Items       []MenuItem `json:"items"`
+}
+
+type MenuItem struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	PriceMinor   int64    `json:"price_minor"`
+	DietaryFlags []string `json:"dietary_flags"`
+	Allergens    []string `json:"allergens"`
+	// Dayparts lists the dayparts the item is served in; empty means all day
+	Dayparts []string `json:"dayparts"`
+}
+
+// Validate checks that items only refer to dayparts the menu defines
+func (c *MenuContent) Validate() error {
+	dayparts := make(map[string]bool, len(c.Dayparts))
+	for _, daypart := range c.Dayparts {
+		if dayparts[daypart.Name] {
+			return fmt.Errorf("daypart %q is defined more than once", daypart.Name)
+		}
+		dayparts[daypart.Name] = true
+	}
+
+	for _, section := range c.Sections {
+		for _, item := range section.Items {
+			for _, daypart := range item.Dayparts {
+				if !dayparts[daypart] {
+					return fmt.Errorf("item %q refers to undefined daypart %q", item.Name, daypart)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// MenuFilter narrows a menu down to what a guest can order. Zero values match all items.
+type MenuFilter struct {
+	Daypart      string
+	DietaryFlags []string
+}
+
+// Filter returns the items matching the filter, dropping sections left empty
+func (c MenuContent) Filter(filter MenuFilter) MenuContent {
+	if filter.Daypart == "" && len(filter.DietaryFlags) == 0 {
+		return c
+	}
+
+	filtered := c
+	filtered.Sections = []MenuSection{}
+	for _, section := range c.Sections {
+		items := []MenuItem{}
+		for _, item := range section.Items {
+			if item.servedIn(filter.Daypart) && item.hasFlags(filter.DietaryFlags) {
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			section.Items = items
+			filtered.Sections = append(filtered.Sections, section)
+		}
+	}
+
+	return filtered
+}
+
+func (i *MenuItem) servedIn(daypart string) bool {
+	if daypart == "" || len(i.Dayparts) == 0 {
+		return true
+	}
+	return containsString(i.Dayparts, daypart)
+}
+
+func (i *MenuItem) hasFlags(flags []string) bool {
+	for _, flag := range flags {
+		if !containsString(i.DietaryFlags, flag) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+)
+
+type NotificationKind string
+
+const (
+	NotificationBookingCreated   NotificationKind = "booking_created"
+	NotificationBookingConfirmed NotificationKind = "booking_confirmed"
+	NotificationBookingCancelled NotificationKind = "booking_cancelled"
+	NotificationBookingReminder  NotificationKind = "booking_reminder"
+	NotificationStaffInvite      NotificationKind = "staff_invite"
+	NotificationRoleInvite       NotificationKind = "role_invite"
+	NotificationPasswordReset    NotificationKind = "password_reset"
+	NotificationEmailVerify      NotificationKind = "email_verify"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// Notification is a rendered message queued for delivery. DedupKey is unique, so the
+// same message is never queued twice and retries resend the stored content.
+type Notification struct {
+	ID            int64               `json:"id" db:"id"`
+	UserID        *int64              `json:"user_id,omitempty" db:"user_id"`
+	BookingID     *int64              `json:"booking_id,omitempty" db:"booking_id"`
+	Kind          NotificationKind    `json:"kind" db:"kind"`
+	Channel       NotificationChannel `json:"channel" db:"channel"`
+	Recipient     string              `json:"recipient" db:"recipient"`
+	Subject       string              `json:"subject" db:"subject"`
+	Body          string              `json:"body" db:"body"`
+	DedupKey      string              `json:"-" db:"dedup_key"`
+	Status        NotificationStatus  `json:"status" db:"status"`
+	Attempts      int                 `json:"attempts" db:"attempts"`
+	LastError     string              `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time           `json:"next_attempt_at" db:"next_attempt_at"`
+	SentAt        *time.Time          `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+}
+
+// EmailMessage is a transactional email rendered from its kind's templates. It is sent
+// regardless of the recipient's preferences, who may not have an account yet.
+// Reference names what the message is about, so it is queued only once.
+type EmailMessage struct {
+	Kind      NotificationKind
+	UserID    *int64
+	Recipient string
+	Reference string
+	Data      interface{}
+}
+
+// NotificationPreferences are a user's opt-ins per channel
+type NotificationPreferences struct {
+	UserID           int64     `json:"user_id" db:"user_id"`
+	EmailEnabled     bool      `json:"email_enabled" db:"email_enabled"`
+	SMSEnabled       bool      `json:"sms_enabled" db:"sms_enabled"`
+	RemindersEnabled bool      `json:"reminders_enabled" db:"reminders_enabled"`
+	Phone            string    `json:"phone" db:"phone"`
+	Locale           string    `json:"locale" db:"locale"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences applies to users who never saved their preferences
+func DefaultNotificationPreferences(userID int64, locale string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:           userID,
+		EmailEnabled:     true,
+		RemindersEnabled: true,
+		Locale:           locale,
+	}
+}
+
+// Allows reports whether a message of the given kind may be sent on the channel
+func (p *NotificationPreferences) Allows(kind NotificationKind, channel NotificationChannel) bool {
+	if kind == NotificationBookingReminder && !p.RemindersEnabled {
+		return false
+	}
+
+	switch channel {
+	case NotificationChannelEmail:
+		return p.EmailEnabled
+	case NotificationChannelSMS:
+		return p.SMSEnabled && p.Phone != ""
+	default:
+		return false
+	}
+}
+
+// NotificationDedupKey identifies one message about one subject on one channel
+func NotificationDedupKey(kind NotificationKind, subject string, channel NotificationChannel) string {
+	return fmt.Sprintf("%s:%s:%s", kind, subject, channel)
+}
+
+// MessageContent is a template rendered for one locale. Short is used for SMS.
+type MessageContent struct {
+	Subject string
+	Body    string
+	Short   string
+}
+
+// For returns the subject and body to send on the channel
+func (m *MessageContent) For(channel NotificationChannel) (string, string) {
+	if channel == NotificationChannelSMS {
+		return "", m.Short
+	}
+	return m.Subject, m.Body
+}
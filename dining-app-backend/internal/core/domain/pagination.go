@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageRequest selects a page of a list, either by offset or by keyset cursor. A cursor
+// page continues after the last row already seen, so rows inserted or deleted in the
+// meantime don't shift it.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	// Cursor takes precedence over Offset when set
+	Cursor *Cursor
+}
+
+// Normalize applies the default page size and caps it at MaxPageSize
+func (p PageRequest) Normalize() PageRequest {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageSize
+	}
+	if p.Limit > MaxPageSize {
+		p.Limit = MaxPageSize
+	}
+	if p.Offset < 0 || p.Cursor != nil {
+		p.Offset = 0
+	}
+	return p
+}
+
+// Cursor points at the last row of a page by its sort key and ID. Sort records the
+// ordering the cursor was issued for, so it isn't replayed against another one.
+type Cursor struct {
+	Sort string `json:"s,omitempty"`
+	Key  string `json:"k,omitempty"`
+	ID   int64  `json:"id"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a token returned by Encode
+func ParseCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return &cursor, nil
+}
+
+// Page is one page of a list. Total is only counted for offset pages; cursor pages
+// are for walking large lists, where counting every row on each page is wasteful.
+type Page[T any] struct {
+	Items      []T
+	Total      *int
+	HasMore    bool
+	NextCursor *Cursor
+}
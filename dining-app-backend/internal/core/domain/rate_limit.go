@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket holding up to Burst tokens, refilled at Rate tokens per
+// second. Each request takes a token and is turned away when none is left.
+type RateLimit struct {
+	Burst int
+	Rate  float64
+}
+
+// RateLimitResult is the outcome of taking a token from a client's bucket
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until a turned away request would be let through
+	RetryAfter time.Duration
+}
+
+// Result describes a bucket left with the tokens after a request was let through or
+// turned away
+func (l RateLimit) Result(tokens float64, allowed bool) *RateLimitResult {
+	result := &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     l.Burst,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     l.refillTime(float64(l.Burst) - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = l.refillTime(1 - tokens)
+	}
+	return result
+}
+
+func (l RateLimit) refillTime(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.Rate * float64(time.Second))
+}
@@ -6,20 +6,97 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// DefaultTimezone is used for restaurants that don't specify an IANA time zone
+const DefaultTimezone = "UTC"
+
 type Restaurant struct {
-	ID          int64     `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name" validate:"required,min=2,max=100"`
-	Description string    `json:"description" db:"description"`
-	Address     string    `json:"address" db:"address" validate:"required"`
-	CuisineType string    `json:"cuisine_type" db:"cuisine_type" validate:"required"`
-	OpeningTime string    `json:"opening_time" db:"opening_time" validate:"required"`
-	ClosingTime string    `json:"closing_time" db:"closing_time" validate:"required"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
-	Tables      []*Table  `json:"tables"`
+	ID          int64  `json:"id" db:"id"`
+	Name        string `json:"name" db:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" db:"description"`
+	Address     string `json:"address" db:"address" validate:"required"`
+	CuisineType string `json:"cuisine_type" db:"cuisine_type" validate:"required"`
+	OpeningTime string `json:"opening_time" db:"opening_time" validate:"required"`
+	ClosingTime string `json:"closing_time" db:"closing_time" validate:"required"`
+	Timezone    string `json:"timezone" db:"timezone" validate:"omitempty,timezone"`
+	// Set by an admin or geocoded from the address; nil when unknown
+	Latitude  *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude *float64  `json:"longitude,omitempty" db:"longitude"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Tables    []*Table  `json:"tables"`
+	// Covers come first, then the gallery in order
+	Images []*RestaurantImage `json:"images"`
+	// Aggregated over visible reviews
+	AverageRating float64 `json:"average_rating" db:"average_rating"`
+	ReviewCount   int     `json:"review_count" db:"review_count"`
 }
 
 func (r *Restaurant) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// Location returns the restaurant's coordinates, or nil if they are unknown
+func (r *Restaurant) Location() *GeoPoint {
+	if r.Latitude == nil || r.Longitude == nil {
+		return nil
+	}
+	return &GeoPoint{Latitude: *r.Latitude, Longitude: *r.Longitude}
+}
+
+// SetLocation stores the coordinates, or clears them when point is nil
+func (r *Restaurant) SetLocation(point *GeoPoint) {
+	if point == nil {
+		r.Latitude, r.Longitude = nil, nil
+		return
+	}
+	lat, lng := point.Latitude, point.Longitude
+	r.Latitude, r.Longitude = &lat, &lng
+}
+
+func (r *Restaurant) SetDefaults() {
+	if r.Timezone == "" {
+		r.Timezone = DefaultTimezone
+	}
+}
+
+type RestaurantSort string
+
+const (
+	RestaurantSortRelevance RestaurantSort = "relevance"
+	RestaurantSortName      RestaurantSort = "name"
+	RestaurantSortRating    RestaurantSort = "rating"
+	RestaurantSortNewest    RestaurantSort = "newest"
+	RestaurantSortDistance  RestaurantSort = "distance"
+)
+
+// SupportsCursor reports whether results in this order can be paged by cursor. The
+// computed orders, such as relevance and distance, can only be paged by offset.
+func (s RestaurantSort) SupportsCursor() bool {
+	return s == RestaurantSortName || s == RestaurantSortNewest
+}
+
+// RestaurantSearch narrows down and orders the restaurant listing. An empty query
+// matches every restaurant.
+type RestaurantSearch struct {
+	Query    string
+	Cuisines []string
+	// OpenNow keeps restaurants open at this moment in their own time zone
+	OpenNow bool
+	// Near keeps restaurants with known coordinates within RadiusKm of the point
+	Near     *GeoPoint
+	RadiusKm float64
+	Sort     RestaurantSort
+	Page     PageRequest
+}
+
+// RestaurantSearchResult is a restaurant with how well it matched the query. The
+// highlights mark matched terms with <mark> tags and are empty without a query.
+type RestaurantSearchResult struct {
+	Restaurant
+	Rank                 float64 `db:"rank"`
+	NameHighlight        string  `db:"name_highlight"`
+	DescriptionHighlight string  `db:"description_highlight"`
+	// DistanceKm is set when searching near a point
+	DistanceKm *float64 `db:"distance_km"`
+}
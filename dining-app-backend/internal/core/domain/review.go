@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Review is a guest's rating of a restaurant, written after a booking there. Hidden
+// reviews are kept for their author but left out of listings and ratings.
+type Review struct {
+	ID           int64     `json:"id" db:"id"`
+	RestaurantID int64     `json:"restaurant_id" db:"restaurant_id"`
+	UserID       int64     `json:"user_id" db:"user_id"`
+	BookingID    int64     `json:"booking_id" db:"booking_id"`
+	Rating       int       `json:"rating" db:"rating"`
+	Comment      string    `json:"comment" db:"comment"`
+	Hidden       bool      `json:"hidden" db:"hidden"`
+	HiddenReason string    `json:"hidden_reason,omitempty" db:"hidden_reason"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	// Populated from the author when the review is read back
+	UserName string `json:"user_name" db:"user_name"`
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// Permission grants one kind of platform-wide action. Roles group permissions and every
+// user has exactly one role.
+type Permission string
+
+const (
+	// PermissionRestaurantWrite creates restaurants and manages any restaurant, its
+	// tables, deals, menu and staff, regardless of membership
+	PermissionRestaurantWrite Permission = "restaurant:write"
+	// PermissionBookingManage manages any restaurant's bookings
+	PermissionBookingManage Permission = "booking:manage"
+	// PermissionUserRead views other users' accounts
+	PermissionUserRead Permission = "user:read"
+	// PermissionUserWrite changes other users' accounts, such as their loyalty points
+	PermissionUserWrite Permission = "user:write"
+	// PermissionReviewModerate hides and restores reviews
+	PermissionReviewModerate Permission = "review:moderate"
+	// PermissionWebhookManage manages webhook endpoints
+	PermissionWebhookManage Permission = "webhook:manage"
+	// PermissionRoleManage edits roles and assigns them to users
+	PermissionRoleManage Permission = "role:manage"
+	// PermissionAPIKeyManage issues and revokes partners' API keys and views their usage
+	PermissionAPIKeyManage Permission = "apikey:manage"
+)
+
+// Permissions lists every permission, in the order they are documented
+var Permissions = []Permission{
+	PermissionRestaurantWrite,
+	PermissionBookingManage,
+	PermissionUserRead,
+	PermissionUserWrite,
+	PermissionReviewModerate,
+	PermissionWebhookManage,
+	PermissionRoleManage,
+	PermissionAPIKeyManage,
+}
+
+func (p Permission) IsValid() bool {
+	for _, permission := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,29}$`)
+
+// ValidRoleName reports whether the name can be used for a new role
+func ValidRoleName(name string) bool {
+	return roleNamePattern.MatchString(name)
+}
+
+// Role is a named set of permissions stored in the database, so it can be changed
+// without a deploy. System roles can't be deleted.
+type Role struct {
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	Permissions []Permission `json:"permissions" db:"-"`
+	System      bool         `json:"system" db:"system"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Has reports whether the role grants the permission
+func (r *Role) Has(permission Permission) bool {
+	for _, granted := range r.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleInvite offers a role to whoever signs in with the email. It is how administrators
+// are added once the first one exists. Only the SHA-256 hash of the emailed token is
+// stored.
+type RoleInvite struct {
+	ID         int64      `json:"id" db:"id"`
+	Email      string     `json:"email" db:"email"`
+	Role       string     `json:"role" db:"role"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	InvitedBy  *int64     `json:"invited_by,omitempty" db:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// RoleChangeSource records how a user came to have a role
+type RoleChangeSource string
+
+const (
+	// RoleChangeBootstrap is the first administrator, promoted from the command line
+	RoleChangeBootstrap RoleChangeSource = "bootstrap"
+	// RoleChangeAssignment is a role assigned by a user with the role:manage permission
+	RoleChangeAssignment RoleChangeSource = "assignment"
+	// RoleChangeInvite is a role invite the user accepted
+	RoleChangeInvite RoleChangeSource = "invite"
+)
+
+// RoleChange is an audit record of a user's role changing. Role names are copied, so
+// the record outlives the roles.
+type RoleChange struct {
+	ID        int64            `json:"id" db:"id"`
+	UserID    int64            `json:"user_id" db:"user_id"`
+	OldRole   string           `json:"old_role" db:"old_role"`
+	NewRole   string           `json:"new_role" db:"new_role"`
+	ChangedBy *int64           `json:"changed_by,omitempty" db:"changed_by"`
+	Source    RoleChangeSource `json:"source" db:"source"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
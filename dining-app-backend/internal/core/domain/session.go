@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Session is one signed-in device. Access tokens name the session they were issued
+// for, so revoking it signs the device out before they expire.
+type Session struct {
+	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	IPAddress  string     `json:"ip_address" db:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Active reports whether the session can still be used
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one link in a session's chain of refresh tokens. Each is used once,
+// in exchange for the next; presenting a used one again means it leaked, and the
+// session is revoked. Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        int64      `json:"id" db:"id"`
+	SessionID int64      `json:"session_id" db:"session_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TokenPair is what signing in or refreshing returns
+type TokenPair struct {
+	SessionID             int64
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+// EventStream is a client's subscription to live events. Events is closed when the
+// subscription ends, including when the client falls too far behind to keep up.
+type EventStream struct {
+	// Replay holds the events recorded after the client's last seen event
+	Replay []*Event
+	// Reset is set instead of Replay when more events were missed than can be
+	// replayed; the client should reload the current state
+	Reset  bool
+	Events <-chan *Event
+}
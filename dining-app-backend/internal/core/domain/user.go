@@ -6,19 +6,20 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-const (
-	RoleUser  = "user"
-	RoleAdmin = "admin"
-)
+// RoleUser is the role new users get. Roles and their permissions live in the
+// database.
+const RoleUser = "user"
 
 type User struct {
-	ID        int64     `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name" validate:"required,min=2,max=100"`
-	Email     string    `json:"email" db:"email" validate:"required,email"`
-	Password  string    `json:"password,omitempty" db:"password" validate:"required,min=6"`
-	Role      string    `json:"role" db:"role"` // Remove required validation
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID              int64      `json:"id" db:"id"`
+	Name            string     `json:"name" db:"name" validate:"required,min=2,max=100"`
+	Email           string     `json:"email" db:"email" validate:"required,email"`
+	Password        string     `json:"password,omitempty" db:"password" validate:"required,min=6"`
+	Role            string     `json:"role" db:"role"` // Remove required validation
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	PendingEmail    *string    `json:"pending_email,omitempty" db:"pending_email"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 func (u *User) Validate() error {
@@ -26,9 +27,34 @@ func (u *User) Validate() error {
 	return validate.Struct(u)
 }
 
+// EmailVerified reports whether the user has proven they own their email address
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // New method to set defaults
 func (u *User) SetDefaults() {
 	if u.Role == "" {
 		u.Role = RoleUser
 	}
 }
+
+// PasswordReset lets whoever holds the emailed token set a new password, once. Only
+// the SHA-256 hash of the token is stored.
+type PasswordReset struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// VerifiedAction is something that can be restricted to users with a verified email
+// address
+type VerifiedAction string
+
+const (
+	VerifiedActionBookings VerifiedAction = "bookings"
+	VerifiedActionReviews  VerifiedAction = "reviews"
+)
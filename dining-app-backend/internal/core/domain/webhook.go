@@ -0,0 +1,101 @@
+package domain
+
+import "time"
+
+type WebhookEventType string
+
+const (
+	WebhookBookingCreated       WebhookEventType = "booking.created"
+	WebhookBookingStatusChanged WebhookEventType = "booking.status_changed"
+	WebhookRestaurantCreated    WebhookEventType = "restaurant.created"
+	WebhookRestaurantUpdated    WebhookEventType = "restaurant.updated"
+	WebhookRestaurantDeleted    WebhookEventType = "restaurant.deleted"
+
+	// WebhookAllEvents subscribes an endpoint to every event type
+	WebhookAllEvents WebhookEventType = "*"
+)
+
+// WebhookEventTypes lists the event types endpoints can subscribe to
+var WebhookEventTypes = []WebhookEventType{
+	WebhookBookingCreated,
+	WebhookBookingStatusChanged,
+	WebhookRestaurantCreated,
+	WebhookRestaurantUpdated,
+	WebhookRestaurantDeleted,
+}
+
+type WebhookEndpoint struct {
+	ID          int64              `json:"id" db:"id"`
+	URL         string             `json:"url" db:"url"`
+	Description string             `json:"description" db:"description"`
+	Secret      string             `json:"-" db:"secret"`
+	Events      []WebhookEventType `json:"events" db:"-"`
+	Active      bool               `json:"active" db:"active"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// Subscribes reports whether the endpoint's event filter matches the event type
+func (e *WebhookEndpoint) Subscribes(event WebhookEventType) bool {
+	for _, subscribed := range e.Events {
+		if subscribed == event || subscribed == WebhookAllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one attempt sequence to deliver an event to an endpoint. An
+// endpoint gets one delivery per event; manual redeliveries create another with the
+// same EventID.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id" db:"id"`
+	EndpointID     int64                 `json:"endpoint_id" db:"endpoint_id"`
+	EventID        string                `json:"event_id" db:"event_id"`
+	EventType      WebhookEventType      `json:"event_type" db:"event_type"`
+	Redelivery     bool                  `json:"redelivery" db:"redelivery"`
+	Payload        string                `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	ResponseStatus *int                  `json:"response_status,omitempty" db:"response_status"`
+	ResponseBody   string                `json:"response_body,omitempty" db:"response_body"`
+	LastError      string                `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+}
+
+// WebhookEvent is the JSON envelope posted to endpoints
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// WebhookResponse is what the endpoint answered to a delivery attempt
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookBookingData is the data of booking events
+type WebhookBookingData struct {
+	Booking        *Booking      `json:"booking"`
+	PreviousStatus BookingStatus `json:"previous_status,omitempty"`
+}
+
+// WebhookRestaurantData is the data of restaurant events. Restaurant is omitted once
+// the restaurant has been deleted.
+type WebhookRestaurantData struct {
+	RestaurantID int64       `json:"restaurant_id"`
+	Restaurant   *Restaurant `json:"restaurant,omitempty"`
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore keeps uploaded files under slash-separated keys
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data io.Reader) error
+	// Delete succeeds if the blob doesn't exist
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes every blob whose key starts with the prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+	// URL returns where clients can fetch the blob
+	URL(key string) string
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// Transactor runs fn in a database transaction. Repository calls made with the
+// context passed to fn take part in it, which is how state changes and their outbox
+// events are committed together.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventHandler reacts to a domain event. Events are delivered at least once, so
+// handlers must tolerate duplicates.
+type EventHandler func(ctx context.Context, event *domain.Event) error
+
+// EventBus routes committed domain events to subscribers
+type EventBus interface {
+	// Subscribe registers a handler that runs once per event, on the replica that
+	// dispatches it from the outbox. A failing handler makes the event be retried.
+	Subscribe(eventType domain.EventType, handler EventHandler)
+	// SubscribeBroadcast registers a handler that runs on every replica, for
+	// replica-local state such as open client connections. Failures are only logged.
+	SubscribeBroadcast(eventType domain.EventType, handler EventHandler)
+	Dispatch(ctx context.Context, event *domain.Event) error
+	Broadcast(ctx context.Context, event *domain.Event)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// Geocoder resolves a free-text address to coordinates. A nil point means the
+// address wasn't recognised.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (*domain.GeoPoint, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// IdentityProvider is an OpenID Connect provider users can sign in with, using the
+// authorization code flow with PKCE
+type IdentityProvider interface {
+	Info() domain.IdentityProviderInfo
+	// AuthorizationURL is where to send the user to sign in at the provider
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the code the provider sent back, and returns the claims of the
+	// ID token once its signature, issuer, audience, expiry and nonce are checked
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalClaims, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// LoginAttemptStore counts failed logins under keys such as an account's email or a
+// client IP. A store shared by every replica is needed for the counts to hold when the
+// API runs on more than one instance.
+type LoginAttemptStore interface {
+	// Get returns the key's attempts, or nil when it has none
+	Get(ctx context.Context, key string) (*domain.LoginAttempts, error)
+	// RecordFailure adds a failure to the key and locks it out when the failures reach
+	// the policy's limit. Failures outside the window, or from before an expired
+	// lockout, are forgotten first.
+	RecordFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (*domain.LoginAttempts, error)
+	// Reset forgets the key's failures and lifts its lockout
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// Notifier delivers a queued notification over a single channel
+type Notifier interface {
+	Channel() domain.NotificationChannel
+	Send(ctx context.Context, notification *domain.Notification) error
+}
+
+// SMSProvider is the gateway used by the SMS notifier
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to, body string) error
+}
+
+// MessageRenderer renders a notification template for a locale
+type MessageRenderer interface {
+	Render(kind domain.NotificationKind, locale string, data interface{}) (*domain.MessageContent, error)
+}
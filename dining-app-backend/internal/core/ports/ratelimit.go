@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// RateLimitStore keeps the token buckets of rate limited clients. Taking a token must
+// be atomic, so replicas sharing a store can't let more requests through than the
+// bucket holds.
+type RateLimitStore interface {
+	// Take takes a token from the key's bucket, which starts full, if one is left
+	Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error)
+}
@@ -12,13 +12,18 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id int64) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	UpdateRole(ctx context.Context, id int64, role string) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	SetPendingEmail(ctx context.Context, id int64, email *string) error
+	ClaimVerificationSend(ctx context.Context, id int64, cooldown time.Duration) (bool, error)
+	MarkEmailVerified(ctx context.Context, id int64, email string) error
 	Delete(ctx context.Context, id int64) error
 }
 
 type RestaurantRepository interface {
 	Create(ctx context.Context, restaurant *domain.Restaurant) error
 	GetByID(ctx context.Context, id int64) (*domain.Restaurant, error)
-	List(ctx context.Context, offset, limit int) ([]*domain.Restaurant, error)
+	Search(ctx context.Context, search domain.RestaurantSearch) (*domain.Page[*domain.RestaurantSearchResult], error)
 	Update(ctx context.Context, restaurant *domain.Restaurant) error
 	Delete(ctx context.Context, id int64) error
 }
@@ -27,6 +32,7 @@ type TableRepository interface {
 	Create(ctx context.Context, table *domain.Table) error
 	GetByID(ctx context.Context, id int64) (*domain.Table, error)
 	GetByRestaurantID(ctx context.Context, restaurantID int64) ([]*domain.Table, error)
+	ListByRestaurantID(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Table], error)
 	UpdateAvailability(ctx context.Context, tableID int64, isAvailable bool) error
 	Delete(ctx context.Context, id int64) error
 }
@@ -35,7 +41,189 @@ type BookingRepository interface {
 	Create(ctx context.Context, booking *domain.Booking) error
 	GetByID(ctx context.Context, id int64) (*domain.Booking, error)
 	GetUserBookings(ctx context.Context, userID int64) ([]*domain.Booking, error)
+	ListUserBookings(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Booking], error)
 	CheckTableAvailability(ctx context.Context, tableID int64, date time.Time, startTime, endTime string) (bool, error)
 	UpdateStatus(ctx context.Context, bookingID int64, status domain.BookingStatus) error
+	GetStartingBetween(ctx context.Context, from, to time.Time) ([]*domain.Booking, error)
+	LockPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Booking, error)
+	GetRestaurantBookings(ctx context.Context, restaurantID int64, date time.Time) ([]*domain.Booking, error)
+	HasBookedRestaurant(ctx context.Context, userID, restaurantID int64) (bool, error)
 	Delete(ctx context.Context, id int64) error
 }
+
+type BookingSeriesRepository interface {
+	Create(ctx context.Context, series *domain.BookingSeries, bookings []*domain.Booking) error
+	GetByID(ctx context.Context, id int64) (*domain.BookingSeries, error)
+	GetOccurrences(ctx context.Context, seriesID int64) ([]*domain.Booking, error)
+	CancelFrom(ctx context.Context, seriesID int64, from time.Time, status domain.BookingSeriesStatus) ([]int64, error)
+}
+
+type CalendarFeedRepository interface {
+	Create(ctx context.Context, token *domain.CalendarFeedToken) error
+	GetActiveByHash(ctx context.Context, tokenHash string) (*domain.CalendarFeedToken, error)
+	RevokeForUser(ctx context.Context, userID int64) error
+}
+
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, notification *domain.Notification) (bool, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.Notification, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error
+}
+
+type NotificationPreferenceRepository interface {
+	Get(ctx context.Context, userID int64) (*domain.NotificationPreferences, error)
+	Upsert(ctx context.Context, preferences *domain.NotificationPreferences) error
+}
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+	ListSubscribedEndpoints(ctx context.Context, eventType domain.WebhookEventType) ([]*domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+	EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, endpointID int64, page domain.PageRequest) (*domain.Page[*domain.WebhookDelivery], error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+	MarkDeliverySucceeded(ctx context.Context, id int64, response *domain.WebhookResponse) error
+	MarkDeliveryFailed(ctx context.Context, id int64, response *domain.WebhookResponse, lastError string, nextAttemptAt *time.Time) error
+}
+
+type OutboxRepository interface {
+	Append(ctx context.Context, events ...*domain.Event) error
+	ClaimUnpublished(ctx context.Context, limit int, lease time.Duration) ([]*domain.Event, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error
+	// Notify sends just the event's ID, since NOTIFY payloads are limited to 8000 bytes;
+	// listeners load the event with GetByID
+	Notify(ctx context.Context, channel string, id int64) error
+	GetByID(ctx context.Context, id int64) (*domain.Event, error)
+	ListDispatched(ctx context.Context, filter domain.EventFilter) ([]*domain.Event, error)
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *domain.Review) error
+	GetByID(ctx context.Context, id int64) (*domain.Review, error)
+	ListVisible(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Review], error)
+	Update(ctx context.Context, review *domain.Review) error
+	Delete(ctx context.Context, id int64) error
+	SetHidden(ctx context.Context, id int64, hidden bool, reason string) error
+}
+
+type MenuRepository interface {
+	GetDraft(ctx context.Context, restaurantID int64) (*domain.Menu, error)
+	GetPublished(ctx context.Context, restaurantID int64) (*domain.Menu, error)
+	GetVersion(ctx context.Context, restaurantID int64, version int) (*domain.Menu, error)
+	ListVersions(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Menu], error)
+	SaveDraft(ctx context.Context, menu *domain.Menu) error
+	PublishDraft(ctx context.Context, restaurantID int64, userID int64) (*domain.Menu, error)
+	Republish(ctx context.Context, restaurantID int64, fromVersion int, userID int64) (*domain.Menu, error)
+}
+
+type DealRepository interface {
+	Create(ctx context.Context, deal *domain.Deal) error
+	GetByID(ctx context.Context, id int64) (*domain.Deal, error)
+	ListByRestaurant(ctx context.Context, restaurantID int64, activeOnly bool) ([]*domain.Deal, error)
+	Update(ctx context.Context, deal *domain.Deal) error
+	Delete(ctx context.Context, id int64) error
+	Lock(ctx context.Context, dealIDs []int64) error
+	GetUsage(ctx context.Context, dealIDs []int64, userID int64) (map[int64]domain.DealUsage, error)
+	Attach(ctx context.Context, bookingID int64, deals []*domain.BookingDeal) error
+	GetBookingDeals(ctx context.Context, bookingIDs []int64) ([]*domain.BookingDeal, error)
+}
+
+type LoyaltyRepository interface {
+	Append(ctx context.Context, entry *domain.LoyaltyEntry) (bool, error)
+	GetBookingEntry(ctx context.Context, bookingID int64, kind domain.LoyaltyEntryKind) (*domain.LoyaltyEntry, error)
+	GetTotals(ctx context.Context, userID int64) (balance int, lifetime int, err error)
+	ListEntries(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.LoyaltyEntry], error)
+	GetRules(ctx context.Context, restaurantID int64) (*domain.LoyaltyRules, error)
+	SaveRules(ctx context.Context, rules *domain.LoyaltyRules) error
+}
+
+type RestaurantImageRepository interface {
+	Create(ctx context.Context, image *domain.RestaurantImage) error
+	GetByID(ctx context.Context, id int64) (*domain.RestaurantImage, error)
+	GetCover(ctx context.Context, restaurantID int64) (*domain.RestaurantImage, error)
+	ListByRestaurants(ctx context.Context, restaurantIDs []int64, kind domain.ImageKind) ([]*domain.RestaurantImage, error)
+	Count(ctx context.Context, restaurantID int64, kind domain.ImageKind) (int, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type MembershipRepository interface {
+	GetRestaurantID(ctx context.Context, resource domain.RestaurantResource, id int64) (int64, error)
+	Create(ctx context.Context, membership *domain.Membership) error
+	Get(ctx context.Context, restaurantID, userID int64) (*domain.Membership, error)
+	ListByRestaurant(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Membership], error)
+	ListByUser(ctx context.Context, userID int64) ([]*domain.Membership, error)
+	LockOwners(ctx context.Context, restaurantID int64) ([]int64, error)
+	UpdateRole(ctx context.Context, restaurantID, userID int64, role domain.MembershipRole) error
+	Delete(ctx context.Context, restaurantID, userID int64) error
+	CreateInvite(ctx context.Context, invite *domain.MembershipInvite) error
+	GetPendingInvite(ctx context.Context, tokenHash string) (*domain.MembershipInvite, error)
+	ListPendingInvites(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.MembershipInvite], error)
+	MarkInviteAccepted(ctx context.Context, id int64) error
+	DeleteInvite(ctx context.Context, restaurantID, inviteID int64) error
+}
+
+type RoleRepository interface {
+	List(ctx context.Context) ([]*domain.Role, error)
+	Get(ctx context.Context, name string) (*domain.Role, error)
+	GetUserRole(ctx context.Context, userID int64) (*domain.Role, error)
+	Create(ctx context.Context, role *domain.Role) error
+	Update(ctx context.Context, role *domain.Role) error
+	Delete(ctx context.Context, name string) error
+	CountUsersWithPermission(ctx context.Context, permission domain.Permission, exceptRole string, exceptUserID int64) (int, error)
+	CreateInvite(ctx context.Context, invite *domain.RoleInvite) error
+	GetPendingInvite(ctx context.Context, tokenHash string) (*domain.RoleInvite, error)
+	ListPendingInvites(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.RoleInvite], error)
+	MarkInviteAccepted(ctx context.Context, id int64) error
+	DeleteInvite(ctx context.Context, id int64) error
+	RecordChange(ctx context.Context, change *domain.RoleChange) error
+	ListChanges(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.RoleChange], error)
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	Get(ctx context.Context, id int64) (*domain.Session, error)
+	IsActive(ctx context.Context, userID, id int64) (bool, error)
+	ListActive(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Session], error)
+	Touch(ctx context.Context, session *domain.Session) error
+	Revoke(ctx context.Context, userID, id int64) error
+	RevokeAll(ctx context.Context, userID, exceptID int64) ([]int64, error)
+	CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error
+	LockRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id int64) error
+}
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *domain.PasswordReset) error
+	LockPending(ctx context.Context, tokenHash string) (*domain.PasswordReset, error)
+	MarkUsed(ctx context.Context, id int64) error
+}
+
+type IdentityRepository interface {
+	CreateLogin(ctx context.Context, login *domain.OIDCLogin) error
+	// ConsumeLogin removes and returns the unexpired login with the state hash
+	ConsumeLogin(ctx context.Context, stateHash string) (*domain.OIDCLogin, error)
+	GetIdentity(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error)
+	CreateIdentity(ctx context.Context, identity *domain.ExternalIdentity) error
+	RecordIdentityLogin(ctx context.Context, id int64, email string) error
+	ListIdentities(ctx context.Context, userID int64) ([]*domain.ExternalIdentity, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id int64) (*domain.APIKey, error)
+	// GetActiveByHash returns the unrevoked key with the hash
+	GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.APIKey], error)
+	Revoke(ctx context.Context, id int64) error
+	// RecordRequest counts a request in the month, unless the month's count has
+	// reached quota, and reports whether it was counted. A quota of 0 is unlimited.
+	RecordRequest(ctx context.Context, id int64, month time.Time, quota int) (*domain.APIKeyUsage, bool, error)
+	// ListUsage returns the key's monthly counts, latest month first
+	ListUsage(ctx context.Context, id int64, months int) ([]*domain.APIKeyUsage, error)
+}
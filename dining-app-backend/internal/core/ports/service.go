@@ -2,32 +2,216 @@ package ports
 
 import (
     "context"
+    "io"
+    "time"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 )
 
 type UserService interface {
     Register(ctx context.Context, user *domain.User) error
-    Login(ctx context.Context, email, password string) (string, *domain.User, error)
+    Login(ctx context.Context, email, password string, device *domain.Session) (*domain.TokenPair, *domain.User, error)
     GetProfile(ctx context.Context, userID int64) (*domain.User, error)
     UpdateProfile(ctx context.Context, user *domain.User) error
+    ForgotPassword(ctx context.Context, email string) error
+    ResetPassword(ctx context.Context, token, password string) error
+    ChangePassword(ctx context.Context, userID, sessionID int64, currentPassword, newPassword, ip string) error
 }
 
 type RestaurantService interface {
     Create(ctx context.Context, restaurant *domain.Restaurant) error
     GetByID(ctx context.Context, id int64) (*domain.Restaurant, error)
-    List(ctx context.Context, page, pageSize int) ([]*domain.Restaurant, error)
+    Search(ctx context.Context, search domain.RestaurantSearch) (*domain.Page[*domain.RestaurantSearchResult], error)
     Update(ctx context.Context, restaurant *domain.Restaurant) error
     Delete(ctx context.Context, id int64) error
 }
 
 type TableService interface {
     CreateTable(ctx context.Context, restaurantID int64, table *domain.Table) error
-    GetRestaurantTables(ctx context.Context, restaurantID int64) ([]*domain.Table, error)
+    GetRestaurantTables(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Table], error)
     UpdateTableAvailability(ctx context.Context, tableID int64, isAvailable bool) error
 }
 
 type BookingService interface {
     CreateBooking(ctx context.Context, booking *domain.Booking) error
-    GetUserBookings(ctx context.Context, userID int64) ([]*domain.Booking, error)
+    GetUserBookings(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Booking], error)
     UpdateBookingStatus(ctx context.Context, bookingID int64, userID int64, status domain.BookingStatus) error
+    CreateRecurringBooking(ctx context.Context, series *domain.BookingSeries, skipConflicts bool) (*domain.RecurringBookingResult, error)
+    GetBookingSeries(ctx context.Context, seriesID int64, userID int64) (*domain.BookingSeries, []*domain.Booking, error)
+    CancelBooking(ctx context.Context, bookingID int64, userID int64, scope domain.CancelScope) (int64, error)
+    ConfirmPending(ctx context.Context, createdBefore time.Time) (int, error)
+    RecordOutcome(ctx context.Context, bookingID int64, status domain.BookingStatus) error
+    GetRestaurantBookings(ctx context.Context, restaurantID int64, date time.Time) ([]*domain.Booking, error)
+}
+
+type CalendarService interface {
+    GetBookingEvent(ctx context.Context, bookingID int64, userID int64) (*domain.Booking, error)
+    CreateFeedToken(ctx context.Context, userID int64) (string, error)
+    RevokeFeedToken(ctx context.Context, userID int64) error
+    GetFeedBookings(ctx context.Context, token string) ([]*domain.Booking, error)
+}
+
+type NotificationService interface {
+    NotifyBooking(ctx context.Context, kind domain.NotificationKind, bookingID int64) error
+    NotifyEmail(ctx context.Context, message *domain.EmailMessage) error
+    GetPreferences(ctx context.Context, userID int64) (*domain.NotificationPreferences, error)
+    UpdatePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error
+    Run(ctx context.Context)
+}
+
+type WebhookService interface {
+    Publish(ctx context.Context, source *domain.Event, eventType domain.WebhookEventType, data interface{}) error
+    CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+    ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+    UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+    DeleteEndpoint(ctx context.Context, id int64) error
+    ListDeliveries(ctx context.Context, endpointID int64, page domain.PageRequest) (*domain.Page[*domain.WebhookDelivery], error)
+    Redeliver(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error)
+    Run(ctx context.Context)
+}
+
+type EventDispatcher interface {
+    Run(ctx context.Context)
+}
+
+type StreamService interface {
+    StreamAvailability(ctx context.Context, restaurantID int64, lastEventID int64) (*domain.EventStream, error)
+    StreamBookings(ctx context.Context, userID int64, lastEventID int64) (*domain.EventStream, error)
+}
+
+type ReviewService interface {
+    CreateReview(ctx context.Context, review *domain.Review) error
+    ListRestaurantReviews(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Review], error)
+    UpdateReview(ctx context.Context, review *domain.Review) error
+    DeleteReview(ctx context.Context, reviewID int64, userID int64) error
+    SetReviewHidden(ctx context.Context, reviewID int64, hidden bool, reason string) (*domain.Review, error)
+}
+
+type MenuService interface {
+    GetMenu(ctx context.Context, restaurantID int64, filter domain.MenuFilter) (*domain.Menu, error)
+    GetDraft(ctx context.Context, restaurantID int64) (*domain.Menu, error)
+    SaveDraft(ctx context.Context, menu *domain.Menu) error
+    Publish(ctx context.Context, restaurantID int64, userID int64) (*domain.Menu, error)
+    ListVersions(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Menu], error)
+    GetVersion(ctx context.Context, restaurantID int64, version int) (*domain.Menu, error)
+    Rollback(ctx context.Context, restaurantID int64, version int, userID int64) (*domain.Menu, error)
+}
+
+type DealService interface {
+    CreateDeal(ctx context.Context, deal *domain.Deal) error
+    GetDeal(ctx context.Context, id int64) (*domain.Deal, error)
+    ListRestaurantDeals(ctx context.Context, restaurantID int64, activeOnly bool) ([]*domain.Deal, error)
+    UpdateDeal(ctx context.Context, deal *domain.Deal) error
+    DeleteDeal(ctx context.Context, id int64) error
+    SearchAvailability(ctx context.Context, restaurantID int64, date time.Time, partySize int) ([]*domain.AvailabilitySlot, error)
+}
+
+type LoyaltyService interface {
+    GetAccount(ctx context.Context, userID int64) (*domain.LoyaltyAccount, error)
+    ListEntries(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.LoyaltyEntry], error)
+    Adjust(ctx context.Context, entry *domain.LoyaltyEntry) error
+    GetRules(ctx context.Context, restaurantID int64) (*domain.LoyaltyRules, error)
+    UpdateRules(ctx context.Context, rules *domain.LoyaltyRules) error
+    AwardBooking(ctx context.Context, bookingID int64) error
+    PenalizeNoShow(ctx context.Context, bookingID int64) error
+}
+
+type MediaService interface {
+    UploadImage(ctx context.Context, restaurantID int64, kind domain.ImageKind, data io.Reader) (*domain.RestaurantImage, error)
+    ListImages(ctx context.Context, restaurantID int64) ([]*domain.RestaurantImage, error)
+    DeleteImage(ctx context.Context, restaurantID int64, imageID int64) error
+    PurgeRestaurant(ctx context.Context, restaurantID int64) error
+}
+
+type MembershipService interface {
+    Authorize(ctx context.Context, userID int64, resource domain.RestaurantResource, resourceID int64, required domain.MembershipRole) error
+    ListMembers(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Membership], error)
+    ListUserMemberships(ctx context.Context, userID int64) ([]*domain.Membership, error)
+    UpdateMemberRole(ctx context.Context, restaurantID, userID int64, role domain.MembershipRole) (*domain.Membership, error)
+    RemoveMember(ctx context.Context, restaurantID, userID int64) error
+    InviteMember(ctx context.Context, invite *domain.MembershipInvite) error
+    ListInvites(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.MembershipInvite], error)
+    RevokeInvite(ctx context.Context, restaurantID, inviteID int64) error
+    AcceptInvite(ctx context.Context, userID int64, token string) (*domain.Membership, error)
+}
+
+// Authorizer checks a user's permissions against their current role
+type Authorizer interface {
+    Authorize(ctx context.Context, userID int64, permission domain.Permission) error
+    HasPermission(ctx context.Context, userID int64, permission domain.Permission) (bool, error)
+}
+
+type RoleService interface {
+    Authorizer
+    GetUserRole(ctx context.Context, userID int64) (*domain.Role, error)
+    ListRoles(ctx context.Context) ([]*domain.Role, error)
+    GetRole(ctx context.Context, name string) (*domain.Role, error)
+    CreateRole(ctx context.Context, role *domain.Role) error
+    UpdateRole(ctx context.Context, role *domain.Role) error
+    DeleteRole(ctx context.Context, name string) error
+    AssignRole(ctx context.Context, actorID, userID int64, role string) error
+    BootstrapAdmin(ctx context.Context, email, role string) (*domain.User, error)
+    InviteToRole(ctx context.Context, invite *domain.RoleInvite) error
+    ListRoleInvites(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.RoleInvite], error)
+    RevokeRoleInvite(ctx context.Context, inviteID int64) error
+    AcceptRoleInvite(ctx context.Context, userID int64, token string) (*domain.Role, error)
+    ListRoleChanges(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.RoleChange], error)
+}
+
+// SessionChecker rejects access tokens whose session has been revoked
+type SessionChecker interface {
+    CheckSession(ctx context.Context, userID, sessionID int64) error
+}
+
+type SessionService interface {
+    SessionChecker
+    StartSession(ctx context.Context, user *domain.User, session *domain.Session) (*domain.TokenPair, error)
+    Refresh(ctx context.Context, refreshToken string, device *domain.Session) (*domain.TokenPair, error)
+    ListSessions(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Session], error)
+    RevokeSession(ctx context.Context, userID, sessionID int64) error
+    RevokeAllSessions(ctx context.Context, userID, exceptSessionID int64) error
+}
+
+// EmailVerifier keeps users with an unverified email from restricted actions
+type EmailVerifier interface {
+    RequireVerified(ctx context.Context, userID int64, action domain.VerifiedAction) error
+}
+
+type VerificationService interface {
+    EmailVerifier
+    SendVerification(ctx context.Context, user *domain.User) error
+    ResendVerification(ctx context.Context, userID int64) error
+    VerifyEmail(ctx context.Context, token string) (*domain.User, error)
+}
+
+// LoginGuard throttles failed logins per account and per client IP
+type LoginGuard interface {
+    Check(ctx context.Context, email, ip string) error
+    RecordFailure(ctx context.Context, email, ip string)
+    RecordSuccess(ctx context.Context, email string)
+    Unlock(ctx context.Context, email string)
+}
+
+// OIDCService signs users in through OpenID Connect providers
+type OIDCService interface {
+    ListProviders(ctx context.Context) []domain.IdentityProviderInfo
+    StartLogin(ctx context.Context, provider string) (string, error)
+    FinishLogin(ctx context.Context, provider, code, state string, device *domain.Session) (*domain.TokenPair, *domain.User, error)
+    ListIdentities(ctx context.Context, userID int64) ([]*domain.ExternalIdentity, error)
+}
+
+// APIKeyAuthenticator checks the API keys partners call the partner routes with, and
+// counts their requests against the keys' monthly quotas
+type APIKeyAuthenticator interface {
+    Authenticate(ctx context.Context, key string) (*domain.APIKey, error)
+    RecordRequest(ctx context.Context, key *domain.APIKey) (*domain.APIKeyUsage, error)
+}
+
+type APIKeyService interface {
+    APIKeyAuthenticator
+    // CreateAPIKey stores the key and returns it in full; only its hash is kept
+    CreateAPIKey(ctx context.Context, key *domain.APIKey) (string, error)
+    ListAPIKeys(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.APIKey], error)
+    RevokeAPIKey(ctx context.Context, id int64) error
+    // GetUsage returns the key with its request counts for its latest months
+    GetUsage(ctx context.Context, id int64, months int) (*domain.APIKey, []*domain.APIKeyUsage, error)
 }
\ No newline at end of file
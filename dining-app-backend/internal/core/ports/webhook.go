@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// WebhookSender performs a single signed delivery attempt. A non-nil response is
+// returned whenever the endpoint answered, even with an error status.
+type WebhookSender interface {
+	Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (*domain.WebhookResponse, error)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+const (
+	// apiKeyPrefix starts every key, so leaked keys are easy to recognise
+	apiKeyPrefix = "dak_"
+	// apiKeyShownLength is how much of a key is kept in the clear to tell keys apart
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+	// apiKeyCacheSize bounds how many keys are cached at once
+	apiKeyCacheSize = 1000
+	// maxUsageMonths bounds how many months of usage are returned at once
+	maxUsageMonths = 24
+)
+
+type cachedAPIKey struct {
+	key       *domain.APIKey
+	expiresAt time.Time
+}
+
+// apiKeyService issues partners' API keys and checks them on the partner routes.
+// Keys are cached by hash for a few seconds, so checking one on every request stays
+// cheap.
+type apiKeyService struct {
+	apiKeyRepo ports.APIKeyRepository
+	userRepo   ports.UserRepository
+	config     config.APIKeyConfig
+	logger     *logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedAPIKey
+}
+
+func NewAPIKeyService(
+	apiKeyRepo ports.APIKeyRepository,
+	userRepo ports.UserRepository,
+	config config.APIKeyConfig,
+	logger *logger.Logger,
+) *apiKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		config:     config,
+		logger:     logger,
+		cache:      make(map[string]cachedAPIKey),
+	}
+}
+
+// CreateAPIKey issues a key for the partner's user account. A key without its own
+// rate limit gets the configured default.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, key *domain.APIKey) (string, error) {
+	s.logger.Info("Creating API key",
+		zap.String("partner", key.Partner),
+		zap.Int64("userID", key.UserID),
+	)
+
+	if len(key.Scopes) == 0 {
+		return "", apperrors.NewError(apperrors.ErrorTypeValidation, "an api key needs at least one scope", nil)
+	}
+	for _, scope := range key.Scopes {
+		if !scope.IsValid() {
+			return "", apperrors.NewError(apperrors.ErrorTypeValidation, "unknown scope: "+string(scope), nil)
+		}
+	}
+	if key.MonthlyQuota < 0 {
+		return "", apperrors.NewError(apperrors.ErrorTypeValidation, "monthly quota can't be negative", nil)
+	}
+
+	if key.RequestsPerMinute == 0 {
+		key.RequestsPerMinute = s.config.DefaultRequestsPerMinute
+	}
+	if key.Burst == 0 {
+		key.Burst = s.config.DefaultBurst
+	}
+	if key.RequestsPerMinute < 1 || key.Burst < 1 {
+		return "", apperrors.NewError(apperrors.ErrorTypeValidation, "an api key needs a positive rate limit and burst", nil)
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, key.UserID); err != nil {
+		return "", err
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate api key", err)
+	}
+	plaintext := apiKeyPrefix + token
+	key.Prefix = plaintext[:apiKeyShownLength]
+	key.KeyHash = auth.HashToken(plaintext)
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		s.logger.Error("Failed to create API key", zap.Error(err))
+		return "", err
+	}
+
+	return plaintext, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.APIKey], error) {
+	return s.apiKeyRepo.List(ctx, page.Normalize())
+}
+
+// RevokeAPIKey stops the key from working. Replicas that have it cached accept it for
+// up to CacheSeconds more.
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	s.logger.Info("Revoking API key", zap.Int64("apiKeyID", id))
+
+	if err := s.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for hash, cached := range s.cache {
+		if cached.key.ID == id {
+			delete(s.cache, hash)
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// GetUsage returns the key with its request counts for its latest months, latest
+// first. Months without requests are left out.
+func (s *apiKeyService) GetUsage(ctx context.Context, id int64, months int) (*domain.APIKey, []*domain.APIKeyUsage, error) {
+	if months < 1 || months > maxUsageMonths {
+		months = maxUsageMonths
+	}
+
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, err := s.apiKeyRepo.ListUsage(ctx, id, months)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, usage, nil
+}
+
+// Authenticate returns the unrevoked key, failing with Unauthorized for any other
+func (s *apiKeyService) Authenticate(ctx context.Context, plaintext string) (*domain.APIKey, error) {
+	hash := auth.HashToken(plaintext)
+
+	s.mu.Lock()
+	cached, ok := s.cache[hash]
+	s.mu.Unlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	key, err := s.apiKeyRepo.GetActiveByHash(ctx, hash)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid or revoked api key", nil)
+		}
+		s.logger.Error("Failed to get API key", zap.Error(err))
+		return nil, err
+	}
+
+	if s.config.CacheSeconds > 0 {
+		s.mu.Lock()
+		if len(s.cache) >= apiKeyCacheSize {
+			s.pruneLocked()
+		}
+		s.cache[hash] = cachedAPIKey{
+			key:       key,
+			expiresAt: time.Now().Add(time.Duration(s.config.CacheSeconds) * time.Second),
+		}
+		s.mu.Unlock()
+	}
+
+	return key, nil
+}
+
+// RecordRequest counts a request made with the key, failing with RateLimited once the
+// key's monthly quota is used up. The quota starts over on the first of each month
+// (UTC).
+func (s *apiKeyService) RecordRequest(ctx context.Context, key *domain.APIKey) (*domain.APIKeyUsage, error) {
+	now := time.Now()
+	month := domain.UsageMonth(now)
+
+	usage, counted, err := s.apiKeyRepo.RecordRequest(ctx, key.ID, month, key.MonthlyQuota)
+	if err != nil {
+		s.logger.Error("Failed to record API key usage", zap.Int64("apiKeyID", key.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if !counted {
+		return usage, retryAfterError("monthly quota used up; it resets in %d seconds", month.AddDate(0, 1, 0).Sub(now))
+	}
+
+	return usage, nil
+}
+
+// pruneLocked drops expired entries, or every entry when none has expired. The caller
+// must hold mu.
+func (s *apiKeyService) pruneLocked() {
+	now := time.Now()
+	for hash, cached := range s.cache {
+		if now.After(cached.expiresAt) {
+			delete(s.cache, hash)
+		}
+	}
+	if len(s.cache) >= apiKeyCacheSize {
+		s.cache = make(map[string]cachedAPIKey)
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// autoConfirmer confirms bookings once they have been pending for the delay. It sweeps
+// the bookings table instead of keeping a timer per booking, so neither a restart nor
+// an event retried by the dispatcher loses or repeats a confirmation.
+type autoConfirmer struct {
+	bookings ports.BookingService
+	delay    time.Duration
+	logger   *logger.Logger
+}
+
+func NewAutoConfirmer(bookings ports.BookingService, delay time.Duration, logger *logger.Logger) *autoConfirmer {
+	return &autoConfirmer{
+		bookings: bookings,
+		delay:    delay,
+		logger:   logger,
+	}
+}
+
+// Run sweeps every delay, but at most once a second, until ctx is done. A booking is
+// confirmed between one and two delays after it was made.
+func (a *autoConfirmer) Run(ctx context.Context) {
+	ticker := time.NewTicker(max(a.delay, time.Second))
+	defer ticker.Stop()
+
+	for {
+		confirmed, err := a.bookings.ConfirmPending(ctx, time.Now().Add(-a.delay))
+		if err == nil && confirmed > 0 {
+			a.logger.Info("Bookings auto-confirmed", zap.Int("count", confirmed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
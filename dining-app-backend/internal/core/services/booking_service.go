@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
@@ -11,23 +12,38 @@ import (
 	"go.uber.org/zap"
 )
 
+// autoConfirmBatchSize bounds how many bookings are confirmed in one transaction
+const autoConfirmBatchSize = 100
+
 type bookingService struct {
 	bookingRepo    ports.BookingRepository
+	seriesRepo     ports.BookingSeriesRepository
 	tableRepo      ports.TableRepository
 	restaurantRepo ports.RestaurantRepository
+	dealRepo       ports.DealRepository
+	outboxRepo     ports.OutboxRepository
+	transactor     ports.Transactor
 	logger         *logger.Logger
 }
 
 func NewBookingService(
 	bookingRepo ports.BookingRepository,
+	seriesRepo ports.BookingSeriesRepository,
 	tableRepo ports.TableRepository,
 	restaurantRepo ports.RestaurantRepository,
+	dealRepo ports.DealRepository,
+	outboxRepo ports.OutboxRepository,
+	transactor ports.Transactor,
 	logger *logger.Logger,
 ) *bookingService {
 	return &bookingService{
 		bookingRepo:    bookingRepo,
+		seriesRepo:     seriesRepo,
 		tableRepo:      tableRepo,
 		restaurantRepo: restaurantRepo,
+		dealRepo:       dealRepo,
+		outboxRepo:     outboxRepo,
+		transactor:     transactor,
 		logger:         logger,
 	}
 }
@@ -78,29 +94,31 @@ func (s *bookingService) CreateBooking(ctx context.Context, booking *domain.Book
 
 	booking.Status = domain.BookingStatusPending
 
-	// Create booking in a transaction
-	err = s.bookingRepo.Create(ctx, booking)
+	deals, err := s.matchDeals(ctx, booking, table.RestaurantID)
 	if err != nil {
-		s.logger.Error("Failed to create booking", zap.Error(err))
+		s.logger.Error("Failed to match deals", zap.Error(err))
 		return err
 	}
 
-	// Start a goroutine to automatically confirm the booking after 5 seconds
-	go func() {
-		time.Sleep(5 * time.Second)
-		// Create a new context for the confirmation
-		confirmCtx := context.Background()
-		if err := s.bookingRepo.UpdateStatus(confirmCtx, booking.ID, domain.BookingStatusConfirmed); err != nil {
-			s.logger.Error("Failed to auto-confirm booking",
-				zap.Int64("bookingID", booking.ID),
-				zap.Error(err),
-			)
-		} else {
-			s.logger.Info("Booking auto-confirmed successfully",
-				zap.Int64("bookingID", booking.ID),
-			)
+	// Create booking, attach its deals and record its events in a transaction
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.bookingRepo.Create(ctx, booking); err != nil {
+			return err
 		}
-	}()
+
+		if err := s.attachDeals(ctx, booking, deals); err != nil {
+			return err
+		}
+
+		return s.outboxRepo.Append(ctx,
+			domain.NewBookingEventPayload(booking, table.RestaurantID, "").Event(domain.EventBookingCreated),
+			domain.NewTableAvailabilityEvent(table.ID, table.RestaurantID, false, &booking.BookingDate),
+		)
+	})
+	if err != nil {
+		s.logger.Error("Failed to create booking", zap.Error(err))
+		return err
+	}
 
 	s.logger.Info("Booking created successfully",
 		zap.Int64("bookingID", booking.ID),
@@ -109,10 +127,10 @@ func (s *bookingService) CreateBooking(ctx context.Context, booking *domain.Book
 	return nil
 }
 
-func (s *bookingService) GetUserBookings(ctx context.Context, userID int64) ([]*domain.Booking, error) {
+func (s *bookingService) GetUserBookings(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Booking], error) {
 	s.logger.Info("Fetching user bookings", zap.Int64("userID", userID))
 
-	bookings, err := s.bookingRepo.GetUserBookings(ctx, userID)
+	result, err := s.bookingRepo.ListUserBookings(ctx, userID, page.Normalize())
 	if err != nil {
 		s.logger.Error("Failed to get user bookings",
 			zap.Int64("userID", userID),
@@ -121,7 +139,102 @@ func (s *bookingService) GetUserBookings(ctx context.Context, userID int64) ([]*
 		return nil, err
 	}
 
-	return bookings, nil
+	bookings := result.Items
+	if len(bookings) == 0 {
+		return result, nil
+	}
+
+	ids := make([]int64, len(bookings))
+	byID := make(map[int64]*domain.Booking, len(bookings))
+	for i, booking := range bookings {
+		ids[i] = booking.ID
+		byID[booking.ID] = booking
+	}
+
+	deals, err := s.dealRepo.GetBookingDeals(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deal := range deals {
+		booking := byID[deal.BookingID]
+		booking.Deals = append(booking.Deals, deal)
+	}
+
+	return result, nil
+}
+
+// matchDeals returns the restaurant's deals whose eligibility rules the booking meets.
+// Usage caps are checked when the deals are attached.
+func (s *bookingService) matchDeals(ctx context.Context, booking *domain.Booking, restaurantID int64) ([]*domain.Deal, error) {
+	deals, err := s.dealRepo.ListByRestaurant(ctx, restaurantID, true)
+	if err != nil || len(deals) == 0 {
+		return nil, err
+	}
+
+	firstVisit, err := s.bookingRepo.HasBookedRestaurant(ctx, booking.UserID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	firstVisit = !firstVisit
+
+	slot := domain.DealSlot{
+		Date:       booking.BookingDate,
+		StartTime:  booking.StartTime,
+		PartySize:  booking.NumberOfGuests,
+		FirstVisit: &firstVisit,
+	}
+
+	matched := []*domain.Deal{}
+	for _, deal := range deals {
+		if deal.Matches(slot) {
+			matched = append(matched, deal)
+		}
+	}
+
+	return matched, nil
+}
+
+// attachDeals attaches the deals whose usage caps allow it. The deals stay locked
+// until the transaction commits, so concurrent bookings can't exceed the caps.
+func (s *bookingService) attachDeals(ctx context.Context, booking *domain.Booking, deals []*domain.Deal) error {
+	if len(deals) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(deals))
+	for i, deal := range deals {
+		ids[i] = deal.ID
+	}
+
+	if err := s.dealRepo.Lock(ctx, ids); err != nil {
+		return err
+	}
+
+	usage, err := s.dealRepo.GetUsage(ctx, ids, booking.UserID)
+	if err != nil {
+		return err
+	}
+
+	applied := []*domain.BookingDeal{}
+	for _, deal := range deals {
+		if !deal.Available(usage[deal.ID]) {
+			continue
+		}
+		dealID := deal.ID
+		applied = append(applied, &domain.BookingDeal{
+			DealID:  &dealID,
+			Title:   deal.Title,
+			Benefit: deal.Benefit(),
+		})
+	}
+
+	if err := s.dealRepo.Attach(ctx, booking.ID, applied); err != nil {
+		return err
+	}
+
+	booking.Deals = applied
+	return nil
 }
 
 func (s *bookingService) UpdateBookingStatus(ctx context.Context, bookingID int64, userID int64, status domain.BookingStatus) error {
@@ -147,7 +260,7 @@ func (s *bookingService) UpdateBookingStatus(ctx context.Context, bookingID int6
 		return apperrors.NewError(apperrors.ErrorTypeValidation, "invalid status transition", nil)
 	}
 
-	if err := s.bookingRepo.UpdateStatus(ctx, bookingID, status); err != nil {
+	if err := s.changeStatus(ctx, booking, status, false); err != nil {
 		s.logger.Error("Failed to update booking status", zap.Error(err))
 		return err
 	}
@@ -159,6 +272,314 @@ func (s *bookingService) UpdateBookingStatus(ctx context.Context, bookingID int6
 	return nil
 }
 
+func (s *bookingService) CreateRecurringBooking(ctx context.Context, series *domain.BookingSeries, skipConflicts bool) (*domain.RecurringBookingResult, error) {
+	s.logger.Info("Creating recurring booking",
+		zap.Int64("userID", series.UserID),
+		zap.Int64("tableID", series.TableID),
+		zap.String("frequency", string(series.Frequency)),
+		zap.Time("startDate", series.StartDate),
+	)
+
+	if err := series.Validate(); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil)
+	}
+
+	table, err := s.tableRepo.GetByID(ctx, series.TableID)
+	if err != nil {
+		s.logger.Error("Failed to get table", zap.Error(err))
+		return nil, err
+	}
+
+	if !table.IsAvailable {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "table is not available", nil)
+	}
+
+	if table.Capacity < series.NumberOfGuests {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "table capacity is insufficient", nil)
+	}
+
+	// Check every occurrence up front so the caller gets a full conflict report
+	today := time.Now().Truncate(24 * time.Hour)
+	result := &domain.RecurringBookingResult{Series: series}
+	for _, date := range series.Occurrences() {
+		if date.Before(today) {
+			result.Conflicts = append(result.Conflicts, domain.BookingConflict{Date: date, Reason: "booking date must be in the future"})
+			continue
+		}
+
+		isAvailable, err := s.bookingRepo.CheckTableAvailability(ctx, series.TableID, date, series.StartTime, series.EndTime)
+		if err != nil {
+			s.logger.Error("Failed to check table availability", zap.Error(err))
+			return nil, err
+		}
+
+		if !isAvailable {
+			result.Conflicts = append(result.Conflicts, domain.BookingConflict{Date: date, Reason: "table is not available for the requested time"})
+			continue
+		}
+
+		result.Bookings = append(result.Bookings, &domain.Booking{
+			UserID:          series.UserID,
+			TableID:         series.TableID,
+			BookingDate:     date,
+			StartTime:       series.StartTime,
+			EndTime:         series.EndTime,
+			NumberOfGuests:  series.NumberOfGuests,
+			SpecialRequests: series.SpecialRequests,
+			Status:          domain.BookingStatusPending,
+		})
+	}
+
+	if len(result.Conflicts) > 0 && !skipConflicts {
+		return nil, apperrors.NewError(apperrors.ErrorTypeConflict, "some occurrences are not available", result.Conflicts)
+	}
+
+	if len(result.Bookings) == 0 {
+		return nil, apperrors.NewError(apperrors.ErrorTypeConflict, "no occurrences are available", result.Conflicts)
+	}
+
+	series.Status = domain.BookingSeriesStatusActive
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.seriesRepo.Create(ctx, series, result.Bookings); err != nil {
+			return err
+		}
+
+		events := []*domain.Event{domain.NewTableAvailabilityEvent(table.ID, table.RestaurantID, false, nil)}
+		for i, booking := range result.Bookings {
+			payload := domain.NewBookingEventPayload(booking, table.RestaurantID, "")
+			payload.Occurrence = i + 1
+			events = append(events, payload.Event(domain.EventBookingCreated))
+		}
+		return s.outboxRepo.Append(ctx, events...)
+	})
+	if err != nil {
+		s.logger.Error("Failed to create booking series", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Recurring booking created successfully",
+		zap.Int64("seriesID", series.ID),
+		zap.Int("occurrences", len(result.Bookings)),
+		zap.Int("conflicts", len(result.Conflicts)),
+	)
+	return result, nil
+}
+
+func (s *bookingService) GetBookingSeries(ctx context.Context, seriesID int64, userID int64) (*domain.BookingSeries, []*domain.Booking, error) {
+	s.logger.Info("Fetching booking series",
+		zap.Int64("seriesID", seriesID),
+		zap.Int64("userID", userID),
+	)
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		s.logger.Error("Failed to get booking series", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if series.UserID != userID {
+		return nil, nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to view this booking series", nil)
+	}
+
+	bookings, err := s.seriesRepo.GetOccurrences(ctx, seriesID)
+	if err != nil {
+		s.logger.Error("Failed to get series occurrences", zap.Error(err))
+		return nil, nil, err
+	}
+
+	return series, bookings, nil
+}
+
+// CancelBooking cancels a single booking, or for recurring bookings optionally this and
+// all following occurrences or the remainder of the whole series. It returns the number
+// of bookings cancelled.
+func (s *bookingService) CancelBooking(ctx context.Context, bookingID int64, userID int64, scope domain.CancelScope) (int64, error) {
+	s.logger.Info("Cancelling booking",
+		zap.Int64("bookingID", bookingID),
+		zap.Int64("userID", userID),
+		zap.String("scope", string(scope)),
+	)
+
+	if scope == domain.CancelScopeOccurrence {
+		if err := s.UpdateBookingStatus(ctx, bookingID, userID, domain.BookingStatusCancelled); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		s.logger.Error("Failed to get booking", zap.Error(err))
+		return 0, err
+	}
+
+	if booking.UserID != userID {
+		return 0, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to update this booking", nil)
+	}
+
+	if booking.SeriesID == nil {
+		return 0, apperrors.NewError(apperrors.ErrorTypeValidation, "booking is not part of a recurring series", nil)
+	}
+
+	// Occurrences that already took place are kept as history
+	from := time.Now().Truncate(24 * time.Hour)
+	status := domain.BookingSeriesStatusCancelled
+	if scope == domain.CancelScopeFollowing {
+		if booking.BookingDate.After(from) {
+			from = booking.BookingDate
+		}
+		status = domain.BookingSeriesStatusActive
+	}
+
+	// Remember the occurrences beforehand so events can report the transition
+	occurrences, err := s.seriesRepo.GetOccurrences(ctx, *booking.SeriesID)
+	if err != nil {
+		s.logger.Error("Failed to get series occurrences", zap.Error(err))
+		return 0, err
+	}
+
+	byID := make(map[int64]*domain.Booking, len(occurrences))
+	for _, occurrence := range occurrences {
+		byID[occurrence.ID] = occurrence
+	}
+
+	var cancelled []int64
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		cancelled, err = s.seriesRepo.CancelFrom(ctx, *booking.SeriesID, from, status)
+		if err != nil {
+			return err
+		}
+
+		// The booking the user acted on comes first, as the one users are told about
+		sort.Slice(cancelled, func(i, j int) bool {
+			if cancelled[i] == bookingID || cancelled[j] == bookingID {
+				return cancelled[i] == bookingID
+			}
+			return cancelled[i] < cancelled[j]
+		})
+
+		events := []*domain.Event{domain.NewTableAvailabilityEvent(booking.TableID, booking.RestaurantID, true, nil)}
+		for i, id := range cancelled {
+			occurrence, ok := byID[id]
+			if !ok {
+				continue
+			}
+			previous := occurrence.Status
+			occurrence.Status = domain.BookingStatusCancelled
+			payload := domain.NewBookingEventPayload(occurrence, booking.RestaurantID, previous)
+			payload.Occurrence = i + 1
+			events = append(events, payload.Event(domain.EventBookingCancelled))
+		}
+		return s.outboxRepo.Append(ctx, events...)
+	})
+	if err != nil {
+		s.logger.Error("Failed to cancel series occurrences", zap.Error(err))
+		return 0, err
+	}
+
+	s.logger.Info("Series occurrences cancelled successfully",
+		zap.Int64("seriesID", *booking.SeriesID),
+		zap.Int("cancelled", len(cancelled)),
+	)
+	return int64(len(cancelled)), nil
+}
+
+// ConfirmPending confirms the bookings still pending that were made before the time,
+// on behalf of the system, and returns how many it confirmed. Each batch is locked
+// and confirmed in one transaction, so a booking is only ever confirmed once.
+func (s *bookingService) ConfirmPending(ctx context.Context, createdBefore time.Time) (int, error) {
+	confirmed := 0
+	for {
+		var bookings []*domain.Booking
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			bookings, err = s.bookingRepo.LockPendingCreatedBefore(ctx, createdBefore, autoConfirmBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, booking := range bookings {
+				if err := s.changeStatus(ctx, booking, domain.BookingStatusConfirmed, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			s.logger.Error("Failed to auto-confirm bookings", zap.Error(err))
+			return confirmed, err
+		}
+
+		confirmed += len(bookings)
+		if len(bookings) < autoConfirmBatchSize {
+			return confirmed, nil
+		}
+	}
+}
+
+// RecordOutcome marks a confirmed booking that has started as completed or as a
+// no-show. A completed booking can still be corrected to a no-show.
+func (s *bookingService) RecordOutcome(ctx context.Context, bookingID int64, status domain.BookingStatus) error {
+	s.logger.Info("Recording booking outcome",
+		zap.Int64("bookingID", bookingID),
+		zap.String("status", string(status)),
+	)
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		s.logger.Error("Failed to get booking", zap.Error(err))
+		return err
+	}
+
+	if !isValidOutcomeTransition(booking.Status, status) {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "invalid status transition", nil)
+	}
+
+	if booking.StartsAt().After(time.Now()) {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "booking has not started yet", nil)
+	}
+
+	if err := s.changeStatus(ctx, booking, status, false); err != nil {
+		s.logger.Error("Failed to record booking outcome", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// changeStatus updates the booking's status and records the matching events in one
+// transaction
+func (s *bookingService) changeStatus(ctx context.Context, booking *domain.Booking, status domain.BookingStatus, automatic bool) error {
+	previous := booking.Status
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.bookingRepo.UpdateStatus(ctx, booking.ID, status); err != nil {
+			return err
+		}
+		booking.Status = status
+
+		payload := domain.NewBookingEventPayload(booking, booking.RestaurantID, previous)
+		payload.Automatic = automatic
+
+		var events []*domain.Event
+		switch status {
+		case domain.BookingStatusConfirmed:
+			events = append(events, payload.Event(domain.EventBookingConfirmed))
+		case domain.BookingStatusCancelled:
+			events = append(events,
+				payload.Event(domain.EventBookingCancelled),
+				domain.NewTableAvailabilityEvent(booking.TableID, booking.RestaurantID, true, &booking.BookingDate),
+			)
+		case domain.BookingStatusCompleted:
+			events = append(events, payload.Event(domain.EventBookingCompleted))
+		case domain.BookingStatusNoShow:
+			events = append(events, payload.Event(domain.EventBookingNoShow))
+		}
+		return s.outboxRepo.Append(ctx, events...)
+	})
+}
+
 // Helper function to validate booking status transitions
 func isValidStatusTransition(current, new domain.BookingStatus) bool {
 	switch current {
@@ -172,3 +593,35 @@ func isValidStatusTransition(current, new domain.BookingStatus) bool {
 		return false
 	}
 }
+
+// isValidOutcomeTransition validates the transitions restaurants make after a booking
+// has started
+func isValidOutcomeTransition(current, new domain.BookingStatus) bool {
+	switch current {
+	case domain.BookingStatusConfirmed:
+		return new == domain.BookingStatusCompleted || new == domain.BookingStatusNoShow
+	case domain.BookingStatusCompleted:
+		return new == domain.BookingStatusNoShow
+	default:
+		return false
+	}
+}
+
+// GetRestaurantBookings returns the bookings at the restaurant on the date that aren't
+// cancelled, for its staff
+func (s *bookingService) GetRestaurantBookings(ctx context.Context, restaurantID int64, date time.Time) ([]*domain.Booking, error) {
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetRestaurantBookings(ctx, restaurantID, date)
+	if err != nil {
+		s.logger.Error("Failed to get restaurant bookings",
+			zap.Int64("restaurantID", restaurantID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return bookings, nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+type calendarService struct {
+	bookingRepo ports.BookingRepository
+	feedRepo    ports.CalendarFeedRepository
+	logger      *logger.Logger
+}
+
+func NewCalendarService(
+	bookingRepo ports.BookingRepository,
+	feedRepo ports.CalendarFeedRepository,
+	logger *logger.Logger,
+) *calendarService {
+	return &calendarService{
+		bookingRepo: bookingRepo,
+		feedRepo:    feedRepo,
+		logger:      logger,
+	}
+}
+
+func (s *calendarService) GetBookingEvent(ctx context.Context, bookingID int64, userID int64) (*domain.Booking, error) {
+	s.logger.Info("Exporting booking to calendar",
+		zap.Int64("bookingID", bookingID),
+		zap.Int64("userID", userID),
+	)
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		s.logger.Error("Failed to get booking", zap.Error(err))
+		return nil, err
+	}
+
+	if booking.UserID != userID {
+		return nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to view this booking", nil)
+	}
+
+	return booking, nil
+}
+
+// CreateFeedToken issues a new feed token, replacing any existing one. The plain token
+// is only returned here; afterwards only its hash is known.
+func (s *calendarService) CreateFeedToken(ctx context.Context, userID int64) (string, error) {
+	s.logger.Info("Creating calendar feed token", zap.Int64("userID", userID))
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate calendar feed token", zap.Error(err))
+		return "", apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate calendar feed token", err)
+	}
+
+	feedToken := &domain.CalendarFeedToken{
+		UserID:    userID,
+		TokenHash: auth.HashToken(token),
+	}
+
+	if err := s.feedRepo.Create(ctx, feedToken); err != nil {
+		s.logger.Error("Failed to store calendar feed token", zap.Error(err))
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *calendarService) RevokeFeedToken(ctx context.Context, userID int64) error {
+	s.logger.Info("Revoking calendar feed token", zap.Int64("userID", userID))
+	return s.feedRepo.RevokeForUser(ctx, userID)
+}
+
+// GetFeedBookings returns the upcoming bookings, including cancelled ones, of the user
+// owning the feed token
+func (s *calendarService) GetFeedBookings(ctx context.Context, token string) ([]*domain.Booking, error) {
+	feedToken, err := s.feedRepo.GetActiveByHash(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetUserBookings(ctx, feedToken.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get user bookings",
+			zap.Int64("userID", feedToken.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	now := time.Now()
+	upcoming := make([]*domain.Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.EndsAt().After(now) {
+			upcoming = append(upcoming, booking)
+		}
+	}
+
+	return upcoming, nil
+}
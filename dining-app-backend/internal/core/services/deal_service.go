@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+type dealService struct {
+	dealRepo       ports.DealRepository
+	restaurantRepo ports.RestaurantRepository
+	tableRepo      ports.TableRepository
+	bookingRepo    ports.BookingRepository
+	config         config.BookingConfig
+	logger         *logger.Logger
+}
+
+func NewDealService(
+	dealRepo ports.DealRepository,
+	restaurantRepo ports.RestaurantRepository,
+	tableRepo ports.TableRepository,
+	bookingRepo ports.BookingRepository,
+	config config.BookingConfig,
+	logger *logger.Logger,
+) *dealService {
+	return &dealService{
+		dealRepo:       dealRepo,
+		restaurantRepo: restaurantRepo,
+		tableRepo:      tableRepo,
+		bookingRepo:    bookingRepo,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+func (s *dealService) CreateDeal(ctx context.Context, deal *domain.Deal) error {
+	s.logger.Info("Creating deal",
+		zap.Int64("restaurantID", deal.RestaurantID),
+		zap.String("title", deal.Title),
+	)
+
+	if err := deal.Validate(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil)
+	}
+
+	return s.dealRepo.Create(ctx, deal)
+}
+
+func (s *dealService) GetDeal(ctx context.Context, id int64) (*domain.Deal, error) {
+	return s.dealRepo.GetByID(ctx, id)
+}
+
+func (s *dealService) ListRestaurantDeals(ctx context.Context, restaurantID int64, activeOnly bool) ([]*domain.Deal, error) {
+	return s.dealRepo.ListByRestaurant(ctx, restaurantID, activeOnly)
+}
+
+func (s *dealService) UpdateDeal(ctx context.Context, deal *domain.Deal) error {
+	s.logger.Info("Updating deal", zap.Int64("dealID", deal.ID))
+
+	if err := deal.Validate(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil)
+	}
+
+	return s.dealRepo.Update(ctx, deal)
+}
+
+// DeleteDeal removes the deal. Bookings keep their copy of it.
+func (s *dealService) DeleteDeal(ctx context.Context, id int64) error {
+	s.logger.Info("Deleting deal", zap.Int64("dealID", id))
+	return s.dealRepo.Delete(ctx, id)
+}
+
+// SearchAvailability lists the slots between opening and closing time that have a
+// table for the party, with the deals a booking there would get. Per-guest caps and
+// first-time-guest rules are only checked when the booking is made.
+func (s *dealService) SearchAvailability(ctx context.Context, restaurantID int64, date time.Time, partySize int) ([]*domain.AvailabilitySlot, error) {
+	restaurant, err := s.restaurantRepo.GetByID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, ok := domain.ParseClock(restaurant.OpeningTime)
+	closing, ok2 := domain.ParseClock(restaurant.ClosingTime)
+	if !ok || !ok2 {
+		s.logger.Error("Restaurant has invalid opening hours",
+			zap.Int64("restaurantID", restaurantID),
+			zap.String("openingTime", restaurant.OpeningTime),
+			zap.String("closingTime", restaurant.ClosingTime),
+		)
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "restaurant has invalid opening hours", nil)
+	}
+
+	tables, err := s.tableRepo.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetRestaurantBookings(ctx, restaurantID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	deals, err := s.availableDeals(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	step := s.config.SlotMinutes
+	duration := s.config.SlotDurationMinutes
+	slots := []*domain.AvailabilitySlot{}
+
+	for start := opening; start+duration <= closing; start += step {
+		end := start + duration
+
+		free := 0
+		for _, table := range tables {
+			if table.IsAvailable && table.Capacity >= partySize && !tableBooked(bookings, table.ID, start, end) {
+				free++
+			}
+		}
+		if free == 0 {
+			continue
+		}
+
+		slot := &domain.AvailabilitySlot{
+			StartTime:       clock(start),
+			EndTime:         clock(end),
+			AvailableTables: free,
+			Deals:           []*domain.Deal{},
+		}
+
+		dealSlot := domain.DealSlot{Date: date, StartTime: slot.StartTime, PartySize: partySize}
+		for _, deal := range deals {
+			if deal.Matches(dealSlot) {
+				slot.Deals = append(slot.Deals, deal)
+			}
+		}
+
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
+// availableDeals returns the restaurant's active deals that are under their total cap
+func (s *dealService) availableDeals(ctx context.Context, restaurantID int64) ([]*domain.Deal, error) {
+	deals, err := s.dealRepo.ListByRestaurant(ctx, restaurantID, true)
+	if err != nil || len(deals) == 0 {
+		return nil, err
+	}
+
+	ids := make([]int64, len(deals))
+	for i, deal := range deals {
+		ids[i] = deal.ID
+	}
+
+	usage, err := s.dealRepo.GetUsage(ctx, ids, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	available := []*domain.Deal{}
+	for _, deal := range deals {
+		if deal.Available(domain.DealUsage{Total: usage[deal.ID].Total}) {
+			available = append(available, deal)
+		}
+	}
+
+	return available, nil
+}
+
+// tableBooked reports whether a booking holds the table for part of [start, end),
+// in minutes after midnight
+func tableBooked(bookings []*domain.Booking, tableID int64, start, end int) bool {
+	for _, booking := range bookings {
+		if booking.TableID != tableID {
+			continue
+		}
+		bookedFrom, _ := domain.ParseClock(booking.StartTime)
+		bookedUntil, _ := domain.ParseClock(booking.EndTime)
+		if bookedFrom < end && bookedUntil > start {
+			return true
+		}
+	}
+	return false
+}
+
+func clock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	eventBatchSize = 100
+	eventLease     = time.Minute
+)
+
+type eventDispatcher struct {
+	outboxRepo ports.OutboxRepository
+	bus        ports.EventBus
+	config     config.EventsConfig
+	logger     *logger.Logger
+}
+
+func NewEventDispatcher(
+	outboxRepo ports.OutboxRepository,
+	bus ports.EventBus,
+	config config.EventsConfig,
+	logger *logger.Logger,
+) *eventDispatcher {
+	return &eventDispatcher{
+		outboxRepo: outboxRepo,
+		bus:        bus,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Run publishes committed outbox events to subscribers until ctx is done
+func (d *eventDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(d.config.PollIntervalMillis) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while there is a backlog
+		for d.dispatch(ctx) == eventBatchSize {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch publishes one batch of events and returns its size
+func (d *eventDispatcher) dispatch(ctx context.Context) int {
+	events, err := d.outboxRepo.ClaimUnpublished(ctx, eventBatchSize, eventLease)
+	if err != nil {
+		d.logger.Error("Failed to claim outbox events", zap.Error(err))
+		return 0
+	}
+
+	for _, event := range events {
+		d.publish(ctx, event)
+	}
+
+	return len(events)
+}
+
+func (d *eventDispatcher) publish(ctx context.Context, event *domain.Event) {
+	// Broadcast subscribers only care about the latest state, so retries skip them
+	if event.Attempts == 1 {
+		d.broadcast(ctx, event)
+	}
+
+	if err := d.bus.Dispatch(ctx, event); err != nil {
+		var nextAttemptAt *time.Time
+		if event.Attempts < d.config.MaxAttempts {
+			next := time.Now().Add(notificationBackoff(event.Attempts))
+			nextAttemptAt = &next
+		}
+		d.logger.Error("Event subscriber failed",
+			zap.Int64("eventID", event.ID),
+			zap.String("eventType", string(event.Type)),
+			zap.Int("attempts", event.Attempts),
+			zap.Error(err),
+		)
+		if err := d.outboxRepo.MarkFailed(ctx, event.ID, err.Error(), nextAttemptAt); err != nil {
+			d.logger.Error("Failed to mark event as failed",
+				zap.Int64("eventID", event.ID),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+
+	if err := d.outboxRepo.MarkPublished(ctx, event.ID); err != nil {
+		d.logger.Error("Failed to mark event as published",
+			zap.Int64("eventID", event.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// broadcast hands the event to broadcast subscribers on every replica. With NOTIFY
+// enabled this replica receives it through its own listener too.
+func (d *eventDispatcher) broadcast(ctx context.Context, event *domain.Event) {
+	if !d.config.Notify {
+		d.bus.Broadcast(ctx, event)
+		return
+	}
+
+	if err := d.outboxRepo.Notify(ctx, d.config.Channel, event.ID); err != nil {
+		d.logger.Error("Failed to notify event",
+			zap.Int64("eventID", event.ID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+)
+
+// SubscribeNotifications queues booking notifications. Of the bookings created or
+// cancelled together by a recurring booking operation only the first is announced, and
+// automatic confirmations of recurring occurrences are not announced at all; reminders
+// cover the rest.
+func SubscribeNotifications(bus ports.EventBus, notifications ports.NotificationService) {
+	notify := func(kind domain.NotificationKind) ports.EventHandler {
+		return func(ctx context.Context, event *domain.Event) error {
+			var payload domain.BookingEventPayload
+			if err := event.Decode(&payload); err != nil {
+				return err
+			}
+
+			if payload.Occurrence > 1 || (payload.Automatic && payload.SeriesID != nil) {
+				return nil
+			}
+
+			return notifications.NotifyBooking(ctx, kind, payload.BookingID)
+		}
+	}
+
+	bus.Subscribe(domain.EventBookingCreated, notify(domain.NotificationBookingCreated))
+	bus.Subscribe(domain.EventBookingConfirmed, notify(domain.NotificationBookingConfirmed))
+	bus.Subscribe(domain.EventBookingCancelled, notify(domain.NotificationBookingCancelled))
+}
+
+// SubscribeLoyalty keeps the points ledger in step with booking outcomes. Both handlers
+// check the booking's current status, so late or repeated events can't undo a
+// correction.
+func SubscribeLoyalty(bus ports.EventBus, loyalty ports.LoyaltyService) {
+	bus.Subscribe(domain.EventBookingCompleted, func(ctx context.Context, event *domain.Event) error {
+		var payload domain.BookingEventPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return ignoreNotFound(loyalty.AwardBooking(ctx, payload.BookingID))
+	})
+
+	bus.Subscribe(domain.EventBookingNoShow, func(ctx context.Context, event *domain.Event) error {
+		var payload domain.BookingEventPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return ignoreNotFound(loyalty.PenalizeNoShow(ctx, payload.BookingID))
+	})
+}
+
+// SubscribeMedia removes the stored images of deleted restaurants
+func SubscribeMedia(bus ports.EventBus, media ports.MediaService) {
+	bus.Subscribe(domain.EventRestaurantDeleted, func(ctx context.Context, event *domain.Event) error {
+		var payload domain.RestaurantEventPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return media.PurgeRestaurant(ctx, payload.RestaurantID)
+	})
+}
+
+// SubscribeWebhooks publishes booking and restaurant events to webhook endpoints
+func SubscribeWebhooks(
+	bus ports.EventBus,
+	webhooks ports.WebhookService,
+	bookingRepo ports.BookingRepository,
+	restaurantRepo ports.RestaurantRepository,
+) {
+	booking := func(eventType domain.WebhookEventType) ports.EventHandler {
+		return func(ctx context.Context, event *domain.Event) error {
+			var payload domain.BookingEventPayload
+			if err := event.Decode(&payload); err != nil {
+				return err
+			}
+
+			booking, err := bookingRepo.GetByID(ctx, payload.BookingID)
+			if err != nil {
+				if isNotFoundError(err) {
+					return nil
+				}
+				return err
+			}
+
+			return webhooks.Publish(ctx, event, eventType, domain.WebhookBookingData{
+				Booking:        booking,
+				PreviousStatus: payload.PreviousStatus,
+			})
+		}
+	}
+
+	restaurant := func(eventType domain.WebhookEventType) ports.EventHandler {
+		return func(ctx context.Context, event *domain.Event) error {
+			var payload domain.RestaurantEventPayload
+			if err := event.Decode(&payload); err != nil {
+				return err
+			}
+
+			data := domain.WebhookRestaurantData{RestaurantID: payload.RestaurantID}
+			if eventType != domain.WebhookRestaurantDeleted {
+				restaurant, err := restaurantRepo.GetByID(ctx, payload.RestaurantID)
+				if err != nil {
+					if isNotFoundError(err) {
+						return nil
+					}
+					return err
+				}
+				data.Restaurant = restaurant
+			}
+
+			return webhooks.Publish(ctx, event, eventType, data)
+		}
+	}
+
+	bus.Subscribe(domain.EventBookingCreated, booking(domain.WebhookBookingCreated))
+	bus.Subscribe(domain.EventBookingConfirmed, booking(domain.WebhookBookingStatusChanged))
+	bus.Subscribe(domain.EventBookingCancelled, booking(domain.WebhookBookingStatusChanged))
+	bus.Subscribe(domain.EventBookingCompleted, booking(domain.WebhookBookingStatusChanged))
+	bus.Subscribe(domain.EventBookingNoShow, booking(domain.WebhookBookingStatusChanged))
+	bus.Subscribe(domain.EventRestaurantCreated, restaurant(domain.WebhookRestaurantCreated))
+	bus.Subscribe(domain.EventRestaurantUpdated, restaurant(domain.WebhookRestaurantUpdated))
+	bus.Subscribe(domain.EventRestaurantDeleted, restaurant(domain.WebhookRestaurantDeleted))
+}
+
+// ignoreNotFound drops errors about records deleted since the event was recorded
+func ignoreNotFound(err error) error {
+	if err != nil && isNotFoundError(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+// loginGuard slows down and then locks out repeated failed logins, both against one
+// account and from one client IP. Accounts are keyed by email whether or not they
+// exist, so the guard doesn't reveal which do.
+type loginGuard struct {
+	store         ports.LoginAttemptStore
+	accountPolicy domain.LockoutPolicy
+	ipPolicy      domain.LockoutPolicy
+	config        config.LoginConfig
+	logger        *logger.Logger
+}
+
+func NewLoginGuard(store ports.LoginAttemptStore, config config.LoginConfig, logger *logger.Logger) *loginGuard {
+	window := time.Duration(config.WindowMinutes) * time.Minute
+	lockout := time.Duration(config.LockoutMinutes) * time.Minute
+
+	return &loginGuard{
+		store: store,
+		accountPolicy: domain.LockoutPolicy{
+			Window:      window,
+			MaxFailures: config.AccountMaxFailures,
+			Lockout:     lockout,
+		},
+		ipPolicy: domain.LockoutPolicy{
+			Window:      window,
+			MaxFailures: config.IPMaxFailures,
+			Lockout:     lockout,
+		},
+		config: config,
+		logger: logger,
+	}
+}
+
+// Check fails with a rate limited error while the account or the IP is locked out, or
+// hasn't waited long enough since its last failure. A store that can't be reached lets
+// the login through, so an outage of the store doesn't stop everyone signing in.
+func (g *loginGuard) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+
+	for _, key := range g.keys(email, ip) {
+		attempts, err := g.store.Get(ctx, key)
+		if err != nil {
+			g.logger.Error("Failed to check login attempts", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if attempts == nil {
+			continue
+		}
+
+		if attempts.Locked(now) {
+			g.logger.Warn("Login rejected: locked out",
+				zap.String("key", key),
+				zap.Time("lockedUntil", *attempts.LockedUntil),
+			)
+			return g.lockedOutError(key, attempts.LockedUntil.Sub(now))
+		}
+		if attempts.LockedUntil != nil || now.Sub(attempts.LastFailureAt) > g.policy(key).Window {
+			continue
+		}
+
+		if wait := attempts.LastFailureAt.Add(g.delay(attempts.Failures)).Sub(now); wait > 0 {
+			return retryAfterError("too many failed logins; try again in %d seconds", wait)
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login against the account and the IP, and logs the
+// lockouts it causes
+func (g *loginGuard) RecordFailure(ctx context.Context, email, ip string) {
+	for _, key := range g.keys(email, ip) {
+		policy := g.policy(key)
+		attempts, err := g.store.RecordFailure(ctx, key, policy)
+		if err != nil {
+			g.logger.Error("Failed to record login failure", zap.String("key", key), zap.Error(err))
+			continue
+		}
+
+		if attempts.Failures == policy.MaxFailures && attempts.LockedUntil != nil {
+			g.logger.Warn("Login locked out",
+				zap.String("key", key),
+				zap.String("ip", ip),
+				zap.Int("failures", attempts.Failures),
+				zap.Time("lockedUntil", *attempts.LockedUntil),
+			)
+		}
+	}
+}
+
+// RecordSuccess forgets the account's failures. The IP's are kept, so a client can't
+// clear them by signing in to an account it controls.
+func (g *loginGuard) RecordSuccess(ctx context.Context, email string) {
+	if err := g.store.Reset(ctx, accountKey(email)); err != nil {
+		g.logger.Error("Failed to reset login attempts", zap.String("email", email), zap.Error(err))
+	}
+}
+
+// Unlock lifts the account's lockout, once its owner has proved who they are by
+// resetting the password
+func (g *loginGuard) Unlock(ctx context.Context, email string) {
+	if err := g.store.Reset(ctx, accountKey(email)); err != nil {
+		g.logger.Error("Failed to unlock account", zap.String("email", email), zap.Error(err))
+		return
+	}
+
+	g.logger.Info("Login lockout lifted", zap.String("email", email))
+}
+
+func (g *loginGuard) keys(email, ip string) []string {
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func (g *loginGuard) policy(key string) domain.LockoutPolicy {
+	if strings.HasPrefix(key, "ip:") {
+		return g.ipPolicy
+	}
+	return g.accountPolicy
+}
+
+// delay is how long to wait after the given number of failures, doubling with each
+func (g *loginGuard) delay(failures int) time.Duration {
+	seconds := float64(g.config.DelaySeconds) * math.Pow(2, float64(failures-1))
+	if seconds > float64(g.config.MaxDelaySeconds) {
+		seconds = float64(g.config.MaxDelaySeconds)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func (g *loginGuard) lockedOutError(key string, wait time.Duration) error {
+	if strings.HasPrefix(key, "ip:") {
+		return retryAfterError("too many failed logins from this address; try again in %d seconds", wait)
+	}
+	return retryAfterError("too many failed logins; try again in %d seconds or reset your password", wait)
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// retryAfterError is a rate limited error whose details say how many seconds to wait
+func retryAfterError(format string, wait time.Duration) error {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	return apperrors.NewError(apperrors.ErrorTypeRateLimited, fmt.Sprintf(format, seconds),
+		map[string]int64{"retry_after_seconds": seconds})
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+type loyaltyService struct {
+	loyaltyRepo    ports.LoyaltyRepository
+	bookingRepo    ports.BookingRepository
+	userRepo       ports.UserRepository
+	restaurantRepo ports.RestaurantRepository
+	transactor     ports.Transactor
+	config         config.LoyaltyConfig
+	tiers          []domain.LoyaltyTier
+	logger         *logger.Logger
+}
+
+func NewLoyaltyService(
+	loyaltyRepo ports.LoyaltyRepository,
+	bookingRepo ports.BookingRepository,
+	userRepo ports.UserRepository,
+	restaurantRepo ports.RestaurantRepository,
+	transactor ports.Transactor,
+	config config.LoyaltyConfig,
+	logger *logger.Logger,
+) *loyaltyService {
+	tiers := make([]domain.LoyaltyTier, len(config.Tiers))
+	for i, tier := range config.Tiers {
+		tiers[i] = domain.LoyaltyTier{
+			Name:      tier.Name,
+			MinPoints: tier.MinPoints,
+			Perks:     tier.Perks,
+		}
+		if tiers[i].Perks == nil {
+			tiers[i].Perks = []string{}
+		}
+	}
+
+	return &loyaltyService{
+		loyaltyRepo:    loyaltyRepo,
+		bookingRepo:    bookingRepo,
+		userRepo:       userRepo,
+		restaurantRepo: restaurantRepo,
+		transactor:     transactor,
+		config:         config,
+		tiers:          tiers,
+		logger:         logger,
+	}
+}
+
+func (s *loyaltyService) GetAccount(ctx context.Context, userID int64) (*domain.LoyaltyAccount, error) {
+	balance, lifetime, err := s.loyaltyRepo.GetTotals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tier, next := domain.TierFor(s.tiers, lifetime)
+	return &domain.LoyaltyAccount{
+		UserID:         userID,
+		Balance:        balance,
+		LifetimePoints: lifetime,
+		Tier:           tier,
+		NextTier:       next,
+	}, nil
+}
+
+func (s *loyaltyService) ListEntries(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.LoyaltyEntry], error) {
+	return s.loyaltyRepo.ListEntries(ctx, userID, page.Normalize())
+}
+
+// Adjust adds an admin correction to a user's ledger. The reason is kept for audits.
+func (s *loyaltyService) Adjust(ctx context.Context, entry *domain.LoyaltyEntry) error {
+	entry.Reason = strings.TrimSpace(entry.Reason)
+	if entry.Reason == "" {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "reason is required", nil)
+	}
+	if entry.Points == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "points must not be zero", nil)
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, entry.UserID); err != nil {
+		return err
+	}
+
+	entry.Kind = domain.LoyaltyAdjustment
+	if _, err := s.loyaltyRepo.Append(ctx, entry); err != nil {
+		s.logger.Error("Failed to adjust loyalty points",
+			zap.Int64("userID", entry.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	s.logger.Info("Adjusted loyalty points",
+		zap.Int64("userID", entry.UserID),
+		zap.Int("points", entry.Points),
+		zap.Int64p("adminID", entry.CreatedBy),
+	)
+	return nil
+}
+
+// GetRules returns the restaurant's accrual rules, or the defaults if it has none
+func (s *loyaltyService) GetRules(ctx context.Context, restaurantID int64) (*domain.LoyaltyRules, error) {
+	rules, err := s.loyaltyRepo.GetRules(ctx, restaurantID)
+	if err == nil || !isNotFoundError(err) {
+		return rules, err
+	}
+
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return &domain.LoyaltyRules{
+		RestaurantID:     restaurantID,
+		Enabled:          true,
+		PointsPerBooking: s.config.PointsPerBooking,
+		PointsPerGuest:   s.config.PointsPerGuest,
+		NoShowPenalty:    s.config.NoShowPenalty,
+	}, nil
+}
+
+func (s *loyaltyService) UpdateRules(ctx context.Context, rules *domain.LoyaltyRules) error {
+	s.logger.Info("Updating loyalty rules", zap.Int64("restaurantID", rules.RestaurantID))
+	return s.loyaltyRepo.SaveRules(ctx, rules)
+}
+
+// AwardBooking credits the points for a completed booking. It does nothing if the
+// booking has since been marked as a no-show, or was already credited.
+func (s *loyaltyService) AwardBooking(ctx context.Context, bookingID int64) error {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	if booking.Status != domain.BookingStatusCompleted {
+		return nil
+	}
+
+	rules, err := s.GetRules(ctx, booking.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	points := rules.PointsFor(booking.NumberOfGuests)
+	if points <= 0 {
+		return nil
+	}
+
+	entry := &domain.LoyaltyEntry{
+		UserID:       booking.UserID,
+		Kind:         domain.LoyaltyEarned,
+		Points:       points,
+		BookingID:    &booking.ID,
+		RestaurantID: &booking.RestaurantID,
+		Reason:       fmt.Sprintf("Dined at %s", booking.RestaurantName),
+	}
+
+	if _, err := s.loyaltyRepo.Append(ctx, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PenalizeNoShow revokes the points a booking earned, if it was completed before being
+// marked as a no-show, and applies the restaurant's no-show penalty
+func (s *loyaltyService) PenalizeNoShow(ctx context.Context, bookingID int64) error {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	if booking.Status != domain.BookingStatusNoShow {
+		return nil
+	}
+
+	rules, err := s.GetRules(ctx, booking.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		earned, err := s.loyaltyRepo.GetBookingEntry(ctx, booking.ID, domain.LoyaltyEarned)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+
+		if earned != nil {
+			_, err := s.loyaltyRepo.Append(ctx, &domain.LoyaltyEntry{
+				UserID:       booking.UserID,
+				Kind:         domain.LoyaltyRevoked,
+				Points:       -earned.Points,
+				BookingID:    &booking.ID,
+				RestaurantID: &booking.RestaurantID,
+				Reason:       fmt.Sprintf("No-show at %s", booking.RestaurantName),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !rules.Enabled || rules.NoShowPenalty <= 0 {
+			return nil
+		}
+
+		_, err = s.loyaltyRepo.Append(ctx, &domain.LoyaltyEntry{
+			UserID:       booking.UserID,
+			Kind:         domain.LoyaltyPenalty,
+			Points:       -rules.NoShowPenalty,
+			BookingID:    &booking.ID,
+			RestaurantID: &booking.RestaurantID,
+			Reason:       fmt.Sprintf("No-show penalty at %s", booking.RestaurantName),
+		})
+		return err
+	})
+}
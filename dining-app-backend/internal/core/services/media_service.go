@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/imaging"
+	"go.uber.org/zap"
+)
+
+// imageExtensions lists the accepted content types, as sniffed from the upload itself
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type mediaService struct {
+	imageRepo      ports.RestaurantImageRepository
+	restaurantRepo ports.RestaurantRepository
+	blobs          ports.BlobStore
+	transactor     ports.Transactor
+	config         config.MediaConfig
+	logger         *logger.Logger
+}
+
+func NewMediaService(
+	imageRepo ports.RestaurantImageRepository,
+	restaurantRepo ports.RestaurantRepository,
+	blobs ports.BlobStore,
+	transactor ports.Transactor,
+	config config.MediaConfig,
+	logger *logger.Logger,
+) *mediaService {
+	return &mediaService{
+		imageRepo:      imageRepo,
+		restaurantRepo: restaurantRepo,
+		blobs:          blobs,
+		transactor:     transactor,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// UploadImage checks the upload, stores it with a thumbnail and records it. A new
+// cover replaces the previous one.
+func (s *mediaService) UploadImage(ctx context.Context, restaurantID int64, kind domain.ImageKind, data io.Reader) (*domain.RestaurantImage, error) {
+	if !kind.IsValid() {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid image kind", nil)
+	}
+
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(data, s.config.MaxUploadBytes+1))
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "failed to read upload", err)
+	}
+	if int64(len(content)) > s.config.MaxUploadBytes {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation,
+			fmt.Sprintf("image must be at most %d bytes", s.config.MaxUploadBytes), nil)
+	}
+
+	contentType := http.DetectContentType(content)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "image must be a JPEG, PNG or GIF", nil)
+	}
+
+	info, err := imaging.Inspect(content, s.config.MaxPixels)
+	if err != nil {
+		if errors.Is(err, imaging.ErrTooLarge) {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "image dimensions are too large", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "image could not be decoded", nil)
+	}
+
+	if kind == domain.ImageKindGallery {
+		count, err := s.imageRepo.Count(ctx, restaurantID, kind)
+		if err != nil {
+			return nil, err
+		}
+		if count >= s.config.MaxGalleryImages {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation,
+				fmt.Sprintf("a restaurant can have at most %d gallery images", s.config.MaxGalleryImages), nil)
+		}
+	}
+
+	var thumbnail bytes.Buffer
+	if err := imaging.Thumbnail(&thumbnail, content, s.config.ThumbnailSize, s.config.ThumbnailQuality); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "image could not be decoded", nil)
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to name image", err)
+	}
+
+	prefix := domain.RestaurantBlobPrefix(restaurantID)
+	image := &domain.RestaurantImage{
+		RestaurantID: restaurantID,
+		Kind:         kind,
+		BlobKey:      prefix + name + extension,
+		ThumbnailKey: prefix + name + "_thumb.jpg",
+		ContentType:  contentType,
+		Width:        info.Width,
+		Height:       info.Height,
+		SizeBytes:    int64(len(content)),
+	}
+
+	if err := s.blobs.Put(ctx, image.BlobKey, contentType, bytes.NewReader(content)); err != nil {
+		s.logger.Error("Failed to store image", zap.String("key", image.BlobKey), zap.Error(err))
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to store image", err)
+	}
+	if err := s.blobs.Put(ctx, image.ThumbnailKey, "image/jpeg", &thumbnail); err != nil {
+		s.logger.Error("Failed to store thumbnail", zap.String("key", image.ThumbnailKey), zap.Error(err))
+		s.deleteBlobs(ctx, image)
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to store image", err)
+	}
+
+	var replaced *domain.RestaurantImage
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if kind == domain.ImageKindCover {
+			cover, err := s.imageRepo.GetCover(ctx, restaurantID)
+			if err != nil && !isNotFoundError(err) {
+				return err
+			}
+			if cover != nil {
+				if err := s.imageRepo.Delete(ctx, cover.ID); err != nil {
+					return err
+				}
+				replaced = cover
+			}
+		}
+		return s.imageRepo.Create(ctx, image)
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, image)
+		return nil, err
+	}
+
+	if replaced != nil {
+		s.deleteBlobs(ctx, replaced)
+	}
+
+	s.logger.Info("Uploaded restaurant image",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int64("imageID", image.ID),
+		zap.String("kind", string(kind)),
+	)
+
+	resolveImageURLs(s.blobs, image)
+	return image, nil
+}
+
+func (s *mediaService) ListImages(ctx context.Context, restaurantID int64) ([]*domain.RestaurantImage, error) {
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	images, err := s.imageRepo.ListByRestaurants(ctx, []int64{restaurantID}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resolveImageURLs(s.blobs, images...)
+	return images, nil
+}
+
+func (s *mediaService) DeleteImage(ctx context.Context, restaurantID int64, imageID int64) error {
+	image, err := s.imageRepo.GetByID(ctx, imageID)
+	if err != nil {
+		return err
+	}
+
+	if image.RestaurantID != restaurantID {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "image not found", nil)
+	}
+
+	if err := s.imageRepo.Delete(ctx, imageID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Deleted restaurant image",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int64("imageID", imageID),
+	)
+
+	s.deleteBlobs(ctx, image)
+	return nil
+}
+
+// PurgeRestaurant removes every blob stored for a deleted restaurant. Its image rows
+// are removed with it by the database.
+func (s *mediaService) PurgeRestaurant(ctx context.Context, restaurantID int64) error {
+	prefix := domain.RestaurantBlobPrefix(restaurantID)
+	if err := s.blobs.DeletePrefix(ctx, prefix); err != nil {
+		s.logger.Error("Failed to purge restaurant images",
+			zap.Int64("restaurantID", restaurantID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	s.logger.Info("Purged restaurant images", zap.Int64("restaurantID", restaurantID))
+	return nil
+}
+
+// deleteBlobs removes an image's files once it is no longer recorded. A failure only
+// leaves an orphaned file behind, so it is logged rather than returned.
+func (s *mediaService) deleteBlobs(ctx context.Context, image *domain.RestaurantImage) {
+	for _, key := range []string{image.BlobKey, image.ThumbnailKey} {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			s.logger.Warn("Failed to delete image blob", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// resolveImageURLs sets the URLs clients fetch the images from
+func resolveImageURLs(blobs ports.BlobStore, images ...*domain.RestaurantImage) {
+	for _, image := range images {
+		image.URL = blobs.URL(image.BlobKey)
+		image.ThumbnailURL = blobs.URL(image.ThumbnailKey)
+	}
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
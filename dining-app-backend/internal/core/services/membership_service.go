@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+// inviteTemplateData is the data available to the staff invite templates
+type inviteTemplateData struct {
+	InviterName    string
+	RestaurantName string
+	Role           string
+	AcceptURL      string
+	ExpiresAt      string
+}
+
+type membershipService struct {
+	membershipRepo ports.MembershipRepository
+	userRepo       ports.UserRepository
+	restaurantRepo ports.RestaurantRepository
+	authorizer     ports.Authorizer
+	notifications  ports.NotificationService
+	transactor     ports.Transactor
+	config         config.MembershipConfig
+	logger         *logger.Logger
+}
+
+func NewMembershipService(
+	membershipRepo ports.MembershipRepository,
+	userRepo ports.UserRepository,
+	restaurantRepo ports.RestaurantRepository,
+	authorizer ports.Authorizer,
+	notifications ports.NotificationService,
+	transactor ports.Transactor,
+	config config.MembershipConfig,
+	logger *logger.Logger,
+) *membershipService {
+	return &membershipService{
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		restaurantRepo: restaurantRepo,
+		authorizer:     authorizer,
+		notifications:  notifications,
+		transactor:     transactor,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// Authorize checks that the user holds at least the required role at the restaurant
+// the resource belongs to. Users with the resource's manage permission may act on every
+// restaurant.
+func (s *membershipService) Authorize(ctx context.Context, userID int64, resource domain.RestaurantResource, resourceID int64, required domain.MembershipRole) error {
+	manager, err := s.authorizer.HasPermission(ctx, userID, resource.ManagePermission())
+	if err != nil {
+		return err
+	}
+	if manager {
+		return nil
+	}
+
+	restaurantID, err := s.membershipRepo.GetRestaurantID(ctx, resource, resourceID)
+	if err != nil {
+		return err
+	}
+
+	membership, err := s.membershipRepo.Get(ctx, restaurantID, userID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "you don't have access to this restaurant", nil)
+		}
+		return err
+	}
+
+	if !membership.Role.Allows(required) {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized,
+			fmt.Sprintf("%s access to this restaurant required", required), nil)
+	}
+
+	return nil
+}
+
+// ListMembers pages through the restaurant's staff by offset only; they're listed by
+// role, which cursors can't continue
+func (s *membershipService) ListMembers(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Membership], error) {
+	if page.Cursor != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "members are paged by page number, not cursor", nil)
+	}
+
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return s.membershipRepo.ListByRestaurant(ctx, restaurantID, page.Normalize())
+}
+
+func (s *membershipService) ListUserMemberships(ctx context.Context, userID int64) ([]*domain.Membership, error) {
+	return s.membershipRepo.ListByUser(ctx, userID)
+}
+
+// UpdateMemberRole changes a member's role. The last owner can't be demoted.
+func (s *membershipService) UpdateMemberRole(ctx context.Context, restaurantID, userID int64, role domain.MembershipRole) (*domain.Membership, error) {
+	s.logger.Info("Updating membership role",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int64("userID", userID),
+		zap.String("role", string(role)),
+	)
+
+	if !role.IsValid() {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid role", nil)
+	}
+
+	var membership *domain.Membership
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.keepAnOwner(ctx, restaurantID, userID, role); err != nil {
+			return err
+		}
+
+		if err := s.membershipRepo.UpdateRole(ctx, restaurantID, userID, role); err != nil {
+			return err
+		}
+
+		var err error
+		membership, err = s.membershipRepo.Get(ctx, restaurantID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// RemoveMember takes the user off the restaurant's staff. The last owner can't be
+// removed.
+func (s *membershipService) RemoveMember(ctx context.Context, restaurantID, userID int64) error {
+	s.logger.Info("Removing membership",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int64("userID", userID),
+	)
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.keepAnOwner(ctx, restaurantID, userID, ""); err != nil {
+			return err
+		}
+		return s.membershipRepo.Delete(ctx, restaurantID, userID)
+	})
+}
+
+// keepAnOwner rejects giving the user the new role, or none, when they are the
+// restaurant's only owner. It must run in a transaction, which keeps the owners locked.
+func (s *membershipService) keepAnOwner(ctx context.Context, restaurantID, userID int64, role domain.MembershipRole) error {
+	owners, err := s.membershipRepo.LockOwners(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	if role != domain.MembershipRoleOwner && len(owners) == 1 && owners[0] == userID {
+		return apperrors.NewError(apperrors.ErrorTypeConflict, "a restaurant must keep at least one owner", nil)
+	}
+
+	return nil
+}
+
+// InviteMember emails the invitee a link to join the restaurant with the role. A new
+// invite to the same email replaces the previous one.
+func (s *membershipService) InviteMember(ctx context.Context, invite *domain.MembershipInvite) error {
+	invite.Email = domain.NormalizeEmail(invite.Email)
+
+	s.logger.Info("Inviting restaurant member",
+		zap.Int64("restaurantID", invite.RestaurantID),
+		zap.String("role", string(invite.Role)),
+	)
+
+	if !invite.Role.IsValid() {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "invalid role", nil)
+	}
+
+	restaurant, err := s.restaurantRepo.GetByID(ctx, invite.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	invitee, err := s.userRepo.GetByEmail(ctx, invite.Email)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+	if invitee != nil {
+		if _, err := s.membershipRepo.Get(ctx, invite.RestaurantID, invitee.ID); err == nil {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "user is already a member of this restaurant", nil)
+		} else if !isNotFoundError(err) {
+			return err
+		}
+	}
+
+	inviterName := restaurant.Name
+	if invite.InvitedBy != nil {
+		inviter, err := s.userRepo.GetByID(ctx, *invite.InvitedBy)
+		if err != nil {
+			return err
+		}
+		inviterName = inviter.Name
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate invite token", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate invite token", err)
+	}
+
+	invite.TokenHash = auth.HashToken(token)
+	invite.ExpiresAt = time.Now().Add(time.Duration(s.config.InviteTTLHours) * time.Hour)
+
+	if err := s.membershipRepo.CreateInvite(ctx, invite); err != nil {
+		s.logger.Error("Failed to create invite", zap.Error(err))
+		return err
+	}
+
+	message := &domain.EmailMessage{
+		Kind:      domain.NotificationStaffInvite,
+		Recipient: invite.Email,
+		Reference: fmt.Sprintf("invite:%d", invite.ID),
+		Data: inviteTemplateData{
+			InviterName:    inviterName,
+			RestaurantName: restaurant.Name,
+			Role:           string(invite.Role),
+			AcceptURL:      tokenURL(s.config.InviteURL, token),
+			ExpiresAt:      invite.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		},
+	}
+	if invitee != nil {
+		message.UserID = &invitee.ID
+	}
+
+	return s.notifications.NotifyEmail(ctx, message)
+}
+
+func (s *membershipService) ListInvites(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.MembershipInvite], error) {
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return s.membershipRepo.ListPendingInvites(ctx, restaurantID, page.Normalize())
+}
+
+func (s *membershipService) RevokeInvite(ctx context.Context, restaurantID, inviteID int64) error {
+	s.logger.Info("Revoking invite",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int64("inviteID", inviteID),
+	)
+
+	return s.membershipRepo.DeleteInvite(ctx, restaurantID, inviteID)
+}
+
+// AcceptInvite makes the user a member with the invited role. The user's email must be
+// the one the invite was sent to.
+func (s *membershipService) AcceptInvite(ctx context.Context, userID int64, token string) (*domain.Membership, error) {
+	invite, err := s.membershipRepo.GetPendingInvite(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.NormalizeEmail(user.Email) != invite.Email {
+		return nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "this invite was sent to a different email address", nil)
+	}
+
+	membership := &domain.Membership{
+		RestaurantID: invite.RestaurantID,
+		UserID:       userID,
+		Role:         invite.Role,
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.membershipRepo.MarkInviteAccepted(ctx, invite.ID); err != nil {
+			return err
+		}
+		return s.membershipRepo.Create(ctx, membership)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invite accepted",
+		zap.Int64("restaurantID", invite.RestaurantID),
+		zap.Int64("userID", userID),
+		zap.String("role", string(invite.Role)),
+	)
+
+	membership.UserName = user.Name
+	membership.UserEmail = user.Email
+	return membership, nil
+}
+
+// tokenURL appends the token to the link as the token query parameter
+func tokenURL(base, token string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(token)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+type menuService struct {
+	menuRepo ports.MenuRepository
+	logger   *logger.Logger
+}
+
+func NewMenuService(menuRepo ports.MenuRepository, logger *logger.Logger) *menuService {
+	return &menuService{
+		menuRepo: menuRepo,
+		logger:   logger,
+	}
+}
+
+// GetMenu returns the published menu, narrowed down by the filter
+func (s *menuService) GetMenu(ctx context.Context, restaurantID int64, filter domain.MenuFilter) (*domain.Menu, error) {
+	menu, err := s.menuRepo.GetPublished(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	menu.Content = menu.Content.Filter(filter)
+	return menu, nil
+}
+
+// GetDraft returns the draft, or the published menu to start a new draft from
+func (s *menuService) GetDraft(ctx context.Context, restaurantID int64) (*domain.Menu, error) {
+	draft, err := s.menuRepo.GetDraft(ctx, restaurantID)
+	if err == nil || !isNotFoundError(err) {
+		return draft, err
+	}
+
+	return s.menuRepo.GetPublished(ctx, restaurantID)
+}
+
+func (s *menuService) SaveDraft(ctx context.Context, menu *domain.Menu) error {
+	s.logger.Info("Saving menu draft", zap.Int64("restaurantID", menu.RestaurantID))
+
+	if err := menu.Content.Validate(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil)
+	}
+
+	return s.menuRepo.SaveDraft(ctx, menu)
+}
+
+func (s *menuService) Publish(ctx context.Context, restaurantID int64, userID int64) (*domain.Menu, error) {
+	menu, err := s.menuRepo.PublishDraft(ctx, restaurantID, userID)
+	if err != nil {
+		s.logger.Error("Failed to publish menu",
+			zap.Int64("restaurantID", restaurantID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	s.logger.Info("Published menu",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int("version", menu.Version),
+	)
+	return menu, nil
+}
+
+func (s *menuService) ListVersions(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Menu], error) {
+	return s.menuRepo.ListVersions(ctx, restaurantID, page.Normalize())
+}
+
+func (s *menuService) GetVersion(ctx context.Context, restaurantID int64, version int) (*domain.Menu, error) {
+	return s.menuRepo.GetVersion(ctx, restaurantID, version)
+}
+
+// Rollback publishes an earlier version again. The draft is left as it is.
+func (s *menuService) Rollback(ctx context.Context, restaurantID int64, version int, userID int64) (*domain.Menu, error) {
+	menu, err := s.menuRepo.Republish(ctx, restaurantID, version, userID)
+	if err != nil {
+		s.logger.Error("Failed to roll back menu",
+			zap.Int64("restaurantID", restaurantID),
+			zap.Int("version", version),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	s.logger.Info("Rolled back menu",
+		zap.Int64("restaurantID", restaurantID),
+		zap.Int("fromVersion", version),
+		zap.Int("version", menu.Version),
+	)
+	return menu, nil
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+const (
+	notificationBatchSize   = 50
+	notificationLease       = 5 * time.Minute
+	notificationSendTimeout = 30 * time.Second
+	notificationBaseBackoff = 30 * time.Second
+	notificationMaxBackoff  = time.Hour
+)
+
+type notificationService struct {
+	notificationRepo ports.NotificationRepository
+	preferenceRepo   ports.NotificationPreferenceRepository
+	userRepo         ports.UserRepository
+	bookingRepo      ports.BookingRepository
+	renderer         ports.MessageRenderer
+	notifiers        map[domain.NotificationChannel]ports.Notifier
+	config           config.NotificationConfig
+	logger           *logger.Logger
+}
+
+func NewNotificationService(
+	notificationRepo ports.NotificationRepository,
+	preferenceRepo ports.NotificationPreferenceRepository,
+	userRepo ports.UserRepository,
+	bookingRepo ports.BookingRepository,
+	renderer ports.MessageRenderer,
+	notifiers []ports.Notifier,
+	config config.NotificationConfig,
+	logger *logger.Logger,
+) *notificationService {
+	byChannel := make(map[domain.NotificationChannel]ports.Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		userRepo:         userRepo,
+		bookingRepo:      bookingRepo,
+		renderer:         renderer,
+		notifiers:        byChannel,
+		config:           config,
+		logger:           logger,
+	}
+}
+
+// bookingTemplateData is the data available to booking notification templates
+type bookingTemplateData struct {
+	UserName       string
+	RestaurantName string
+	Address        string
+	TableNumber    string
+	Date           string
+	StartTime      string
+	EndTime        string
+	Guests         int
+}
+
+// NotifyBooking queues a message about the booking on every channel the user allows.
+// Calling it again for the same booking and kind has no effect.
+func (s *notificationService) NotifyBooking(ctx context.Context, kind domain.NotificationKind, bookingID int64) error {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		s.logger.Error("Failed to get booking for notification",
+			zap.Int64("bookingID", bookingID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return s.notifyBooking(ctx, kind, booking)
+}
+
+func (s *notificationService) notifyBooking(ctx context.Context, kind domain.NotificationKind, booking *domain.Booking) error {
+	user, err := s.userRepo.GetByID(ctx, booking.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get user for notification",
+			zap.Int64("userID", booking.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	preferences, err := s.GetPreferences(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	content, err := s.renderer.Render(kind, preferences.Locale, bookingTemplateData{
+		UserName:       user.Name,
+		RestaurantName: booking.RestaurantName,
+		Address:        booking.RestaurantAddress,
+		TableNumber:    booking.TableNumber,
+		Date:           booking.BookingDate.Format("2006-01-02"),
+		StartTime:      booking.StartsAt().Format("15:04"),
+		EndTime:        booking.EndsAt().Format("15:04"),
+		Guests:         booking.NumberOfGuests,
+	})
+	if err != nil {
+		s.logger.Error("Failed to render notification",
+			zap.String("kind", string(kind)),
+			zap.Error(err),
+		)
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to render notification", err)
+	}
+
+	for channel := range s.notifiers {
+		if !preferences.Allows(kind, channel) {
+			continue
+		}
+
+		recipient := user.Email
+		if channel == domain.NotificationChannelSMS {
+			recipient = preferences.Phone
+		}
+
+		subject, body := content.For(channel)
+		notification := &domain.Notification{
+			UserID:    &user.ID,
+			BookingID: &booking.ID,
+			Kind:      kind,
+			Channel:   channel,
+			Recipient: recipient,
+			Subject:   subject,
+			Body:      body,
+			DedupKey:  domain.NotificationDedupKey(kind, fmt.Sprintf("booking:%d", booking.ID), channel),
+		}
+
+		created, err := s.notificationRepo.Enqueue(ctx, notification)
+		if err != nil {
+			s.logger.Error("Failed to enqueue notification", zap.Error(err))
+			return err
+		}
+
+		if created {
+			s.logger.Info("Notification queued",
+				zap.Int64("notificationID", notification.ID),
+				zap.String("kind", string(kind)),
+				zap.String("channel", string(channel)),
+			)
+		}
+	}
+
+	return nil
+}
+
+// NotifyEmail queues a transactional email in the recipient's language, or the default
+// one when they have no account. Calling it again for the same reference has no effect.
+func (s *notificationService) NotifyEmail(ctx context.Context, message *domain.EmailMessage) error {
+	if _, ok := s.notifiers[domain.NotificationChannelEmail]; !ok {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "email notifications are not configured", nil)
+	}
+
+	locale := s.config.DefaultLocale
+	if message.UserID != nil {
+		preferences, err := s.GetPreferences(ctx, *message.UserID)
+		if err != nil {
+			return err
+		}
+		locale = preferences.Locale
+	}
+
+	content, err := s.renderer.Render(message.Kind, locale, message.Data)
+	if err != nil {
+		s.logger.Error("Failed to render notification",
+			zap.String("kind", string(message.Kind)),
+			zap.Error(err),
+		)
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to render notification", err)
+	}
+
+	subject, body := content.For(domain.NotificationChannelEmail)
+	notification := &domain.Notification{
+		UserID:    message.UserID,
+		Kind:      message.Kind,
+		Channel:   domain.NotificationChannelEmail,
+		Recipient: message.Recipient,
+		Subject:   subject,
+		Body:      body,
+		DedupKey:  domain.NotificationDedupKey(message.Kind, message.Reference, domain.NotificationChannelEmail),
+	}
+
+	created, err := s.notificationRepo.Enqueue(ctx, notification)
+	if err != nil {
+		s.logger.Error("Failed to enqueue notification", zap.Error(err))
+		return err
+	}
+
+	if created {
+		s.logger.Info("Notification queued",
+			zap.Int64("notificationID", notification.ID),
+			zap.String("kind", string(message.Kind)),
+			zap.String("channel", string(domain.NotificationChannelEmail)),
+		)
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID int64) (*domain.NotificationPreferences, error) {
+	preferences, err := s.preferenceRepo.Get(ctx, userID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return domain.DefaultNotificationPreferences(userID, s.config.DefaultLocale), nil
+		}
+		s.logger.Error("Failed to get notification preferences",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error {
+	s.logger.Info("Updating notification preferences", zap.Int64("userID", preferences.UserID))
+
+	if preferences.SMSEnabled && preferences.Phone == "" {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "a phone number is required for SMS notifications", nil)
+	}
+
+	if preferences.Locale == "" {
+		preferences.Locale = s.config.DefaultLocale
+	}
+
+	if err := s.preferenceRepo.Upsert(ctx, preferences); err != nil {
+		s.logger.Error("Failed to update notification preferences", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Run delivers queued notifications and schedules booking reminders until ctx is done
+func (s *notificationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		s.scheduleReminders(ctx)
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scheduleReminders queues a reminder for every booking starting within the lead time.
+// Bookings that already have one are skipped by the dedup key.
+func (s *notificationService) scheduleReminders(ctx context.Context) {
+	now := time.Now()
+	lead := time.Duration(s.config.ReminderLeadMinutes) * time.Minute
+
+	bookings, err := s.bookingRepo.GetStartingBetween(ctx, now, now.Add(lead))
+	if err != nil {
+		s.logger.Error("Failed to get upcoming bookings for reminders", zap.Error(err))
+		return
+	}
+
+	for _, booking := range bookings {
+		if err := s.notifyBooking(ctx, domain.NotificationBookingReminder, booking); err != nil {
+			s.logger.Error("Failed to schedule booking reminder",
+				zap.Int64("bookingID", booking.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (s *notificationService) dispatch(ctx context.Context) {
+	notifications, err := s.notificationRepo.ClaimDue(ctx, notificationBatchSize, notificationLease)
+	if err != nil {
+		s.logger.Error("Failed to claim notifications", zap.Error(err))
+		return
+	}
+
+	for _, notification := range notifications {
+		s.deliver(ctx, notification)
+	}
+}
+
+func (s *notificationService) deliver(ctx context.Context, notification *domain.Notification) {
+	notifier, ok := s.notifiers[notification.Channel]
+	if !ok {
+		s.markFailed(ctx, notification, "no notifier for channel", nil)
+		return
+	}
+
+	// A reminder queued before the booking was cancelled must not go out
+	if notification.Kind == domain.NotificationBookingReminder && notification.BookingID != nil {
+		booking, err := s.bookingRepo.GetByID(ctx, *notification.BookingID)
+		if err == nil && booking.Status == domain.BookingStatusCancelled {
+			s.markFailed(ctx, notification, "booking cancelled", nil)
+			return
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	defer cancel()
+
+	if err := notifier.Send(sendCtx, notification); err != nil {
+		var nextAttemptAt *time.Time
+		if notification.Attempts < s.config.MaxAttempts {
+			next := time.Now().Add(notificationBackoff(notification.Attempts))
+			nextAttemptAt = &next
+		}
+		s.logger.Error("Failed to send notification",
+			zap.Int64("notificationID", notification.ID),
+			zap.Int("attempts", notification.Attempts),
+			zap.Error(err),
+		)
+		s.markFailed(ctx, notification, err.Error(), nextAttemptAt)
+		return
+	}
+
+	if err := s.notificationRepo.MarkSent(ctx, notification.ID); err != nil {
+		s.logger.Error("Failed to mark notification as sent",
+			zap.Int64("notificationID", notification.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Notification sent",
+		zap.Int64("notificationID", notification.ID),
+		zap.String("channel", string(notification.Channel)),
+	)
+}
+
+func (s *notificationService) markFailed(ctx context.Context, notification *domain.Notification, reason string, nextAttemptAt *time.Time) {
+	if err := s.notificationRepo.MarkFailed(ctx, notification.ID, reason, nextAttemptAt); err != nil {
+		s.logger.Error("Failed to mark notification as failed",
+			zap.Int64("notificationID", notification.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// notificationBackoff doubles the delay after every attempt, up to an hour
+func notificationBackoff(attempts int) time.Duration {
+	delay := notificationBaseBackoff
+	for i := 1; i < attempts && delay < notificationMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > notificationMaxBackoff {
+		delay = notificationMaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+// maxNameLength is the size of the users.name column
+const maxNameLength = 100
+
+// oidcService signs users in through OpenID Connect providers. The first sign-in with
+// a provider account links it to the user with the same verified email, or creates a
+// user without a password; later sign-ins find the user by the link.
+type oidcService struct {
+	providers     map[string]ports.IdentityProvider
+	order         []string
+	identityRepo  ports.IdentityRepository
+	userRepo      ports.UserRepository
+	outboxRepo    ports.OutboxRepository
+	transactor    ports.Transactor
+	sessions      ports.SessionService
+	verifications ports.VerificationService
+	config        config.OIDCConfig
+	logger        *logger.Logger
+}
+
+func NewOIDCService(
+	providers []ports.IdentityProvider,
+	identityRepo ports.IdentityRepository,
+	userRepo ports.UserRepository,
+	outboxRepo ports.OutboxRepository,
+	transactor ports.Transactor,
+	sessions ports.SessionService,
+	verifications ports.VerificationService,
+	config config.OIDCConfig,
+	logger *logger.Logger,
+) *oidcService {
+	s := &oidcService{
+		providers:     make(map[string]ports.IdentityProvider),
+		identityRepo:  identityRepo,
+		userRepo:      userRepo,
+		outboxRepo:    outboxRepo,
+		transactor:    transactor,
+		sessions:      sessions,
+		verifications: verifications,
+		config:        config,
+		logger:        logger,
+	}
+	for _, provider := range providers {
+		name := provider.Info().Name
+		s.providers[name] = provider
+		s.order = append(s.order, name)
+	}
+	return s
+}
+
+func (s *oidcService) ListProviders(ctx context.Context) []domain.IdentityProviderInfo {
+	providers := make([]domain.IdentityProviderInfo, len(s.order))
+	for i, name := range s.order {
+		providers[i] = s.providers[name].Info()
+	}
+	return providers
+}
+
+// StartLogin remembers a new sign-in with the provider and returns where to send the
+// user. The state, PKCE code verifier and nonce are fresh for each sign-in.
+func (s *oidcService) StartLogin(ctx context.Context, providerName string) (string, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	var state, codeVerifier, nonce string
+	for _, token := range []*string{&state, &codeVerifier, &nonce} {
+		if *token, err = auth.GenerateOpaqueToken(); err != nil {
+			s.logger.Error("Failed to generate sign-in token", zap.Error(err))
+			return "", apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start sign-in", err)
+		}
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	authorizationURL, err := provider.AuthorizationURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		s.logger.Error("Failed to reach identity provider", zap.String("provider", providerName), zap.Error(err))
+		return "", apperrors.NewError(apperrors.ErrorTypeInternal, "identity provider is unavailable", nil)
+	}
+
+	login := &domain.OIDCLogin{
+		StateHash:    auth.HashToken(state),
+		Provider:     providerName,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(time.Duration(s.config.StateTTLMinutes) * time.Minute),
+	}
+	if err := s.identityRepo.CreateLogin(ctx, login); err != nil {
+		return "", err
+	}
+
+	return authorizationURL, nil
+}
+
+// FinishLogin redeems the code the provider sent back with the state, and starts a
+// session for the user the provider account belongs to
+func (s *oidcService) FinishLogin(ctx context.Context, providerName, code, state string, device *domain.Session) (*domain.TokenPair, *domain.User, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	login, err := s.identityRepo.ConsumeLogin(ctx, auth.HashToken(state))
+	if err != nil {
+		return nil, nil, err
+	}
+	if login.Provider != providerName {
+		return nil, nil, apperrors.NewError(apperrors.ErrorTypeValidation, "sign-in was started with another provider", nil)
+	}
+
+	claims, err := provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		s.logger.Warn("Sign-in with identity provider failed", zap.String("provider", providerName), zap.Error(err))
+		return nil, nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "sign-in with "+providerName+" failed", nil)
+	}
+
+	user, err := s.resolveUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.sessions.StartSession(ctx, user, device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user.Password = ""
+
+	s.logger.Info("User signed in with identity provider",
+		zap.Int64("userID", user.ID),
+		zap.String("provider", providerName),
+	)
+	return tokens, user, nil
+}
+
+func (s *oidcService) ListIdentities(ctx context.Context, userID int64) ([]*domain.ExternalIdentity, error) {
+	return s.identityRepo.ListIdentities(ctx, userID)
+}
+
+// resolveUser finds the user linked to the provider account, linking or creating one
+// on its first sign-in
+func (s *oidcService) resolveUser(ctx context.Context, providerName string, claims *domain.ExternalClaims) (*domain.User, error) {
+	identity, err := s.identityRepo.GetIdentity(ctx, providerName, claims.Subject)
+	if err == nil {
+		if err := s.identityRepo.RecordIdentityLogin(ctx, identity.ID, claims.Email); err != nil {
+			return nil, err
+		}
+		return s.userRepo.GetByID(ctx, identity.UserID)
+	}
+	if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if claims.Email == "" {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, providerName+" did not share an email address", nil)
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, claims.Email)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	if existing != nil {
+		return s.link(ctx, providerName, claims, existing)
+	}
+	return s.createUser(ctx, providerName, claims)
+}
+
+// link adds the provider account to the user with its email. Both sides must have
+// verified the email, or whoever registered it first could take over the other's
+// account.
+func (s *oidcService) link(ctx context.Context, providerName string, claims *domain.ExternalClaims, user *domain.User) (*domain.User, error) {
+	if !claims.EmailVerified {
+		return nil, apperrors.NewError(apperrors.ErrorTypeConflict,
+			"an account with this email already exists; "+providerName+" hasn't verified the email", nil)
+	}
+	if !user.EmailVerified() {
+		return nil, apperrors.NewError(apperrors.ErrorTypeConflict,
+			"an account with this email already exists; verify its email, then sign in with "+providerName+" again", nil)
+	}
+
+	identity := &domain.ExternalIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	if err := s.identityRepo.CreateIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Identity linked", zap.Int64("userID", user.ID), zap.String("provider", providerName))
+	return user, nil
+}
+
+// createUser registers a user for the provider account. The user has no password, and
+// can set one through the forgot password flow.
+func (s *oidcService) createUser(ctx context.Context, providerName string, claims *domain.ExternalClaims) (*domain.User, error) {
+	name := strings.TrimSpace(claims.Name)
+	if len(name) < 2 {
+		name = claims.Email
+	}
+
+	user := &domain.User{
+		Name:  truncate(name, maxNameLength),
+		Email: claims.Email,
+		Role:  domain.RoleUser,
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if claims.EmailVerified {
+			if err := s.userRepo.MarkEmailVerified(ctx, user.ID, user.Email); err != nil {
+				return err
+			}
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+		if err := s.identityRepo.CreateIdentity(ctx, &domain.ExternalIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		}); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewUserRegisteredEvent(user))
+	})
+	if err != nil {
+		s.logger.Error("Failed to create user for identity", zap.String("provider", providerName), zap.Error(err))
+		return nil, err
+	}
+
+	if !user.EmailVerified() {
+		// The account exists either way; the user can ask for another link
+		if err := s.verifications.SendVerification(ctx, user); err != nil {
+			s.logger.Error("Failed to send verification email", zap.Int64("userID", user.ID), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("User registered with identity provider",
+		zap.Int64("userID", user.ID),
+		zap.String("provider", providerName),
+	)
+	return user, nil
+}
+
+func (s *oidcService) provider(name string) (ports.IdentityProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "unknown identity provider", nil)
+	}
+	return provider, nil
+}
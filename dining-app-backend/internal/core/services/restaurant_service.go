@@ -2,15 +2,24 @@ package services
 
 import (
 	"context"
+	"strings"
 
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
 	"go.uber.org/zap"
 )
 
 type restaurantService struct {
 	restaurantRepo ports.RestaurantRepository
+	imageRepo      ports.RestaurantImageRepository
+	geocoder       ports.Geocoder
+	blobs          ports.BlobStore
+	outboxRepo     ports.OutboxRepository
+	transactor     ports.Transactor
+	config         config.GeoConfig
 	logger         *logger.Logger
 }
 
@@ -25,30 +34,195 @@ func (s *restaurantService) GetByID(ctx context.Context, id int64) (*domain.Rest
 		)
 		return nil, err
 	}
+
+	if err := s.attachImages(ctx, "", restaurant); err != nil {
+		return nil, err
+	}
 	return restaurant, nil
 }
 
 func (s *restaurantService) Create(ctx context.Context, restaurant *domain.Restaurant) error {
 	s.logger.Info("Creating restaurant", zap.String("name", restaurant.Name))
-	return s.restaurantRepo.Create(ctx, restaurant)
+	restaurant.SetDefaults()
+	if err := s.locate(ctx, restaurant); err != nil {
+		return err
+	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.restaurantRepo.Create(ctx, restaurant); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewRestaurantEvent(domain.EventRestaurantCreated, restaurant.ID))
+	})
 }
 
 func (s *restaurantService) Delete(ctx context.Context, id int64) error {
 	s.logger.Info("Deleting restaurant", zap.Int64("restaurantID", id))
-	return s.restaurantRepo.Delete(ctx, id)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.restaurantRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewRestaurantEvent(domain.EventRestaurantDeleted, id))
+	})
 }
 
-func (s *restaurantService) List(ctx context.Context, offset, limit int) ([]*domain.Restaurant, error) {
-	s.logger.Info("Listing restaurants", zap.Int("offset", offset), zap.Int("limit", limit))
-	return s.restaurantRepo.List(ctx, offset, limit)
+// Search lists restaurants matching the search. Without a query, relevance has nothing
+// to rank by, so results are sorted by distance when searching near a point and by
+// name otherwise.
+func (s *restaurantService) Search(ctx context.Context, search domain.RestaurantSearch) (*domain.Page[*domain.RestaurantSearchResult], error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Sort == "" || (search.Sort == domain.RestaurantSortRelevance && search.Query == "") {
+		switch {
+		case search.Query != "":
+			search.Sort = domain.RestaurantSortRelevance
+		case search.Near != nil:
+			search.Sort = domain.RestaurantSortDistance
+		default:
+			search.Sort = domain.RestaurantSortName
+		}
+	}
+
+	if search.Near == nil {
+		if search.Sort == domain.RestaurantSortDistance {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "sorting by distance requires a location", nil)
+		}
+	} else {
+		if search.RadiusKm <= 0 {
+			search.RadiusKm = s.config.DefaultRadiusKm
+		}
+		if s.config.MaxRadiusKm > 0 && search.RadiusKm > s.config.MaxRadiusKm {
+			search.RadiusKm = s.config.MaxRadiusKm
+		}
+	}
+
+	search.Page = search.Page.Normalize()
+	if cursor := search.Page.Cursor; cursor != nil {
+		if !search.Sort.SupportsCursor() {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "cursors are only supported when sorting by name or newest", nil)
+		}
+		if cursor.Sort != string(search.Sort) {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "cursor was issued for a different sort", nil)
+		}
+	}
+
+	s.logger.Info("Searching restaurants",
+		zap.String("query", search.Query),
+		zap.Strings("cuisines", search.Cuisines),
+		zap.Bool("openNow", search.OpenNow),
+		zap.String("sort", string(search.Sort)),
+		zap.Float64("radiusKm", search.RadiusKm),
+	)
+
+	page, err := s.restaurantRepo.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurants := make([]*domain.Restaurant, len(page.Items))
+	for i, result := range page.Items {
+		restaurants[i] = &result.Restaurant
+	}
+	if err := s.attachImages(ctx, domain.ImageKindCover, restaurants...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
+
 func (s *restaurantService) Update(ctx context.Context, restaurant *domain.Restaurant) error {
 	s.logger.Info("Updating restaurant", zap.Int64("restaurantID", restaurant.ID))
-	return s.restaurantRepo.Update(ctx, restaurant)
+	if err := s.locate(ctx, restaurant); err != nil {
+		return err
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.restaurantRepo.Update(ctx, restaurant); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewRestaurantEvent(domain.EventRestaurantUpdated, restaurant.ID))
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.attachImages(ctx, "", restaurant)
 }
-func NewRestaurantService(restaurantRepo ports.RestaurantRepository, logger *logger.Logger) *restaurantService {
+
+// attachImages loads the restaurants' images of the kind, or of every kind when empty
+func (s *restaurantService) attachImages(ctx context.Context, kind domain.ImageKind, restaurants ...*domain.Restaurant) error {
+	if len(restaurants) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(restaurants))
+	byID := make(map[int64]*domain.Restaurant, len(restaurants))
+	for i, restaurant := range restaurants {
+		ids[i] = restaurant.ID
+		byID[restaurant.ID] = restaurant
+		restaurant.Images = []*domain.RestaurantImage{}
+	}
+
+	images, err := s.imageRepo.ListByRestaurants(ctx, ids, kind)
+	if err != nil {
+		return err
+	}
+
+	resolveImageURLs(s.blobs, images...)
+	for _, image := range images {
+		restaurant := byID[image.RestaurantID]
+		restaurant.Images = append(restaurant.Images, image)
+	}
+	return nil
+}
+
+// locate checks the restaurant's coordinates, or geocodes its address when it has none.
+// An address the geocoder doesn't recognise leaves the restaurant without a location.
+func (s *restaurantService) locate(ctx context.Context, restaurant *domain.Restaurant) error {
+	if (restaurant.Latitude == nil) != (restaurant.Longitude == nil) {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "latitude and longitude must be set together", nil)
+	}
+
+	if point := restaurant.Location(); point != nil {
+		if err := point.Validate(); err != nil {
+			return apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil)
+		}
+		return nil
+	}
+
+	if s.geocoder == nil {
+		return nil
+	}
+
+	point, err := s.geocoder.Geocode(ctx, restaurant.Address)
+	if err != nil {
+		s.logger.Warn("Failed to geocode restaurant address",
+			zap.String("address", restaurant.Address),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	restaurant.SetLocation(point)
+	return nil
+}
+
+// NewRestaurantService creates the service. The geocoder is nil when geocoding is disabled.
+func NewRestaurantService(
+	restaurantRepo ports.RestaurantRepository,
+	imageRepo ports.RestaurantImageRepository,
+	geocoder ports.Geocoder,
+	blobs ports.BlobStore,
+	outboxRepo ports.OutboxRepository,
+	transactor ports.Transactor,
+	config config.GeoConfig,
+	logger *logger.Logger,
+) *restaurantService {
 	return &restaurantService{
 		restaurantRepo: restaurantRepo,
+		imageRepo:      imageRepo,
+		geocoder:       geocoder,
+		blobs:          blobs,
+		outboxRepo:     outboxRepo,
+		transactor:     transactor,
+		config:         config,
 		logger:         logger,
 	}
 }
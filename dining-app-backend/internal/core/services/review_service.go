@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"go.uber.org/zap"
+)
+
+type reviewService struct {
+	reviewRepo  ports.ReviewRepository
+	bookingRepo ports.BookingRepository
+	logger      *logger.Logger
+}
+
+func NewReviewService(
+	reviewRepo ports.ReviewRepository,
+	bookingRepo ports.BookingRepository,
+	logger *logger.Logger,
+) *reviewService {
+	return &reviewService{
+		reviewRepo:  reviewRepo,
+		bookingRepo: bookingRepo,
+		logger:      logger,
+	}
+}
+
+// CreateReview reviews the restaurant of one of the user's bookings. The booking must
+// not be cancelled and must have ended; the restaurant is taken from the booking.
+func (s *reviewService) CreateReview(ctx context.Context, review *domain.Review) error {
+	s.logger.Info("Creating review",
+		zap.Int64("bookingID", review.BookingID),
+		zap.Int64("userID", review.UserID),
+	)
+
+	booking, err := s.bookingRepo.GetByID(ctx, review.BookingID)
+	if err != nil {
+		return err
+	}
+
+	if booking.UserID != review.UserID {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to review this booking", nil)
+	}
+
+	if review.RestaurantID != 0 && review.RestaurantID != booking.RestaurantID {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "booking is not at this restaurant", nil)
+	}
+
+	if booking.Status == domain.BookingStatusCancelled {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "cancelled bookings cannot be reviewed", nil)
+	}
+
+	if booking.Status == domain.BookingStatusNoShow {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "missed bookings cannot be reviewed", nil)
+	}
+
+	if booking.EndsAt().After(time.Now()) {
+		return apperrors.NewError(apperrors.ErrorTypeValidation, "bookings can only be reviewed after they have taken place", nil)
+	}
+
+	review.RestaurantID = booking.RestaurantID
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		s.logger.Error("Failed to create review",
+			zap.Int64("bookingID", review.BookingID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Read back to pick up the author's name
+	created, err := s.reviewRepo.GetByID(ctx, review.ID)
+	if err != nil {
+		return err
+	}
+
+	*review = *created
+	return nil
+}
+
+func (s *reviewService) ListRestaurantReviews(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Review], error) {
+	return s.reviewRepo.ListVisible(ctx, restaurantID, page.Normalize())
+}
+
+// UpdateReview changes the rating and comment of the user's own review
+func (s *reviewService) UpdateReview(ctx context.Context, review *domain.Review) error {
+	s.logger.Info("Updating review", zap.Int64("reviewID", review.ID))
+
+	existing, err := s.reviewRepo.GetByID(ctx, review.ID)
+	if err != nil {
+		return err
+	}
+
+	if existing.UserID != review.UserID {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to update this review", nil)
+	}
+
+	existing.Rating = review.Rating
+	existing.Comment = review.Comment
+	if err := s.reviewRepo.Update(ctx, existing); err != nil {
+		return err
+	}
+
+	*review = *existing
+	return nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, reviewID int64, userID int64) error {
+	s.logger.Info("Deleting review",
+		zap.Int64("reviewID", reviewID),
+		zap.Int64("userID", userID),
+	)
+
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if review.UserID != userID {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized to delete this review", nil)
+	}
+
+	return s.reviewRepo.Delete(ctx, reviewID)
+}
+
+// SetReviewHidden hides a review from listings and ratings, or restores it
+func (s *reviewService) SetReviewHidden(ctx context.Context, reviewID int64, hidden bool, reason string) (*domain.Review, error) {
+	s.logger.Info("Changing review visibility",
+		zap.Int64("reviewID", reviewID),
+		zap.Bool("hidden", hidden),
+	)
+
+	if err := s.reviewRepo.SetHidden(ctx, reviewID, hidden, reason); err != nil {
+		return nil, err
+	}
+
+	return s.reviewRepo.GetByID(ctx, reviewID)
+}
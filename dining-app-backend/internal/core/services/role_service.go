@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+// roleCacheSize bounds how many users' roles are cached at once
+const roleCacheSize = 10000
+
+// roleInviteTemplateData is the data available to the role invite templates
+type roleInviteTemplateData struct {
+	InviterName string
+	Role        string
+	AcceptURL   string
+	ExpiresAt   string
+}
+
+type cachedRole struct {
+	role      *domain.Role
+	expiresAt time.Time
+}
+
+// roleService manages roles and authorizes users by the role they have now, rather
+// than the one in their token, so role changes apply to tokens already issued
+type roleService struct {
+	roleRepo      ports.RoleRepository
+	userRepo      ports.UserRepository
+	notifications ports.NotificationService
+	transactor    ports.Transactor
+	config        config.AuthorizationConfig
+	logger        *logger.Logger
+
+	mu    sync.Mutex
+	cache map[int64]cachedRole
+}
+
+func NewRoleService(
+	roleRepo ports.RoleRepository,
+	userRepo ports.UserRepository,
+	notifications ports.NotificationService,
+	transactor ports.Transactor,
+	config config.AuthorizationConfig,
+	logger *logger.Logger,
+) *roleService {
+	return &roleService{
+		roleRepo:      roleRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+		transactor:    transactor,
+		config:        config,
+		logger:        logger,
+		cache:         make(map[int64]cachedRole),
+	}
+}
+
+// Authorize fails unless the user's role grants the permission
+func (s *roleService) Authorize(ctx context.Context, userID int64, permission domain.Permission) error {
+	allowed, err := s.HasPermission(ctx, userID, permission)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, fmt.Sprintf("%s permission required", permission), nil)
+	}
+
+	return nil
+}
+
+func (s *roleService) HasPermission(ctx context.Context, userID int64, permission domain.Permission) (bool, error) {
+	role, err := s.GetUserRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return role.Has(permission), nil
+}
+
+// GetUserRole returns the user's current role, cached for a few seconds
+func (s *roleService) GetUserRole(ctx context.Context, userID int64) (*domain.Role, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[userID]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.role, nil
+	}
+
+	role, err := s.roleRepo.GetUserRole(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user role", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	if s.config.CacheSeconds > 0 {
+		s.mu.Lock()
+		if len(s.cache) >= roleCacheSize {
+			s.pruneLocked()
+		}
+		s.cache[userID] = cachedRole{
+			role:      role,
+			expiresAt: time.Now().Add(time.Duration(s.config.CacheSeconds) * time.Second),
+		}
+		s.mu.Unlock()
+	}
+
+	return role, nil
+}
+
+func (s *roleService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+func (s *roleService) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	return s.roleRepo.Get(ctx, name)
+}
+
+func (s *roleService) CreateRole(ctx context.Context, role *domain.Role) error {
+	s.logger.Info("Creating role", zap.String("role", role.Name))
+
+	if !domain.ValidRoleName(role.Name) {
+		return apperrors.NewError(apperrors.ErrorTypeValidation,
+			"role names are 2 to 30 lowercase letters, digits, dashes or underscores, starting with a letter", nil)
+	}
+
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		s.logger.Error("Failed to create role", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// UpdateRole replaces the role's description and permissions. The change applies to
+// its users on their next request, once their cached role expires.
+func (s *roleService) UpdateRole(ctx context.Context, role *domain.Role) error {
+	s.logger.Info("Updating role", zap.String("role", role.Name))
+
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+
+	current, err := s.roleRepo.Get(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+
+	if current.Has(domain.PermissionRoleManage) && !role.Has(domain.PermissionRoleManage) {
+		if err := s.keepARoleManager(ctx, role.Name, 0); err != nil {
+			return err
+		}
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		s.logger.Error("Failed to update role", zap.Error(err))
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// DeleteRole removes a role that isn't a system role and that no user has
+func (s *roleService) DeleteRole(ctx context.Context, name string) error {
+	s.logger.Info("Deleting role", zap.String("role", name))
+
+	role, err := s.roleRepo.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if role.System {
+		return apperrors.NewError(apperrors.ErrorTypeConflict, "system roles can't be deleted", nil)
+	}
+
+	if err := s.roleRepo.Delete(ctx, name); err != nil {
+		s.logger.Error("Failed to delete role", zap.Error(err))
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// AssignRole gives the user another role, effective on their next request. The change
+// is audited with the acting user.
+func (s *roleService) AssignRole(ctx context.Context, actorID, userID int64, name string) error {
+	s.logger.Info("Assigning role",
+		zap.Int64("actorID", actorID),
+		zap.Int64("userID", userID),
+		zap.String("role", name),
+	)
+
+	role, err := s.roleRepo.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.changeRole(ctx, userID, role, &actorID, domain.RoleChangeAssignment)
+	})
+	if err != nil {
+		s.logger.Error("Failed to assign role", zap.Error(err))
+		return err
+	}
+
+	s.forget(userID)
+	return nil
+}
+
+// BootstrapAdmin gives the account with the email a role granting role:manage. It only
+// works while no user has that permission; further administrators are invited.
+func (s *roleService) BootstrapAdmin(ctx context.Context, email, name string) (*domain.User, error) {
+	s.logger.Info("Bootstrapping administrator",
+		zap.String("email", email),
+		zap.String("role", name),
+	)
+
+	role, err := s.roleRepo.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !role.Has(domain.PermissionRoleManage) {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation,
+			fmt.Sprintf("the first administrator's role must grant the %s permission", domain.PermissionRoleManage), nil)
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		count, err := s.roleRepo.CountUsersWithPermission(ctx, domain.PermissionRoleManage, "", 0)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return apperrors.NewError(apperrors.ErrorTypeConflict,
+				"an administrator already exists; further administrators must be invited", nil)
+		}
+
+		return s.changeRole(ctx, user.ID, role, nil, domain.RoleChangeBootstrap)
+	})
+	if err != nil {
+		s.logger.Error("Failed to bootstrap administrator", zap.Error(err))
+		return nil, err
+	}
+
+	s.forget(user.ID)
+	user.Role = role.Name
+	return user, nil
+}
+
+// InviteToRole emails the invitee a single-use link that gives them the role. A new
+// invite to the same email replaces the previous one.
+func (s *roleService) InviteToRole(ctx context.Context, invite *domain.RoleInvite) error {
+	invite.Email = domain.NormalizeEmail(invite.Email)
+
+	s.logger.Info("Inviting user to role", zap.String("role", invite.Role))
+
+	role, err := s.roleRepo.Get(ctx, invite.Role)
+	if err != nil {
+		return err
+	}
+
+	invitee, err := s.userRepo.GetByEmail(ctx, invite.Email)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+	if invitee != nil && invitee.Role == role.Name {
+		return apperrors.NewError(apperrors.ErrorTypeConflict, "user already has this role", nil)
+	}
+
+	inviterName := "An administrator"
+	if invite.InvitedBy != nil {
+		inviter, err := s.userRepo.GetByID(ctx, *invite.InvitedBy)
+		if err != nil {
+			return err
+		}
+		inviterName = inviter.Name
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate invite token", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate invite token", err)
+	}
+
+	invite.TokenHash = auth.HashToken(token)
+	invite.ExpiresAt = time.Now().Add(time.Duration(s.config.InviteTTLHours) * time.Hour)
+
+	if err := s.roleRepo.CreateInvite(ctx, invite); err != nil {
+		s.logger.Error("Failed to create role invite", zap.Error(err))
+		return err
+	}
+
+	message := &domain.EmailMessage{
+		Kind:      domain.NotificationRoleInvite,
+		Recipient: invite.Email,
+		Reference: fmt.Sprintf("role-invite:%d", invite.ID),
+		Data: roleInviteTemplateData{
+			InviterName: inviterName,
+			Role:        role.Name,
+			AcceptURL:   tokenURL(s.config.InviteURL, token),
+			ExpiresAt:   invite.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		},
+	}
+	if invitee != nil {
+		message.UserID = &invitee.ID
+	}
+
+	return s.notifications.NotifyEmail(ctx, message)
+}
+
+func (s *roleService) ListRoleInvites(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.RoleInvite], error) {
+	return s.roleRepo.ListPendingInvites(ctx, page.Normalize())
+}
+
+func (s *roleService) RevokeRoleInvite(ctx context.Context, inviteID int64) error {
+	s.logger.Info("Revoking role invite", zap.Int64("inviteID", inviteID))
+
+	return s.roleRepo.DeleteInvite(ctx, inviteID)
+}
+
+// AcceptRoleInvite gives the user the invited role. The user's email must be the one
+// the invite was sent to, and the invite can be used once.
+func (s *roleService) AcceptRoleInvite(ctx context.Context, userID int64, token string) (*domain.Role, error) {
+	invite, err := s.roleRepo.GetPendingInvite(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.NormalizeEmail(user.Email) != invite.Email {
+		return nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "this invite was sent to a different email address", nil)
+	}
+
+	role, err := s.roleRepo.Get(ctx, invite.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.MarkInviteAccepted(ctx, invite.ID); err != nil {
+			return err
+		}
+		return s.changeRole(ctx, userID, role, invite.InvitedBy, domain.RoleChangeInvite)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Role invite accepted",
+		zap.Int64("userID", userID),
+		zap.String("role", role.Name),
+	)
+
+	s.forget(userID)
+	return role, nil
+}
+
+// ListRoleChanges returns the audit trail of the user's roles, newest first
+func (s *roleService) ListRoleChanges(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.RoleChange], error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepo.ListChanges(ctx, userID, page.Normalize())
+}
+
+// changeRole gives the user the role and records the change. Giving a user the role
+// they have is a no-op. It must run in a transaction, so the audit record is written
+// with the change.
+func (s *roleService) changeRole(ctx context.Context, userID int64, role *domain.Role, changedBy *int64, source domain.RoleChangeSource) error {
+	current, err := s.roleRepo.GetUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if current.Name == role.Name {
+		return nil
+	}
+
+	if current.Has(domain.PermissionRoleManage) && !role.Has(domain.PermissionRoleManage) {
+		if err := s.keepARoleManager(ctx, "", userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, userID, role.Name); err != nil {
+		return err
+	}
+
+	return s.roleRepo.RecordChange(ctx, &domain.RoleChange{
+		UserID:    userID,
+		OldRole:   current.Name,
+		NewRole:   role.Name,
+		ChangedBy: changedBy,
+		Source:    source,
+	})
+}
+
+// keepARoleManager rejects a change that would leave nobody able to manage roles
+func (s *roleService) keepARoleManager(ctx context.Context, exceptRole string, exceptUserID int64) error {
+	count, err := s.roleRepo.CountUsersWithPermission(ctx, domain.PermissionRoleManage, exceptRole, exceptUserID)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeConflict,
+			fmt.Sprintf("at least one user must keep the %s permission", domain.PermissionRoleManage), nil)
+	}
+
+	return nil
+}
+
+func (s *roleService) forget(userID int64) {
+	s.mu.Lock()
+	delete(s.cache, userID)
+	s.mu.Unlock()
+}
+
+func (s *roleService) invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[int64]cachedRole)
+	s.mu.Unlock()
+}
+
+// pruneLocked drops expired entries, or every entry when none has expired. The caller
+// must hold mu.
+func (s *roleService) pruneLocked() {
+	now := time.Now()
+	for userID, cached := range s.cache {
+		if now.After(cached.expiresAt) {
+			delete(s.cache, userID)
+		}
+	}
+	if len(s.cache) >= roleCacheSize {
+		s.cache = make(map[int64]cachedRole)
+	}
+}
+
+// normalizePermissions rejects unknown permissions and drops duplicates
+func normalizePermissions(permissions []domain.Permission) ([]domain.Permission, error) {
+	seen := make(map[domain.Permission]bool, len(permissions))
+	normalized := make([]domain.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, fmt.Sprintf("unknown permission %q", permission), nil)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized, nil
+}
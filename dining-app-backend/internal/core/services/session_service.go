@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+// sessionCacheSize bounds how many sessions' states are cached at once
+const sessionCacheSize = 10000
+
+// maxUserAgentLength is the size of the sessions.user_agent column
+const maxUserAgentLength = 255
+
+type cachedSession struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// sessionService issues access and refresh tokens for sessions and revokes them.
+// Whether a session is still active is cached for a few seconds, so checking it on
+// every request stays cheap.
+type sessionService struct {
+	sessionRepo ports.SessionRepository
+	userRepo    ports.UserRepository
+	transactor  ports.Transactor
+	authService *auth.Service
+	config      config.JWTConfig
+	logger      *logger.Logger
+
+	mu    sync.Mutex
+	cache map[int64]cachedSession
+}
+
+func NewSessionService(
+	sessionRepo ports.SessionRepository,
+	userRepo ports.UserRepository,
+	transactor ports.Transactor,
+	authService *auth.Service,
+	config config.JWTConfig,
+	logger *logger.Logger,
+) *sessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		transactor:  transactor,
+		authService: authService,
+		config:      config,
+		logger:      logger,
+		cache:       make(map[int64]cachedSession),
+	}
+}
+
+// StartSession signs the user in on the device described by the session's user agent
+// and IP address
+func (s *sessionService) StartSession(ctx context.Context, user *domain.User, session *domain.Session) (*domain.TokenPair, error) {
+	session.UserID = user.ID
+	session.UserAgent = truncate(session.UserAgent, maxUserAgentLength)
+	session.ExpiresAt = s.refreshExpiry()
+
+	var refreshToken string
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+
+		var err error
+		refreshToken, err = s.issueRefreshToken(ctx, session)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Failed to start session", zap.Int64("userID", user.ID), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Session started",
+		zap.Int64("userID", user.ID),
+		zap.Int64("sessionID", session.ID),
+	)
+
+	return s.tokenPair(user, session, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token. Each refresh
+// token works once; presenting one again revokes the session, since it must have
+// leaked.
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string, device *domain.Session) (*domain.TokenPair, error) {
+	var (
+		session *domain.Session
+		user    *domain.User
+		next    string
+		reused  bool
+	)
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.sessionRepo.LockRefreshToken(ctx, auth.HashToken(refreshToken))
+		if err != nil {
+			if isNotFoundError(err) {
+				return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid refresh token", nil)
+			}
+			return err
+		}
+
+		session, err = s.sessionRepo.Get(ctx, token.SessionID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if token.UsedAt != nil {
+			// Revoke in this transaction and report the reuse once it commits
+			reused = true
+			if session.RevokedAt == nil {
+				return s.sessionRepo.Revoke(ctx, session.UserID, session.ID)
+			}
+			return nil
+		}
+
+		if !session.Active(now) || !now.Before(token.ExpiresAt) {
+			return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "session has expired or been revoked", nil)
+		}
+
+		if err := s.sessionRepo.MarkRefreshTokenUsed(ctx, token.ID); err != nil {
+			return err
+		}
+
+		session.UserAgent = truncate(device.UserAgent, maxUserAgentLength)
+		session.IPAddress = device.IPAddress
+		session.ExpiresAt = s.refreshExpiry()
+		if err := s.sessionRepo.Touch(ctx, session); err != nil {
+			return err
+		}
+
+		user, err = s.userRepo.GetByID(ctx, session.UserID)
+		if err != nil {
+			return err
+		}
+
+		next, err = s.issueRefreshToken(ctx, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		s.logger.Warn("Refresh token reused; session revoked",
+			zap.Int64("userID", session.UserID),
+			zap.Int64("sessionID", session.ID),
+		)
+		s.forget(session.ID)
+		return nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "refresh token was already used; the session has been revoked", nil)
+	}
+
+	return s.tokenPair(user, session, next)
+}
+
+// CheckSession fails unless the user's session is still active. Revocations made on
+// other replicas are seen within SessionCacheSeconds.
+func (s *sessionService) CheckSession(ctx context.Context, userID, sessionID int64) error {
+	s.mu.Lock()
+	cached, ok := s.cache[sessionID]
+	s.mu.Unlock()
+
+	active := cached.active
+	if !ok || !time.Now().Before(cached.expiresAt) {
+		var err error
+		active, err = s.sessionRepo.IsActive(ctx, userID, sessionID)
+		if err != nil {
+			s.logger.Error("Failed to check session", zap.Int64("sessionID", sessionID), zap.Error(err))
+			return err
+		}
+
+		if s.config.SessionCacheSeconds > 0 {
+			s.mu.Lock()
+			if len(s.cache) >= sessionCacheSize {
+				s.pruneLocked()
+			}
+			s.cache[sessionID] = cachedSession{
+				active:    active,
+				expiresAt: time.Now().Add(time.Duration(s.config.SessionCacheSeconds) * time.Second),
+			}
+			s.mu.Unlock()
+		}
+	}
+
+	if !active {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "session has expired or been revoked", nil)
+	}
+
+	return nil
+}
+
+func (s *sessionService) ListSessions(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Session], error) {
+	return s.sessionRepo.ListActive(ctx, userID, page.Normalize())
+}
+
+// RevokeSession signs one of the user's devices out
+func (s *sessionService) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	s.logger.Info("Revoking session",
+		zap.Int64("userID", userID),
+		zap.Int64("sessionID", sessionID),
+	)
+
+	if err := s.sessionRepo.Revoke(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	s.forget(sessionID)
+	return nil
+}
+
+// RevokeAllSessions signs the user out everywhere, except on the session
+// exceptSessionID when it isn't zero
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID, exceptSessionID int64) error {
+	s.logger.Info("Revoking all sessions",
+		zap.Int64("userID", userID),
+		zap.Int64("exceptSessionID", exceptSessionID),
+	)
+
+	ids, err := s.sessionRepo.RevokeAll(ctx, userID, exceptSessionID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		s.forget(id)
+	}
+	return nil
+}
+
+func (s *sessionService) issueRefreshToken(ctx context.Context, session *domain.Session) (string, error) {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate refresh token", err)
+	}
+
+	refreshToken := &domain.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: session.ExpiresAt,
+	}
+	if err := s.sessionRepo.CreateRefreshToken(ctx, refreshToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *sessionService) tokenPair(user *domain.User, session *domain.Session, refreshToken string) (*domain.TokenPair, error) {
+	accessToken, expiresAt, err := s.authService.GenerateToken(user.ID, user.Role, session.ID)
+	if err != nil {
+		s.logger.Error("Failed to generate token", zap.Error(err))
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate token", err)
+	}
+
+	return &domain.TokenPair{
+		SessionID:             session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (s *sessionService) refreshExpiry() time.Time {
+	return time.Now().Add(time.Duration(s.config.RefreshTokenDays) * 24 * time.Hour)
+}
+
+func (s *sessionService) forget(sessionID int64) {
+	s.mu.Lock()
+	delete(s.cache, sessionID)
+	s.mu.Unlock()
+}
+
+// pruneLocked drops expired entries, or every entry when none has expired. The caller
+// must hold mu.
+func (s *sessionService) pruneLocked() {
+	now := time.Now()
+	for sessionID, cached := range s.cache {
+		if now.After(cached.expiresAt) {
+			delete(s.cache, sessionID)
+		}
+	}
+	if len(s.cache) >= sessionCacheSize {
+		s.cache = make(map[int64]cachedSession)
+	}
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	availabilityStreamEvents = []domain.EventType{
+		domain.EventTableAvailabilityChanged,
+	}
+	bookingStreamEvents = []domain.EventType{
+		domain.EventBookingCreated,
+		domain.EventBookingConfirmed,
+		domain.EventBookingCancelled,
+		domain.EventBookingCompleted,
+		domain.EventBookingNoShow,
+	}
+)
+
+// streamService fans domain events out to the clients connected to this replica. It
+// receives events as a broadcast subscriber, so every replica sees every event.
+type streamService struct {
+	outboxRepo     ports.OutboxRepository
+	restaurantRepo ports.RestaurantRepository
+	config         config.StreamConfig
+	logger         *logger.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan *domain.Event]struct{}
+}
+
+// NewStreamService creates the service and subscribes it to the bus
+func NewStreamService(
+	outboxRepo ports.OutboxRepository,
+	restaurantRepo ports.RestaurantRepository,
+	bus ports.EventBus,
+	config config.StreamConfig,
+	logger *logger.Logger,
+) *streamService {
+	s := &streamService{
+		outboxRepo:     outboxRepo,
+		restaurantRepo: restaurantRepo,
+		config:         config,
+		logger:         logger,
+		subscribers:    make(map[string]map[chan *domain.Event]struct{}),
+	}
+
+	for _, eventType := range availabilityStreamEvents {
+		bus.SubscribeBroadcast(eventType, s.onAvailabilityEvent)
+	}
+	for _, eventType := range bookingStreamEvents {
+		bus.SubscribeBroadcast(eventType, s.onBookingEvent)
+	}
+
+	return s
+}
+
+// StreamAvailability subscribes to table availability changes at a restaurant
+func (s *streamService) StreamAvailability(ctx context.Context, restaurantID int64, lastEventID int64) (*domain.EventStream, error) {
+	if _, err := s.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return s.open(ctx, availabilityTopic(restaurantID), lastEventID, domain.EventFilter{
+		Types:        availabilityStreamEvents,
+		RestaurantID: restaurantID,
+	})
+}
+
+// StreamBookings subscribes to changes to the user's own bookings
+func (s *streamService) StreamBookings(ctx context.Context, userID int64, lastEventID int64) (*domain.EventStream, error) {
+	return s.open(ctx, bookingTopic(userID), lastEventID, domain.EventFilter{
+		Types:  bookingStreamEvents,
+		UserID: userID,
+	})
+}
+
+// open subscribes before loading the replay, so no event falls between the two; the
+// caller skips live events it has already replayed. Events committed out of order can
+// still be missed on resume, since the replay starts after the last seen ID.
+func (s *streamService) open(ctx context.Context, topic string, lastEventID int64, filter domain.EventFilter) (*domain.EventStream, error) {
+	events := make(chan *domain.Event, s.config.BufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[topic] == nil {
+		s.subscribers[topic] = make(map[chan *domain.Event]struct{})
+	}
+	s.subscribers[topic][events] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.remove(topic, events)
+	}()
+
+	stream := &domain.EventStream{Events: events}
+	if lastEventID <= 0 {
+		return stream, nil
+	}
+
+	filter.AfterID = lastEventID
+	filter.Limit = s.config.ReplayLimit + 1
+	replay, err := s.outboxRepo.ListDispatched(ctx, filter)
+	if err != nil {
+		s.remove(topic, events)
+		return nil, err
+	}
+
+	if len(replay) > s.config.ReplayLimit {
+		stream.Reset = true
+	} else {
+		stream.Replay = replay
+	}
+
+	return stream, nil
+}
+
+func (s *streamService) onAvailabilityEvent(ctx context.Context, event *domain.Event) error {
+	var payload domain.TableAvailabilityPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	s.publish(availabilityTopic(payload.RestaurantID), event)
+	return nil
+}
+
+func (s *streamService) onBookingEvent(ctx context.Context, event *domain.Event) error {
+	var payload domain.BookingEventPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	s.publish(bookingTopic(payload.UserID), event)
+	return nil
+}
+
+// publish never blocks the bus. A subscriber whose buffer is full is disconnected; its
+// client reconnects with Last-Event-ID and catches up from the outbox.
+func (s *streamService) publish(topic string, event *domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for events := range s.subscribers[topic] {
+		select {
+		case events <- event:
+		default:
+			s.logger.Warn("Disconnecting slow stream subscriber",
+				zap.String("topic", topic),
+				zap.Int64("eventID", event.ID),
+			)
+			s.removeLocked(topic, events)
+		}
+	}
+}
+
+func (s *streamService) remove(topic string, events chan *domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(topic, events)
+}
+
+func (s *streamService) removeLocked(topic string, events chan *domain.Event) {
+	if _, ok := s.subscribers[topic][events]; !ok {
+		return
+	}
+
+	delete(s.subscribers[topic], events)
+	if len(s.subscribers[topic]) == 0 {
+		delete(s.subscribers, topic)
+	}
+	close(events)
+}
+
+func availabilityTopic(restaurantID int64) string {
+	return fmt.Sprintf("restaurant:%d", restaurantID)
+}
+
+func bookingTopic(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
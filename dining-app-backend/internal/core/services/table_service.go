@@ -13,17 +13,23 @@ import (
 type tableService struct {
 	tableRepo      ports.TableRepository
 	restaurantRepo ports.RestaurantRepository
+	outboxRepo     ports.OutboxRepository
+	transactor     ports.Transactor
 	logger         *logger.Logger
 }
 
 func NewTableService(
 	tableRepo ports.TableRepository,
 	restaurantRepo ports.RestaurantRepository,
+	outboxRepo ports.OutboxRepository,
+	transactor ports.Transactor,
 	logger *logger.Logger,
 ) *tableService {
 	return &tableService{
 		tableRepo:      tableRepo,
 		restaurantRepo: restaurantRepo,
+		outboxRepo:     outboxRepo,
+		transactor:     transactor,
 		logger:         logger,
 	}
 }
@@ -77,7 +83,7 @@ func (s *tableService) CreateTable(ctx context.Context, restaurantID int64, tabl
 	return nil
 }
 
-func (s *tableService) GetRestaurantTables(ctx context.Context, restaurantID int64) ([]*domain.Table, error) {
+func (s *tableService) GetRestaurantTables(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Table], error) {
 	s.logger.Info("Fetching restaurant tables", zap.Int64("restaurantID", restaurantID))
 
 	// Verify restaurant exists
@@ -94,7 +100,7 @@ func (s *tableService) GetRestaurantTables(ctx context.Context, restaurantID int
 		return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", nil)
 	}
 
-	tables, err := s.tableRepo.GetByRestaurantID(ctx, restaurantID)
+	tables, err := s.tableRepo.ListByRestaurantID(ctx, restaurantID, page.Normalize())
 	if err != nil {
 		s.logger.Error("Failed to get restaurant tables", zap.Error(err))
 		return nil, err
@@ -123,7 +129,13 @@ func (s *tableService) UpdateTableAvailability(ctx context.Context, tableID int6
 		return apperrors.NewError(apperrors.ErrorTypeNotFound, "table not found", nil)
 	}
 
-	if err := s.tableRepo.UpdateAvailability(ctx, tableID, isAvailable); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tableRepo.UpdateAvailability(ctx, tableID, isAvailable); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewTableAvailabilityEvent(tableID, table.RestaurantID, isAvailable, nil))
+	})
+	if err != nil {
 		s.logger.Error("Failed to update table availability", zap.Error(err))
 		return err
 	}
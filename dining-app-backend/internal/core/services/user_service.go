@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
@@ -12,22 +15,54 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// passwordResetTemplateData is the data available to the password reset templates
+type passwordResetTemplateData struct {
+	Name      string
+	ResetURL  string
+	ExpiresAt string
+}
+
 type userService struct {
-	userRepo    ports.UserRepository
-	authService *auth.Service
-	logger      *logger.Logger
+	userRepo      ports.UserRepository
+	resetRepo     ports.PasswordResetRepository
+	outboxRepo    ports.OutboxRepository
+	transactor    ports.Transactor
+	sessions      ports.SessionService
+	verifications ports.VerificationService
+	loginGuard    ports.LoginGuard
+	notifications ports.NotificationService
+	config        config.PasswordConfig
+	logger        *logger.Logger
 }
 
-func NewUserService(userRepo ports.UserRepository, authService *auth.Service, logger *logger.Logger) *userService {
+func NewUserService(
+	userRepo ports.UserRepository,
+	resetRepo ports.PasswordResetRepository,
+	outboxRepo ports.OutboxRepository,
+	transactor ports.Transactor,
+	sessions ports.SessionService,
+	verifications ports.VerificationService,
+	loginGuard ports.LoginGuard,
+	notifications ports.NotificationService,
+	config config.PasswordConfig,
+	logger *logger.Logger,
+) *userService {
 	return &userService{
-		userRepo:    userRepo,
-		authService: authService,
-		logger:      logger,
+		userRepo:      userRepo,
+		resetRepo:     resetRepo,
+		outboxRepo:    outboxRepo,
+		transactor:    transactor,
+		sessions:      sessions,
+		verifications: verifications,
+		loginGuard:    loginGuard,
+		notifications: notifications,
+		config:        config,
+		logger:        logger,
 	}
 }
 
 func (s *userService) Register(ctx context.Context, user *domain.User) error {
-	s.logger.Info("Registering new user", zap.String("email", user.Email), zap.String("role", user.Role))
+	s.logger.Info("Registering new user", zap.String("email", user.Email))
 
 	// Check if user already exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, user.Email)
@@ -53,18 +88,26 @@ func (s *userService) Register(ctx context.Context, user *domain.User) error {
 
 	user.Password = string(hashedPassword)
 
-	// Set default role only if not provided
-	if user.Role == "" {
-		user.Role = domain.RoleUser
-	}
+	// Public registration never grants more than the default role; administrators are
+	// bootstrapped from the command line or invited
+	user.Role = domain.RoleUser
 
-	s.logger.Info("Saving user with role", zap.String("role", user.Role))
-
-	if err := s.userRepo.Create(ctx, user); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.outboxRepo.Append(ctx, domain.NewUserRegisteredEvent(user))
+	})
+	if err != nil {
 		s.logger.Error("Failed to create user", zap.Error(err))
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create user", err)
 	}
 
+	// The account exists either way; the user can ask for another link
+	if err := s.verifications.SendVerification(ctx, user); err != nil {
+		s.logger.Error("Failed to send verification email", zap.Int64("userID", user.ID), zap.Error(err))
+	}
+
 	s.logger.Info("User registered successfully",
 		zap.Int64("userID", user.ID),
 		zap.String("role", user.Role),
@@ -72,24 +115,32 @@ func (s *userService) Register(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-func (s *userService) Login(ctx context.Context, email, password string) (string, *domain.User, error) {
+// Login checks the credentials and starts a session on the device
+func (s *userService) Login(ctx context.Context, email, password string, device *domain.Session) (*domain.TokenPair, *domain.User, error) {
 	s.logger.Info("Attempting login", zap.String("email", email))
 
+	if err := s.loginGuard.Check(ctx, email, device.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		s.logger.Warn("Login failed: user not found", zap.String("email", email))
-		return "", nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid credentials", nil)
+		s.loginGuard.RecordFailure(ctx, email, device.IPAddress)
+		return nil, nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid credentials", nil)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
 		s.logger.Warn("Login failed: invalid password", zap.String("email", email))
-		return "", nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid credentials", nil)
+		s.loginGuard.RecordFailure(ctx, email, device.IPAddress)
+		return nil, nil, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "invalid credentials", nil)
 	}
 
-	token, err := s.authService.GenerateToken(user.ID, user.Role)
+	s.loginGuard.RecordSuccess(ctx, email)
+
+	tokens, err := s.sessions.StartSession(ctx, user, device)
 	if err != nil {
-		s.logger.Error("Failed to generate token", zap.Error(err))
-		return "", nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate token", err)
+		return nil, nil, err
 	}
 
 	// Clear sensitive data before returning
@@ -99,7 +150,7 @@ func (s *userService) Login(ctx context.Context, email, password string) (string
 		zap.Int64("userID", user.ID),
 		zap.String("role", user.Role),
 	)
-	return token, user, nil
+	return tokens, user, nil
 }
 
 func (s *userService) GetProfile(ctx context.Context, userID int64) (*domain.User, error) {
@@ -139,16 +190,20 @@ func (s *userService) UpdateProfile(ctx context.Context, user *domain.User) erro
 		return err
 	}
 
-	// Preserve role and password
+	// Preserve role, password and verification
 	user.Role = currentUser.Role
 	user.Password = currentUser.Password
+	user.EmailVerifiedAt = currentUser.EmailVerifiedAt
 
-	// Check if email is being changed and if it's already taken
-	if user.Email != currentUser.Email {
-		existingUser, err := s.userRepo.GetByEmail(ctx, user.Email)
+	// A new email only takes effect once verified, so keep the current one until then
+	newEmail := user.Email
+	user.Email = currentUser.Email
+	user.PendingEmail = nil
+	if newEmail != currentUser.Email {
+		existingUser, err := s.userRepo.GetByEmail(ctx, newEmail)
 		if err != nil && !isNotFoundError(err) {
 			s.logger.Error("Failed to check email availability",
-				zap.String("email", user.Email),
+				zap.String("email", newEmail),
 				zap.Error(err),
 			)
 			return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to check email availability", err)
@@ -156,9 +211,16 @@ func (s *userService) UpdateProfile(ctx context.Context, user *domain.User) erro
 		if existingUser != nil {
 			return apperrors.NewError(apperrors.ErrorTypeValidation, "email already taken", nil)
 		}
+		user.PendingEmail = &newEmail
 	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.userRepo.SetPendingEmail(ctx, user.ID, user.PendingEmail)
+	})
+	if err != nil {
 		s.logger.Error("Failed to update user profile",
 			zap.Int64("userID", user.ID),
 			zap.Error(err),
@@ -169,6 +231,15 @@ func (s *userService) UpdateProfile(ctx context.Context, user *domain.User) erro
 		return err
 	}
 
+	changed := user.PendingEmail != nil &&
+		(currentUser.PendingEmail == nil || *currentUser.PendingEmail != *user.PendingEmail)
+	if changed {
+		// The change is saved either way; the user can ask for another link
+		if err := s.verifications.SendVerification(ctx, user); err != nil {
+			s.logger.Warn("Failed to send verification email", zap.Int64("userID", user.ID), zap.Error(err))
+		}
+	}
+
 	s.logger.Info("User profile updated successfully",
 		zap.Int64("userID", user.ID),
 		zap.String("email", user.Email),
@@ -176,6 +247,132 @@ func (s *userService) UpdateProfile(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+// ForgotPassword emails the account with the address a single-use link to set a new
+// password. It succeeds whether or not the account exists, so it can't be used to
+// find out which emails are registered.
+func (s *userService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if isNotFoundError(err) {
+			s.logger.Info("Password reset requested for unknown email")
+			return nil
+		}
+		return err
+	}
+
+	s.logger.Info("Password reset requested", zap.Int64("userID", user.ID))
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate reset token", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate reset token", err)
+	}
+
+	reset := &domain.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(s.config.ResetTTLMinutes) * time.Minute),
+	}
+	if err := s.resetRepo.Create(ctx, reset); err != nil {
+		s.logger.Error("Failed to create password reset", zap.Error(err))
+		return err
+	}
+
+	return s.notifications.NotifyEmail(ctx, &domain.EmailMessage{
+		Kind:      domain.NotificationPasswordReset,
+		UserID:    &user.ID,
+		Recipient: user.Email,
+		Reference: fmt.Sprintf("password-reset:%d", reset.ID),
+		Data: passwordResetTemplateData{
+			Name:      user.Name,
+			ResetURL:  tokenURL(s.config.ResetURL, token),
+			ExpiresAt: reset.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		},
+	})
+}
+
+// ResetPassword sets a new password with an emailed reset token and signs the user out
+// everywhere
+func (s *userService) ResetPassword(ctx context.Context, token, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.Error("Failed to hash password", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to hash password", err)
+	}
+
+	var userID int64
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reset, err := s.resetRepo.LockPending(ctx, auth.HashToken(token))
+		if err != nil {
+			return err
+		}
+		userID = reset.UserID
+
+		if err := s.resetRepo.MarkUsed(ctx, reset.ID); err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdatePassword(ctx, reset.UserID, string(hashedPassword)); err != nil {
+			return err
+		}
+		return s.sessions.RevokeAllSessions(ctx, reset.UserID, 0)
+	})
+	if err != nil {
+		s.logger.Warn("Password reset failed", zap.Error(err))
+		return err
+	}
+
+	// Resetting the password proves the user owns the account, so it lifts a lockout
+	if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		s.loginGuard.Unlock(ctx, user.Email)
+	}
+
+	s.logger.Info("Password reset", zap.Int64("userID", userID))
+	return nil
+}
+
+// ChangePassword sets a new password after checking the current one, and signs the
+// user out everywhere but the session making the change. Wrong current passwords
+// count towards the login lockout, so a stolen access token can't guess the password.
+func (s *userService) ChangePassword(ctx context.Context, userID, sessionID int64, currentPassword, newPassword, ip string) error {
+	s.logger.Info("Changing password", zap.Int64("userID", userID))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginGuard.Check(ctx, user.Email, ip); err != nil {
+		return err
+	}
+
+	// Only the lookup by email loads the password hash
+	user, err = s.userRepo.GetByEmail(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		s.logger.Warn("Password change failed: invalid current password", zap.Int64("userID", userID))
+		s.loginGuard.RecordFailure(ctx, user.Email, ip)
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized, "current password is incorrect", nil)
+	}
+
+	s.loginGuard.RecordSuccess(ctx, user.Email)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.Error("Failed to hash password", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to hash password", err)
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+			return err
+		}
+		return s.sessions.RevokeAllSessions(ctx, userID, sessionID)
+	})
+}
+
 // Helper function to check if error is "not found" error
 func isNotFoundError(err error) bool {
 	if appErr, ok := err.(*apperrors.Error); ok {
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+// verificationTemplateData is the data available to the email verification templates
+type verificationTemplateData struct {
+	Name      string
+	Email     string
+	Change    bool
+	VerifyURL string
+	ExpiresAt string
+}
+
+// verificationService proves users own their email address with signed links, and
+// keeps unverified users from the configured actions
+type verificationService struct {
+	userRepo      ports.UserRepository
+	notifications ports.NotificationService
+	authService   *auth.Service
+	config        config.VerificationConfig
+	logger        *logger.Logger
+}
+
+func NewVerificationService(
+	userRepo ports.UserRepository,
+	notifications ports.NotificationService,
+	authService *auth.Service,
+	config config.VerificationConfig,
+	logger *logger.Logger,
+) *verificationService {
+	return &verificationService{
+		userRepo:      userRepo,
+		notifications: notifications,
+		authService:   authService,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// SendVerification emails a verification link to the user's pending email, or to their
+// email when it isn't verified yet. At most one link is sent per cooldown.
+func (s *verificationService) SendVerification(ctx context.Context, user *domain.User) error {
+	email := user.Email
+	change := user.PendingEmail != nil
+	if change {
+		email = *user.PendingEmail
+	} else if user.EmailVerified() {
+		return apperrors.NewError(apperrors.ErrorTypeConflict, "email is already verified", nil)
+	}
+
+	cooldown := time.Duration(s.config.ResendCooldownSeconds) * time.Second
+	claimed, err := s.userRepo.ClaimVerificationSend(ctx, user.ID, cooldown)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return apperrors.NewError(apperrors.ErrorTypeRateLimited,
+			fmt.Sprintf("a verification email was sent recently; try again in %d seconds", s.config.ResendCooldownSeconds), nil)
+	}
+
+	ttl := time.Duration(s.config.TokenTTLHours) * time.Hour
+	token, err := s.authService.GenerateEmailToken(user.ID, email, ttl)
+	if err != nil {
+		s.logger.Error("Failed to sign verification token", zap.Error(err))
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to sign verification token", err)
+	}
+
+	s.logger.Info("Sending email verification",
+		zap.Int64("userID", user.ID),
+		zap.Bool("change", change),
+	)
+
+	now := time.Now()
+	return s.notifications.NotifyEmail(ctx, &domain.EmailMessage{
+		Kind:      domain.NotificationEmailVerify,
+		UserID:    &user.ID,
+		Recipient: email,
+		// Each send is its own message; the cooldown keeps them apart
+		Reference: fmt.Sprintf("verify:%d:%d", user.ID, now.Unix()),
+		Data: verificationTemplateData{
+			Name:      user.Name,
+			Email:     email,
+			Change:    change,
+			VerifyURL: tokenURL(s.config.VerifyURL, token),
+			ExpiresAt: now.Add(ttl).UTC().Format("2006-01-02 15:04 MST"),
+		},
+	})
+}
+
+func (s *verificationService) ResendVerification(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.SendVerification(ctx, user)
+}
+
+// VerifyEmail marks the address in the signed token verified. A pending email becomes
+// the user's email.
+func (s *verificationService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	claims, err := s.authService.ValidateEmailToken(token)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "verification link is invalid or has expired", nil)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email == claims.Email && user.EmailVerified() {
+		return user, nil
+	}
+
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID, claims.Email); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Email verified", zap.Int64("userID", user.ID))
+
+	return s.userRepo.GetByID(ctx, user.ID)
+}
+
+// RequireVerified fails when the action requires a verified email and the user's isn't
+func (s *verificationService) RequireVerified(ctx context.Context, userID int64, action domain.VerifiedAction) error {
+	required := false
+	for _, restricted := range s.config.RequiredFor {
+		if domain.VerifiedAction(restricted) == action {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.EmailVerified() {
+		return apperrors.NewError(apperrors.ErrorTypeUnauthorized,
+			fmt.Sprintf("verify your email address to use %s", action), nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookBatchSize = 50
+	webhookLease     = 5 * time.Minute
+)
+
+type webhookService struct {
+	webhookRepo ports.WebhookRepository
+	sender      ports.WebhookSender
+	config      config.WebhookConfig
+	logger      *logger.Logger
+}
+
+func NewWebhookService(
+	webhookRepo ports.WebhookRepository,
+	sender ports.WebhookSender,
+	config config.WebhookConfig,
+	logger *logger.Logger,
+) *webhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+// Publish queues the event for every active endpoint subscribed to its type.
+// Delivery happens asynchronously in Run. The webhook event takes its ID from the
+// domain event it reports, so when the event bus retries the domain event, endpoints
+// that already have a delivery for it aren't sent another.
+func (s *webhookService) Publish(ctx context.Context, source *domain.Event, eventType domain.WebhookEventType, data interface{}) error {
+	endpoints, err := s.webhookRepo.ListSubscribedEndpoints(ctx, eventType)
+	if err != nil {
+		s.logger.Error("Failed to list subscribed webhook endpoints",
+			zap.String("eventType", string(eventType)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	eventID := webhookEventID(source)
+
+	payload, err := json.Marshal(domain.WebhookEvent{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: source.CreatedAt.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to encode webhook event", err)
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := &domain.WebhookDelivery{
+			EndpointID: endpoint.ID,
+			EventID:    eventID,
+			EventType:  eventType,
+			Payload:    string(payload),
+		}
+
+		if err := s.webhookRepo.EnqueueDelivery(ctx, delivery); err != nil {
+			s.logger.Error("Failed to enqueue webhook delivery",
+				zap.Int64("endpointID", endpoint.ID),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	s.logger.Info("Webhook event published",
+		zap.String("eventID", eventID),
+		zap.String("eventType", string(eventType)),
+		zap.Int("endpoints", len(endpoints)),
+	)
+	return nil
+}
+
+// CreateEndpoint registers an endpoint with a freshly generated signing secret
+func (s *webhookService) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	s.logger.Info("Creating webhook endpoint", zap.String("url", endpoint.URL))
+
+	secret, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to generate webhook secret", err)
+	}
+	endpoint.Secret = secret
+
+	if err := s.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to create webhook endpoint", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *webhookService) ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return s.webhookRepo.ListEndpoints(ctx)
+}
+
+func (s *webhookService) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	s.logger.Info("Updating webhook endpoint", zap.Int64("endpointID", endpoint.ID))
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to update webhook endpoint", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, id int64) error {
+	s.logger.Info("Deleting webhook endpoint", zap.Int64("endpointID", id))
+	return s.webhookRepo.DeleteEndpoint(ctx, id)
+}
+
+// ListDeliveries pages through the deliveries to the endpoint, latest first
+func (s *webhookService) ListDeliveries(ctx context.Context, endpointID int64, page domain.PageRequest) (*domain.Page[*domain.WebhookDelivery], error) {
+	if _, err := s.webhookRepo.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.ListDeliveries(ctx, endpointID, page.Normalize())
+}
+
+// Redeliver queues the delivery's event again for the same endpoint. The original
+// delivery is kept in the log unchanged.
+func (s *webhookService) Redeliver(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error) {
+	s.logger.Info("Redelivering webhook", zap.Int64("deliveryID", deliveryID))
+
+	original, err := s.webhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &domain.WebhookDelivery{
+		EndpointID: original.EndpointID,
+		EventID:    original.EventID,
+		EventType:  original.EventType,
+		Payload:    original.Payload,
+		Redelivery: true,
+	}
+
+	if err := s.webhookRepo.EnqueueDelivery(ctx, delivery); err != nil {
+		s.logger.Error("Failed to enqueue webhook redelivery", zap.Error(err))
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Run delivers queued webhooks until ctx is done
+func (s *webhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *webhookService) dispatch(ctx context.Context) {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, webhookBatchSize, webhookLease)
+	if err != nil {
+		s.logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return
+	}
+
+	for _, delivery := range deliveries {
+		s.deliver(ctx, delivery)
+	}
+}
+
+func (s *webhookService) deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	endpoint, err := s.webhookRepo.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		s.markFailed(ctx, delivery, nil, "endpoint not found", nil)
+		return
+	}
+
+	if !endpoint.Active {
+		s.markFailed(ctx, delivery, nil, "endpoint disabled", nil)
+		return
+	}
+
+	response, err := s.sender.Send(ctx, endpoint, delivery)
+	if err != nil {
+		var nextAttemptAt *time.Time
+		if delivery.Attempts < s.config.MaxAttempts {
+			next := time.Now().Add(notificationBackoff(delivery.Attempts))
+			nextAttemptAt = &next
+		}
+		s.logger.Error("Failed to deliver webhook",
+			zap.Int64("deliveryID", delivery.ID),
+			zap.Int64("endpointID", endpoint.ID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(err),
+		)
+		s.markFailed(ctx, delivery, response, err.Error(), nextAttemptAt)
+		return
+	}
+
+	if err := s.webhookRepo.MarkDeliverySucceeded(ctx, delivery.ID, response); err != nil {
+		s.logger.Error("Failed to mark webhook delivery as succeeded",
+			zap.Int64("deliveryID", delivery.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Webhook delivered",
+		zap.Int64("deliveryID", delivery.ID),
+		zap.Int64("endpointID", endpoint.ID),
+		zap.Int("status", response.StatusCode),
+	)
+}
+
+func (s *webhookService) markFailed(ctx context.Context, delivery *domain.WebhookDelivery, response *domain.WebhookResponse, reason string, nextAttemptAt *time.Time) {
+	if err := s.webhookRepo.MarkDeliveryFailed(ctx, delivery.ID, response, reason, nextAttemptAt); err != nil {
+		s.logger.Error("Failed to mark webhook delivery as failed",
+			zap.Int64("deliveryID", delivery.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// webhookEventID derives the identifier receivers use to drop duplicates from the
+// domain event's outbox ID, so it is the same every time the event is published
+func webhookEventID(event *domain.Event) string {
+	return "evt_" + strconv.FormatInt(event.ID, 10)
+}
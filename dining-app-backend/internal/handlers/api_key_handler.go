@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyService ports.APIKeyService
+	validator     *utils.CustomValidator
+}
+
+func NewAPIKeyHandler(apiKeyService ports.APIKeyService, validator *utils.CustomValidator) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		validator:     validator,
+	}
+}
+
+// CreateAPIKey issues a key to a partner. The key itself is only shown in this response.
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	scopes := make([]domain.APIKeyScope, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = domain.APIKeyScope(scope)
+	}
+
+	createdBy := userID.(int64)
+	key := &domain.APIKey{
+		Partner:           req.Partner,
+		UserID:            req.UserID,
+		Scopes:            scopes,
+		RequestsPerMinute: req.RequestsPerMinute,
+		Burst:             req.Burst,
+		MonthlyQuota:      *req.MonthlyQuota,
+		CreatedBy:         &createdBy,
+	}
+
+	plaintext, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), key)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := toAPIKeyResponse(key)
+	response.Key = plaintext
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListAPIKeysResponse{
+		APIKeys:      make([]dto.APIKeyResponse, len(keys.Items)),
+		PageResponse: pageResponse(c, page, keys),
+	}
+	for i, key := range keys.Items {
+		response.APIKeys[i] = toAPIKeyResponse(key)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid api key id", err))
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "api key revoked successfully"})
+}
+
+// GetUsage lists the key's request counts by month. The months query parameter picks
+// how many of the latest months to return.
+func (h *APIKeyHandler) GetUsage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid api key id", err))
+		return
+	}
+
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid months", err))
+		return
+	}
+
+	key, usage, err := h.apiKeyService.GetUsage(c.Request.Context(), id, months)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.APIKeyUsageResponse{
+		APIKeyID:     key.ID,
+		MonthlyQuota: key.MonthlyQuota,
+		Months:       make([]dto.APIKeyMonthUsage, len(usage)),
+	}
+	for i, month := range usage {
+		response.Months[i] = dto.APIKeyMonthUsage{
+			Month:         month.Month.Format("2006-01"),
+			Requests:      month.Requests,
+			LastRequestAt: month.LastRequestAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func toAPIKeyResponse(key *domain.APIKey) dto.APIKeyResponse {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return dto.APIKeyResponse{
+		ID:                key.ID,
+		Partner:           key.Partner,
+		UserID:            key.UserID,
+		Prefix:            key.Prefix,
+		Scopes:            scopes,
+		RequestsPerMinute: key.RequestsPerMinute,
+		Burst:             key.Burst,
+		MonthlyQuota:      key.MonthlyQuota,
+		CreatedBy:         key.CreatedBy,
+		CreatedAt:         key.CreatedAt,
+		RevokedAt:         key.RevokedAt,
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -59,19 +60,14 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		return
 	}
 
-	// Try parsing date in DD-MM-YYYY format first
-	bookingDate, err := time.Parse("02-01-2006", req.BookingDate)
+	bookingDate, err := parseBookingDate(req.BookingDate)
 	if err != nil {
-		// If that fails, try YYYY-MM-DD format
-		bookingDate, err = time.Parse("2006-01-02", req.BookingDate)
-		if err != nil {
-			fmt.Printf("Date parsing error: %v\n", err)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid booking date format (use DD-MM-YYYY or YYYY-MM-DD)",
-				"error":   err.Error(),
-			})
-			return
-		}
+		fmt.Printf("Date parsing error: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid booking date format (use DD-MM-YYYY or YYYY-MM-DD)",
+			"error":   err.Error(),
+		})
+		return
 	}
 
 	// Parse start and end times
@@ -122,6 +118,12 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		Status:          domain.BookingStatusPending,
 	}
 
+	// Bookings made on the partner routes record which partner's key made them
+	if apiKeyID, exists := c.Get("apiKeyID"); exists {
+		id := apiKeyID.(int64)
+		booking.APIKeyID = &id
+	}
+
 	if err := h.bookingService.CreateBooking(c.Request.Context(), booking); err != nil {
 		appErr := err.(*apperrors.Error)
 		fmt.Printf("Service error: %+v\n", appErr)
@@ -142,16 +144,24 @@ func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 		return
 	}
 
-	bookings, err := h.bookingService.GetUserBookings(c.Request.Context(), userID.(int64))
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.bookingService.GetUserBookings(c.Request.Context(), userID.(int64), page)
 	if err != nil {
 		appErr := err.(*apperrors.Error)
 		c.JSON(apperrors.GetStatusCode(appErr), appErr)
 		return
 	}
 
-	response := make([]dto.BookingResponse, len(bookings))
-	for i, booking := range bookings {
-		response[i] = toBookingResponse(booking)
+	response := dto.ListBookingsResponse{
+		Bookings:     make([]dto.BookingResponse, len(bookings.Items)),
+		PageResponse: pageResponse(c, page, bookings),
+	}
+	for i, booking := range bookings.Items {
+		response.Bookings[i] = toBookingResponse(booking)
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -194,8 +204,273 @@ func (h *BookingHandler) UpdateBookingStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "booking status updated successfully"})
 }
 
+// RecordOutcome marks a booking that has started as completed or as a no-show
+func (h *BookingHandler) RecordOutcome(c *gin.Context) {
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid booking id", err))
+		return
+	}
+
+	var req dto.BookingOutcomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.bookingService.RecordOutcome(c.Request.Context(), bookingID, domain.BookingStatus(req.Status)); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "booking outcome recorded successfully"})
+}
+
+func (h *BookingHandler) CreateRecurringBooking(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.CreateRecurringBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid request data",
+			"errors":  h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Validation failed",
+			"errors":  h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	startDate, err := parseBookingDate(req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid start date format (use DD-MM-YYYY or YYYY-MM-DD)",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var untilDate *time.Time
+	if req.UntilDate != "" {
+		parsed, err := parseBookingDate(req.UntilDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": "Invalid until date format (use DD-MM-YYYY or YYYY-MM-DD)",
+				"error":   err.Error(),
+			})
+			return
+		}
+		untilDate = &parsed
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid start time format (use ISO 8601 format)",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid end time format (use ISO 8601 format)",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if !endTime.After(startTime) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "End time must be after start time",
+		})
+		return
+	}
+
+	series := &domain.BookingSeries{
+		UserID:          userID.(int64),
+		TableID:         req.TableID,
+		Frequency:       domain.RecurrenceFrequency(req.Frequency),
+		StartDate:       startDate,
+		UntilDate:       untilDate,
+		OccurrenceCount: req.Count,
+		StartTime:       startTime.Format("15:04"),
+		EndTime:         endTime.Format("15:04"),
+		NumberOfGuests:  req.NumberOfGuests,
+		SpecialRequests: req.SpecialRequests,
+	}
+
+	result, err := h.bookingService.CreateRecurringBooking(c.Request.Context(), series, req.SkipConflicts)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBookingSeriesResponse(result.Series, result.Bookings, result.Conflicts))
+}
+
+func (h *BookingHandler) GetBookingSeries(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	seriesID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid series id", err))
+		return
+	}
+
+	series, bookings, err := h.bookingService.GetBookingSeries(c.Request.Context(), seriesID, userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBookingSeriesResponse(series, bookings, nil))
+}
+
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid booking id", err))
+		return
+	}
+
+	var req dto.CancelBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	scope := domain.CancelScope(req.Scope)
+	if scope == "" {
+		scope = domain.CancelScopeOccurrence
+	}
+
+	cancelled, err := h.bookingService.CancelBooking(c.Request.Context(), bookingID, userID.(int64), scope)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "booking cancelled successfully",
+		"cancelled": cancelled,
+	})
+}
+
+// GetRestaurantBookings lists the restaurant's bookings on the date for its staff
+func (h *BookingHandler) GetRestaurantBookings(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "date must be formatted as YYYY-MM-DD", err))
+		return
+	}
+
+	bookings, err := h.bookingService.GetRestaurantBookings(c.Request.Context(), restaurantID, date)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.RestaurantBookingsResponse{
+		RestaurantID: restaurantID,
+		Date:         date.Format("2006-01-02"),
+		Bookings:     make([]dto.RestaurantBookingResponse, len(bookings)),
+	}
+	for i, booking := range bookings {
+		response.Bookings[i] = dto.RestaurantBookingResponse{
+			BookingResponse: toBookingResponse(booking),
+			UserID:          booking.UserID,
+			TableID:         booking.TableID,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseBookingDate accepts DD-MM-YYYY first, then YYYY-MM-DD
+func parseBookingDate(value string) (time.Time, error) {
+	date, err := time.Parse("02-01-2006", value)
+	if err != nil {
+		return time.Parse("2006-01-02", value)
+	}
+	return date, nil
+}
+
+func toBookingSeriesResponse(series *domain.BookingSeries, bookings []*domain.Booking, conflicts []domain.BookingConflict) dto.BookingSeriesResponse {
+	response := dto.BookingSeriesResponse{
+		ID:              series.ID,
+		TableID:         series.TableID,
+		Frequency:       string(series.Frequency),
+		RRule:           series.RRule(),
+		StartDate:       series.StartDate,
+		UntilDate:       series.UntilDate,
+		Count:           series.OccurrenceCount,
+		StartTime:       series.StartTime,
+		EndTime:         series.EndTime,
+		NumberOfGuests:  series.NumberOfGuests,
+		SpecialRequests: series.SpecialRequests,
+		Status:          string(series.Status),
+		Bookings:        make([]dto.BookingResponse, len(bookings)),
+	}
+
+	for i, booking := range bookings {
+		response.Bookings[i] = toBookingResponse(booking)
+	}
+
+	for _, conflict := range conflicts {
+		response.Conflicts = append(response.Conflicts, dto.BookingConflictResponse{
+			Date:   conflict.Date,
+			Reason: conflict.Reason,
+		})
+	}
+
+	return response
+}
+
 func toBookingResponse(booking *domain.Booking) dto.BookingResponse {
-	return dto.BookingResponse{
+	response := dto.BookingResponse{
 		ID:              booking.ID,
 		BookingDate:     booking.BookingDate,
 		StartTime:       booking.StartTime,
@@ -203,7 +478,19 @@ func toBookingResponse(booking *domain.Booking) dto.BookingResponse {
 		NumberOfGuests:  booking.NumberOfGuests,
 		Status:          string(booking.Status),
 		SpecialRequests: booking.SpecialRequests,
+		SeriesID:        booking.SeriesID,
+		APIKeyID:        booking.APIKeyID,
 		TableNumber:     booking.TableNumber,
 		RestaurantName:  booking.RestaurantName,
 	}
+
+	for _, deal := range booking.Deals {
+		response.Deals = append(response.Deals, dto.BookingDealResponse{
+			DealID:  deal.DealID,
+			Title:   deal.Title,
+			Benefit: deal.Benefit,
+		})
+	}
+
+	return response
 }
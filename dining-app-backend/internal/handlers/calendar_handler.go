@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/ical"
+	"github.com/gin-gonic/gin"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct {
+	calendarService ports.CalendarService
+	config          config.CalendarConfig
+}
+
+func NewCalendarHandler(calendarService ports.CalendarService, config config.CalendarConfig) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		config:          config,
+	}
+}
+
+func (h *CalendarHandler) ExportBooking(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid booking id", err))
+		return
+	}
+
+	booking, err := h.calendarService.GetBookingEvent(c.Request.Context(), bookingID, userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	calendar := &ical.Calendar{
+		ProductID: h.config.ProductID,
+		Method:    ical.MethodPublish,
+		Events:    []ical.Event{h.toCalendarEvent(booking)},
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="booking-%d.ics"`, booking.ID))
+	c.Data(http.StatusOK, calendarContentType, calendar.Bytes())
+}
+
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	token, err := h.calendarService.CreateFeedToken(c.Request.Context(), userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CalendarFeedResponse{
+		URL: strings.TrimSuffix(h.config.FeedBaseURL, "/") + "/" + token + ".ics",
+	})
+}
+
+func (h *CalendarHandler) RevokeFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	if err := h.calendarService.RevokeFeedToken(c.Request.Context(), userID.(int64)); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "calendar feed revoked successfully"})
+}
+
+// Feed serves the subscribable calendar. It is authenticated by the token in the URL
+// rather than a JWT, since calendar clients can't send custom headers.
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	bookings, err := h.calendarService.GetFeedBookings(c.Request.Context(), token)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	calendar := &ical.Calendar{
+		ProductID: h.config.ProductID,
+		Name:      "Dining reservations",
+		Events:    make([]ical.Event, len(bookings)),
+	}
+
+	for i, booking := range bookings {
+		calendar.Events[i] = h.toCalendarEvent(booking)
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, calendarContentType, calendar.Bytes())
+}
+
+func (h *CalendarHandler) toCalendarEvent(booking *domain.Booking) ical.Event {
+	location := booking.RestaurantName
+	if booking.RestaurantAddress != "" {
+		location += ", " + booking.RestaurantAddress
+	}
+
+	description := fmt.Sprintf("Table %s for %d guests", booking.TableNumber, booking.NumberOfGuests)
+	if booking.SpecialRequests != "" {
+		description += "\nSpecial requests: " + booking.SpecialRequests
+	}
+
+	status, sequence := ical.StatusTentative, 0
+	switch booking.Status {
+	case domain.BookingStatusConfirmed, domain.BookingStatusCompleted, domain.BookingStatusNoShow:
+		status, sequence = ical.StatusConfirmed, 1
+	case domain.BookingStatusCancelled:
+		status, sequence = ical.StatusCancelled, 2
+	}
+
+	return ical.Event{
+		UID:          fmt.Sprintf("booking-%d@%s", booking.ID, h.config.UIDDomain),
+		Sequence:     sequence,
+		Stamp:        time.Now(),
+		LastModified: booking.UpdatedAt,
+		Start:        booking.StartsAt(),
+		End:          booking.EndsAt(),
+		Summary:      fmt.Sprintf("Table for %d at %s", booking.NumberOfGuests, booking.RestaurantName),
+		Location:     location,
+		Description:  description,
+		Status:       status,
+	}
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DealHandler struct {
+	dealService ports.DealService
+	validator   *utils.CustomValidator
+}
+
+func NewDealHandler(dealService ports.DealService, validator *utils.CustomValidator) *DealHandler {
+	return &DealHandler{
+		dealService: dealService,
+		validator:   validator,
+	}
+}
+
+func (h *DealHandler) CreateDeal(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("restaurantId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	var req dto.DealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	deal := toDeal(&req)
+	deal.RestaurantID = restaurantID
+
+	if err := h.dealService.CreateDeal(c.Request.Context(), deal); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDealResponse(deal))
+}
+
+// ListDeals lists all of a restaurant's deals, including inactive ones
+func (h *DealHandler) ListDeals(c *gin.Context) {
+	h.listDeals(c, c.Param("restaurantId"), false)
+}
+
+// ListActiveDeals lists the deals guests can currently get
+func (h *DealHandler) ListActiveDeals(c *gin.Context) {
+	h.listDeals(c, c.Param("id"), true)
+}
+
+func (h *DealHandler) listDeals(c *gin.Context, param string, activeOnly bool) {
+	restaurantID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	deals, err := h.dealService.ListRestaurantDeals(c.Request.Context(), restaurantID, activeOnly)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := make([]dto.DealResponse, len(deals))
+	for i, deal := range deals {
+		response[i] = toDealResponse(deal)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateDeal replaces the deal's terms. Bookings it is already attached to keep it.
+func (h *DealHandler) UpdateDeal(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid deal id", err))
+		return
+	}
+
+	var req dto.DealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	deal := toDeal(&req)
+	deal.ID = id
+
+	if err := h.dealService.UpdateDeal(c.Request.Context(), deal); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDealResponse(deal))
+}
+
+func (h *DealHandler) DeleteDeal(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid deal id", err))
+		return
+	}
+
+	if err := h.dealService.DeleteDeal(c.Request.Context(), id); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "deal deleted successfully"})
+}
+
+// SearchAvailability lists the open slots on a date for a party, with their deals
+func (h *DealHandler) SearchAvailability(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "date must be formatted as YYYY-MM-DD", err))
+		return
+	}
+
+	partySize, err := strconv.Atoi(c.DefaultQuery("party_size", "2"))
+	if err != nil || partySize < 1 {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "party_size must be a positive number", err))
+		return
+	}
+
+	slots, err := h.dealService.SearchAvailability(c.Request.Context(), restaurantID, date, partySize)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.AvailabilityResponse{
+		RestaurantID: restaurantID,
+		Date:         date.Format("2006-01-02"),
+		PartySize:    partySize,
+		Slots:        make([]dto.AvailabilitySlotResponse, len(slots)),
+	}
+
+	for i, slot := range slots {
+		deals := make([]dto.BookingDealResponse, len(slot.Deals))
+		for j, deal := range slot.Deals {
+			dealID := deal.ID
+			deals[j] = dto.BookingDealResponse{
+				DealID:  &dealID,
+				Title:   deal.Title,
+				Benefit: deal.Benefit(),
+			}
+		}
+
+		response.Slots[i] = dto.AvailabilitySlotResponse{
+			StartTime:       slot.StartTime,
+			EndTime:         slot.EndTime,
+			AvailableTables: slot.AvailableTables,
+			Deals:           deals,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toDeal converts the request; binding has already checked the date formats.
+// Deals are active unless the request says otherwise.
+func toDeal(req *dto.DealRequest) *domain.Deal {
+	deal := &domain.Deal{
+		Title:               req.Title,
+		Description:         req.Description,
+		BenefitType:         domain.DealBenefitType(req.BenefitType),
+		DiscountPercent:     req.DiscountPercent,
+		AppliesTo:           req.AppliesTo,
+		FreeItem:            req.FreeItem,
+		DaysOfWeek:          req.DaysOfWeek,
+		StartTime:           req.StartTime,
+		EndTime:             req.EndTime,
+		MinPartySize:        req.MinPartySize,
+		MaxPartySize:        req.MaxPartySize,
+		FirstTimeGuestsOnly: req.FirstTimeGuestsOnly,
+		MaxRedemptions:      req.MaxRedemptions,
+		MaxPerGuest:         req.MaxPerGuest,
+		Active:              req.Active == nil || *req.Active,
+	}
+
+	if deal.DaysOfWeek == nil {
+		deal.DaysOfWeek = []int{}
+	}
+	if req.ValidFrom != "" {
+		validFrom, _ := time.Parse("2006-01-02", req.ValidFrom)
+		deal.ValidFrom = &validFrom
+	}
+	if req.ValidUntil != "" {
+		validUntil, _ := time.Parse("2006-01-02", req.ValidUntil)
+		deal.ValidUntil = &validUntil
+	}
+
+	return deal
+}
+
+func toDealResponse(deal *domain.Deal) dto.DealResponse {
+	return dto.DealResponse{
+		ID:                  deal.ID,
+		RestaurantID:        deal.RestaurantID,
+		Title:               deal.Title,
+		Description:         deal.Description,
+		BenefitType:         string(deal.BenefitType),
+		DiscountPercent:     deal.DiscountPercent,
+		AppliesTo:           deal.AppliesTo,
+		FreeItem:            deal.FreeItem,
+		Benefit:             deal.Benefit(),
+		DaysOfWeek:          deal.DaysOfWeek,
+		StartTime:           deal.StartTime,
+		EndTime:             deal.EndTime,
+		MinPartySize:        deal.MinPartySize,
+		MaxPartySize:        deal.MaxPartySize,
+		FirstTimeGuestsOnly: deal.FirstTimeGuestsOnly,
+		ValidFrom:           deal.ValidFrom,
+		ValidUntil:          deal.ValidUntil,
+		MaxRedemptions:      deal.MaxRedemptions,
+		MaxPerGuest:         deal.MaxPerGuest,
+		Active:              deal.Active,
+		CreatedAt:           deal.CreatedAt,
+		UpdatedAt:           deal.UpdatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest issues a key for the partner's user account. A monthly quota of
+// 0 means no quota; a key without a rate limit gets the configured default.
+type CreateAPIKeyRequest struct {
+	Partner           string   `json:"partner" binding:"required,max=100"`
+	UserID            int64    `json:"user_id" binding:"required"`
+	Scopes            []string `json:"scopes" binding:"required,min=1,dive,oneof=availability:read bookings:read bookings:write"`
+	RequestsPerMinute int      `json:"requests_per_minute" binding:"min=0"`
+	Burst             int      `json:"burst" binding:"min=0"`
+	MonthlyQuota      *int     `json:"monthly_quota" binding:"required,min=0"`
+}
+
+type APIKeyResponse struct {
+	ID                int64      `json:"id"`
+	Partner           string     `json:"partner"`
+	UserID            int64      `json:"user_id"`
+	Prefix            string     `json:"prefix"`
+	Scopes            []string   `json:"scopes"`
+	RequestsPerMinute int        `json:"requests_per_minute"`
+	Burst             int        `json:"burst"`
+	MonthlyQuota      int        `json:"monthly_quota"`
+	CreatedBy         *int64     `json:"created_by,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	// Key is only returned when the key is created
+	Key string `json:"key,omitempty"`
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+	PageResponse
+}
+
+// APIKeyUsageResponse lists a key's request counts by month, latest first
+type APIKeyUsageResponse struct {
+	APIKeyID     int64              `json:"api_key_id"`
+	MonthlyQuota int                `json:"monthly_quota"`
+	Months       []APIKeyMonthUsage `json:"months"`
+}
+
+type APIKeyMonthUsage struct {
+	Month         string    `json:"month"`
+	Requests      int64     `json:"requests"`
+	LastRequestAt time.Time `json:"last_request_at"`
+}
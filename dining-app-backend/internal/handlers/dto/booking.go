@@ -16,6 +16,26 @@ type UpdateBookingStatusRequest struct {
 	Status string `json:"status" binding:"required,oneof=pending confirmed cancelled"`
 }
 
+// CreateRecurringBookingRequest represents the request body for creating a recurring booking
+type CreateRecurringBookingRequest struct {
+	TableID         int64  `json:"table_id" validate:"required"`
+	StartDate       string `json:"start_date" validate:"required"`
+	StartTime       string `json:"start_time" validate:"required"`
+	EndTime         string `json:"end_time" validate:"required"`
+	NumberOfGuests  int    `json:"number_of_guests" validate:"required,min=1"`
+	SpecialRequests string `json:"special_requests"`
+	Frequency       string `json:"frequency" validate:"required,oneof=weekly biweekly monthly"`
+	UntilDate       string `json:"until_date" validate:"required_without=Count"`
+	Count           int    `json:"count" validate:"omitempty,min=1,max=52"`
+	// SkipConflicts books the available occurrences instead of rejecting the whole series
+	SkipConflicts bool `json:"skip_conflicts"`
+}
+
+// CancelBookingRequest represents the request body for cancelling a booking
+type CancelBookingRequest struct {
+	Scope string `json:"scope" validate:"omitempty,oneof=occurrence following series"`
+}
+
 // BookingResponse represents the response body for booking operations
 type BookingResponse struct {
 	ID              int64     `json:"id"`
@@ -25,7 +45,54 @@ type BookingResponse struct {
 	NumberOfGuests  int       `json:"number_of_guests"`
 	Status          string    `json:"status"`
 	SpecialRequests string    `json:"special_requests"`
+	SeriesID        *int64    `json:"series_id,omitempty"`
+	APIKeyID        *int64    `json:"api_key_id,omitempty"`
 	// You might want to add these fields if needed
-	TableNumber    string `json:"table_number,omitempty"`
-	RestaurantName string `json:"restaurant_name,omitempty"`
+	TableNumber    string                `json:"table_number,omitempty"`
+	RestaurantName string                `json:"restaurant_name,omitempty"`
+	Deals          []BookingDealResponse `json:"deals,omitempty"`
+}
+
+// ListBookingsResponse is a page of the user's bookings, latest first
+type ListBookingsResponse struct {
+	Bookings []BookingResponse `json:"bookings"`
+	PageResponse
+}
+
+// BookingSeriesResponse represents the response body for recurring booking operations
+type BookingSeriesResponse struct {
+	ID              int64                     `json:"id"`
+	TableID         int64                     `json:"table_id"`
+	Frequency       string                    `json:"frequency"`
+	RRule           string                    `json:"rrule"`
+	StartDate       time.Time                 `json:"start_date"`
+	UntilDate       *time.Time                `json:"until_date,omitempty"`
+	Count           int                       `json:"count,omitempty"`
+	StartTime       string                    `json:"start_time"`
+	EndTime         string                    `json:"end_time"`
+	NumberOfGuests  int                       `json:"number_of_guests"`
+	SpecialRequests string                    `json:"special_requests"`
+	Status          string                    `json:"status"`
+	Bookings        []BookingResponse         `json:"bookings"`
+	Conflicts       []BookingConflictResponse `json:"conflicts,omitempty"`
+}
+
+// BookingConflictResponse describes an occurrence that could not be booked
+type BookingConflictResponse struct {
+	Date   time.Time `json:"date"`
+	Reason string    `json:"reason"`
+}
+
+// RestaurantBookingResponse is a booking as the restaurant's staff see it
+type RestaurantBookingResponse struct {
+	BookingResponse
+	UserID  int64 `json:"user_id"`
+	TableID int64 `json:"table_id"`
+}
+
+// RestaurantBookingsResponse lists a restaurant's bookings on one day by start time
+type RestaurantBookingsResponse struct {
+	RestaurantID int64                       `json:"restaurant_id"`
+	Date         string                      `json:"date"`
+	Bookings     []RestaurantBookingResponse `json:"bookings"`
 }
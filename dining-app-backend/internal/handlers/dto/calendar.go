@@ -0,0 +1,6 @@
+package dto
+
+// CalendarFeedResponse carries the subscribable calendar URL. It is only shown once.
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
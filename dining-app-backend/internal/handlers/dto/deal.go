@@ -0,0 +1,70 @@
+package dto
+
+import "time"
+
+type DealRequest struct {
+	Title               string `json:"title" binding:"required,max=200"`
+	Description         string `json:"description" binding:"max=2000"`
+	BenefitType         string `json:"benefit_type" binding:"required,oneof=percent_off free_item"`
+	DiscountPercent     int    `json:"discount_percent" binding:"omitempty,min=1,max=100"`
+	AppliesTo           string `json:"applies_to" binding:"omitempty,oneof=food drinks total"`
+	FreeItem            string `json:"free_item" binding:"max=200"`
+	DaysOfWeek          []int  `json:"days_of_week" binding:"dive,min=0,max=6"`
+	StartTime           string `json:"start_time" binding:"omitempty,datetime=15:04"`
+	EndTime             string `json:"end_time" binding:"omitempty,datetime=15:04"`
+	MinPartySize        int    `json:"min_party_size" binding:"min=0"`
+	MaxPartySize        int    `json:"max_party_size" binding:"min=0"`
+	FirstTimeGuestsOnly bool   `json:"first_time_guests_only"`
+	// Dates as YYYY-MM-DD, both inclusive
+	ValidFrom      string `json:"valid_from" binding:"omitempty,datetime=2006-01-02"`
+	ValidUntil     string `json:"valid_until" binding:"omitempty,datetime=2006-01-02"`
+	MaxRedemptions int    `json:"max_redemptions" binding:"min=0"`
+	MaxPerGuest    int    `json:"max_per_guest" binding:"min=0"`
+	Active         *bool  `json:"active"`
+}
+
+type DealResponse struct {
+	ID                  int64      `json:"id"`
+	RestaurantID        int64      `json:"restaurant_id"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	BenefitType         string     `json:"benefit_type"`
+	DiscountPercent     int        `json:"discount_percent,omitempty"`
+	AppliesTo           string     `json:"applies_to,omitempty"`
+	FreeItem            string     `json:"free_item,omitempty"`
+	Benefit             string     `json:"benefit"`
+	DaysOfWeek          []int      `json:"days_of_week"`
+	StartTime           string     `json:"start_time,omitempty"`
+	EndTime             string     `json:"end_time,omitempty"`
+	MinPartySize        int        `json:"min_party_size,omitempty"`
+	MaxPartySize        int        `json:"max_party_size,omitempty"`
+	FirstTimeGuestsOnly bool       `json:"first_time_guests_only"`
+	ValidFrom           *time.Time `json:"valid_from,omitempty"`
+	ValidUntil          *time.Time `json:"valid_until,omitempty"`
+	MaxRedemptions      int        `json:"max_redemptions,omitempty"`
+	MaxPerGuest         int        `json:"max_per_guest,omitempty"`
+	Active              bool       `json:"active"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// BookingDealResponse is a deal as attached to a booking
+type BookingDealResponse struct {
+	DealID  *int64 `json:"deal_id"`
+	Title   string `json:"title"`
+	Benefit string `json:"benefit"`
+}
+
+type AvailabilitySlotResponse struct {
+	StartTime       string                `json:"start_time"`
+	EndTime         string                `json:"end_time"`
+	AvailableTables int                   `json:"available_tables"`
+	Deals           []BookingDealResponse `json:"deals"`
+}
+
+type AvailabilityResponse struct {
+	RestaurantID int64                      `json:"restaurant_id"`
+	Date         string                     `json:"date"`
+	PartySize    int                        `json:"party_size"`
+	Slots        []AvailabilitySlotResponse `json:"slots"`
+}
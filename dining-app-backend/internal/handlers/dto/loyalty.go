@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type LoyaltyAdjustmentRequest struct {
+	Points int    `json:"points" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type LoyaltyRulesRequest struct {
+	Enabled          bool `json:"enabled"`
+	PointsPerBooking int  `json:"points_per_booking" binding:"min=0"`
+	PointsPerGuest   int  `json:"points_per_guest" binding:"min=0"`
+	NoShowPenalty    int  `json:"no_show_penalty" binding:"min=0"`
+}
+
+type LoyaltyTierResponse struct {
+	Name      string   `json:"name"`
+	MinPoints int      `json:"min_points"`
+	Perks     []string `json:"perks"`
+}
+
+type LoyaltyAccountResponse struct {
+	Balance          int                  `json:"balance"`
+	LifetimePoints   int                  `json:"lifetime_points"`
+	Tier             *LoyaltyTierResponse `json:"tier"`
+	NextTier         *LoyaltyTierResponse `json:"next_tier,omitempty"`
+	PointsToNextTier int                  `json:"points_to_next_tier,omitempty"`
+}
+
+type LoyaltyEntryResponse struct {
+	ID           int64     `json:"id"`
+	Kind         string    `json:"kind"`
+	Points       int       `json:"points"`
+	BookingID    *int64    `json:"booking_id,omitempty"`
+	RestaurantID *int64    `json:"restaurant_id,omitempty"`
+	Reason       string    `json:"reason"`
+	CreatedBy    *int64    `json:"created_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type LoyaltyLedgerResponse struct {
+	Entries []LoyaltyEntryResponse `json:"entries"`
+	PageResponse
+}
+
+type LoyaltyRulesResponse struct {
+	RestaurantID     int64 `json:"restaurant_id"`
+	Enabled          bool  `json:"enabled"`
+	PointsPerBooking int   `json:"points_per_booking"`
+	PointsPerGuest   int   `json:"points_per_guest"`
+	NoShowPenalty    int   `json:"no_show_penalty"`
+}
+
+// BookingOutcomeRequest records how a booking that has started turned out
+type BookingOutcomeRequest struct {
+	Status string `json:"status" binding:"required,oneof=completed no_show"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type RestaurantImageResponse struct {
+	ID           int64     `json:"id"`
+	Kind         string    `json:"kind"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	SizeBytes    int64     `json:"size_bytes"`
+	Position     int       `json:"position"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ListRestaurantImagesResponse struct {
+	Cover   *RestaurantImageResponse  `json:"cover"`
+	Gallery []RestaurantImageResponse `json:"gallery"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+	Role  string `json:"role" binding:"required,oneof=owner manager host"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner manager host"`
+}
+
+type AcceptInviteRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type MembershipResponse struct {
+	RestaurantID   int64     `json:"restaurant_id"`
+	RestaurantName string    `json:"restaurant_name,omitempty"`
+	UserID         int64     `json:"user_id"`
+	UserName       string    `json:"user_name,omitempty"`
+	UserEmail      string    `json:"user_email,omitempty"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ListMembershipsResponse struct {
+	Memberships []MembershipResponse `json:"memberships"`
+}
+
+// ListMembersResponse is a page of a restaurant's staff, owners first
+type ListMembersResponse struct {
+	Memberships []MembershipResponse `json:"memberships"`
+	PageResponse
+}
+
+// InviteResponse never includes the token, which only the invitee receives
+type InviteResponse struct {
+	ID           int64     `json:"id"`
+	RestaurantID int64     `json:"restaurant_id"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	InvitedBy    *int64    `json:"invited_by,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Expired      bool      `json:"expired"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ListInvitesResponse struct {
+	Invites []InviteResponse `json:"invites"`
+	PageResponse
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+type MenuRequest struct {
+	Currency string               `json:"currency" binding:"required,iso4217"`
+	Dayparts []Daypart            `json:"dayparts" binding:"dive"`
+	Sections []MenuSectionRequest `json:"sections" binding:"required,min=1,dive"`
+}
+
+type Daypart struct {
+	Name      string `json:"name" binding:"required,max=50"`
+	StartTime string `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime   string `json:"end_time" binding:"required,datetime=15:04"`
+}
+
+type MenuSectionRequest struct {
+	Name        string            `json:"name" binding:"required,max=100"`
+	Description string            `json:"description" binding:"max=500"`
+	Items       []MenuItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type MenuItemRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	// Price is a decimal amount in the menu's currency, such as "12.50"
+	Price        string   `json:"price" binding:"required"`
+	DietaryFlags []string `json:"dietary_flags" binding:"dive,oneof=vegetarian vegan pescatarian gluten_free dairy_free nut_free halal kosher spicy"`
+	Allergens    []string `json:"allergens" binding:"dive,oneof=celery gluten crustaceans eggs fish lupin milk molluscs mustard nuts peanuts sesame soy sulphites"`
+	Dayparts     []string `json:"dayparts" binding:"dive,required"`
+}
+
+type MenuResponse struct {
+	RestaurantID   int64                 `json:"restaurant_id"`
+	Version        int                   `json:"version"`
+	Status         string                `json:"status"`
+	BasedOnVersion *int                  `json:"based_on_version,omitempty"`
+	Currency       string                `json:"currency"`
+	Dayparts       []Daypart             `json:"dayparts"`
+	Sections       []MenuSectionResponse `json:"sections"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	PublishedAt    *time.Time            `json:"published_at,omitempty"`
+}
+
+type MenuSectionResponse struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Items       []MenuItemResponse `json:"items"`
+}
+
+type MenuItemResponse struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Price        string   `json:"price"`
+	PriceMinor   int64    `json:"price_minor"`
+	DietaryFlags []string `json:"dietary_flags"`
+	Allergens    []string `json:"allergens"`
+	Dayparts     []string `json:"dayparts"`
+}
+
+type MenuVersionResponse struct {
+	Version        int        `json:"version"`
+	Status         string     `json:"status"`
+	BasedOnVersion *int       `json:"based_on_version,omitempty"`
+	UpdatedBy      int64      `json:"updated_by"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+}
+
+// ListMenuVersionsResponse is a page of a menu's published and archived versions,
+// newest first
+type ListMenuVersionsResponse struct {
+	Versions []MenuVersionResponse `json:"versions"`
+	PageResponse
+}
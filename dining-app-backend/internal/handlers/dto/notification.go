@@ -0,0 +1,17 @@
+package dto
+
+type NotificationPreferencesRequest struct {
+	EmailEnabled     bool   `json:"email_enabled"`
+	SMSEnabled       bool   `json:"sms_enabled"`
+	RemindersEnabled bool   `json:"reminders_enabled"`
+	Phone            string `json:"phone" validate:"omitempty,phone"`
+	Locale           string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+}
+
+type NotificationPreferencesResponse struct {
+	EmailEnabled     bool   `json:"email_enabled"`
+	SMSEnabled       bool   `json:"sms_enabled"`
+	RemindersEnabled bool   `json:"reminders_enabled"`
+	Phone            string `json:"phone,omitempty"`
+	Locale           string `json:"locale"`
+}
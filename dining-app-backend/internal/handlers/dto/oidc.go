@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type OIDCStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// OIDCCallbackRequest carries what the provider sent back to the redirect URL
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+type IdentityResponse struct {
+	ID          int64     `json:"id"`
+	Provider    string    `json:"provider"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+type ListIdentitiesResponse struct {
+	Identities []IdentityResponse `json:"identities"`
+}
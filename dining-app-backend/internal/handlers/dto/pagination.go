@@ -0,0 +1,18 @@
+package dto
+
+// PageQuery selects a page by number, or continues from the cursor returned with the
+// previous page. The two can't be combined.
+type PageQuery struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor" binding:"omitempty,max=512"`
+}
+
+// PageResponse is embedded in list responses. Page and Total are left out of cursor pages.
+type PageResponse struct {
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	Total      *int   `json:"total,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
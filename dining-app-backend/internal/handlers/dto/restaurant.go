@@ -7,15 +7,40 @@ type CreateRestaurantRequest struct {
 	CuisineType string `json:"cuisine_type" binding:"required"`
 	OpeningTime string `json:"opening_time" binding:"required"`
 	ClosingTime string `json:"closing_time" binding:"required"`
+	Timezone    string `json:"timezone" binding:"omitempty,timezone"`
+	// Geocoded from the address when both are left out
+	Latitude  *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,longitude"`
 }
 
 type UpdateRestaurantRequest struct {
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
-	Address     string `json:"address,omitempty"`
-	CuisineType string `json:"cuisine_type,omitempty"`
-	OpeningTime string `json:"opening_time,omitempty"`
-	ClosingTime string `json:"closing_time,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Address     string   `json:"address,omitempty"`
+	CuisineType string   `json:"cuisine_type,omitempty"`
+	OpeningTime string   `json:"opening_time,omitempty"`
+	ClosingTime string   `json:"closing_time,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"`
+	Latitude    *float64 `json:"latitude,omitempty" binding:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude,omitempty" binding:"omitempty,longitude"`
+}
+
+// SearchRestaurantsQuery holds the listing's query string. Cuisines are comma-separated
+// and near is a "lat,lng" pair.
+type SearchRestaurantsQuery struct {
+	Query    string  `form:"q" binding:"max=200"`
+	Cuisine  string  `form:"cuisine"`
+	OpenNow  bool    `form:"open_now"`
+	Near     string  `form:"near"`
+	RadiusKm float64 `form:"radius_km" binding:"omitempty,gt=0"`
+	Sort     string  `form:"sort" binding:"omitempty,oneof=relevance name rating newest distance"`
+	PageQuery
+}
+
+// RestaurantHighlights mark the matched terms with <mark> tags
+type RestaurantHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
 
 type RestaurantResponse struct {
@@ -26,12 +51,24 @@ type RestaurantResponse struct {
 	CuisineType string          `json:"cuisine_type"`
 	OpeningTime string          `json:"opening_time"`
 	ClosingTime string          `json:"closing_time"`
+	Timezone    string          `json:"timezone"`
+	Latitude    *float64        `json:"latitude"`
+	Longitude   *float64        `json:"longitude"`
 	Tables      []TableResponse `json:"tables"`
+	// Listings only carry the cover
+	CoverImage *RestaurantImageResponse  `json:"cover_image"`
+	Gallery    []RestaurantImageResponse `json:"gallery,omitempty"`
+	// AverageRating is 0 while the restaurant has no reviews
+	AverageRating float64 `json:"average_rating"`
+	ReviewCount   int     `json:"review_count"`
+	// Set on search results
+	Relevance  float64               `json:"relevance,omitempty"`
+	Highlights *RestaurantHighlights `json:"highlights,omitempty"`
+	// Set when searching near a point, in kilometres
+	DistanceKm *float64 `json:"distance_km,omitempty"`
 }
 
 type ListRestaurantsResponse struct {
 	Restaurants []RestaurantResponse `json:"restaurants"`
-	Total       int                  `json:"total"`
-	Page        int                  `json:"page"`
-	PageSize    int                  `json:"page_size"`
+	PageResponse
 }
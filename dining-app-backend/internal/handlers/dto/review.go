@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type CreateReviewRequest struct {
+	BookingID int64  `json:"booking_id" binding:"required"`
+	Rating    int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment   string `json:"comment" binding:"max=2000"`
+}
+
+type UpdateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=2000"`
+}
+
+type ReviewVisibilityRequest struct {
+	Hidden bool   `json:"hidden"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type ReviewResponse struct {
+	ID           int64     `json:"id"`
+	RestaurantID int64     `json:"restaurant_id"`
+	BookingID    int64     `json:"booking_id"`
+	UserName     string    `json:"user_name"`
+	Rating       int       `json:"rating"`
+	Comment      string    `json:"comment"`
+	Hidden       bool      `json:"hidden,omitempty"`
+	HiddenReason string    `json:"hidden_reason,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ListReviewsResponse struct {
+	Reviews []ReviewResponse `json:"reviews"`
+	PageResponse
+}
@@ -0,0 +1,77 @@
+package dto
+
+import "time"
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+type UpdateRoleRequest struct {
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type RoleResponse struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	System      bool      `json:"system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListRolesResponse also lists every permission roles can grant
+type ListRolesResponse struct {
+	Roles       []RoleResponse `json:"roles"`
+	Permissions []string       `json:"permissions"`
+}
+
+// UserPermissionsResponse is what the signed-in user's current role allows
+type UserPermissionsResponse struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+type InviteToRoleRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+	Role  string `json:"role" binding:"required"`
+}
+
+// RoleInviteResponse never includes the token, which only the invitee receives
+type RoleInviteResponse struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy *int64    `json:"invited_by,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Expired   bool      `json:"expired"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListRoleInvitesResponse struct {
+	Invites []RoleInviteResponse `json:"invites"`
+	PageResponse
+}
+
+// RoleChangeResponse is one audited change of a user's role. ChangedBy is empty for
+// the bootstrapped administrator.
+type RoleChangeResponse struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	OldRole   string    `json:"old_role"`
+	NewRole   string    `json:"new_role"`
+	ChangedBy *int64    `json:"changed_by,omitempty"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListRoleChangesResponse struct {
+	Changes []RoleChangeResponse `json:"changes"`
+	PageResponse
+}
@@ -16,3 +16,8 @@ type TableResponse struct {
 	Capacity     int    `json:"capacity"`
 	IsAvailable  bool   `json:"is_available"`
 }
+
+type ListTablesResponse struct {
+	Tables []TableResponse `json:"tables"`
+	PageResponse
+}
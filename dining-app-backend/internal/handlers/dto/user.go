@@ -1,10 +1,10 @@
 package dto
 
+// RegisterRequest checks the password's strength like ResetPasswordRequest does
 type RegisterRequest struct {
 	Name     string `json:"name" binding:"required,min=2,max=100"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role" binding:"omitempty,oneof=user admin"`
+	Password string `json:"password" binding:"required,max=72" validate:"password"`
 }
 
 type LoginRequest struct {
@@ -13,18 +13,42 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string      `json:"token"`
-	User  UserProfile `json:"user"`
+	TokenResponse
+	User UserProfile `json:"user"`
 }
 
+// UserProfile describes a user. PendingEmail is set while a new email awaits
+// verification; Email stays the current one until then.
 type UserProfile struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Email         string  `json:"email"`
+	EmailVerified bool    `json:"email_verified"`
+	PendingEmail  *string `json:"pending_email,omitempty"`
+	Role          string  `json:"role"`
 }
 
 type UpdateProfileRequest struct {
 	Name  string `json:"name" binding:"required,min=2,max=100"`
 	Email string `json:"email" binding:"required,email"`
 }
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest and ChangePasswordRequest check the new password's strength
+// with the custom password validator
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=72" validate:"password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,max=72" validate:"password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
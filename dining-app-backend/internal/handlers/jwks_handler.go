@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	authService *auth.Service
+}
+
+func NewJWKSHandler(authService *auth.Service) *JWKSHandler {
+	return &JWKSHandler{
+		authService: authService,
+	}
+}
+
+// Keys publishes the public keys that verify access tokens, so other services can check
+// tokens without being able to issue them. Verifiers may cache the set for five
+// minutes, so a new key should be listed that long before it signs.
+func (h *JWKSHandler) Keys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type LoyaltyHandler struct {
+	loyaltyService ports.LoyaltyService
+	validator      *utils.CustomValidator
+}
+
+func NewLoyaltyHandler(loyaltyService ports.LoyaltyService, validator *utils.CustomValidator) *LoyaltyHandler {
+	return &LoyaltyHandler{
+		loyaltyService: loyaltyService,
+		validator:      validator,
+	}
+}
+
+// GetAccount returns the signed-in user's balance and tier
+func (h *LoyaltyHandler) GetAccount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	h.getAccount(c, userID.(int64))
+}
+
+// GetLedger returns the signed-in user's ledger, newest first
+func (h *LoyaltyHandler) GetLedger(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	h.getLedger(c, userID.(int64))
+}
+
+func (h *LoyaltyHandler) GetUserAccount(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	h.getAccount(c, userID)
+}
+
+func (h *LoyaltyHandler) GetUserLedger(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	h.getLedger(c, userID)
+}
+
+// Adjust credits or debits a user's points on behalf of the signed-in admin
+func (h *LoyaltyHandler) Adjust(c *gin.Context) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	var req dto.LoyaltyAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	createdBy := adminID.(int64)
+	entry := &domain.LoyaltyEntry{
+		UserID:    userID,
+		Points:    req.Points,
+		Reason:    req.Reason,
+		CreatedBy: &createdBy,
+	}
+
+	if err := h.loyaltyService.Adjust(c.Request.Context(), entry); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toLoyaltyEntryResponse(entry))
+}
+
+func (h *LoyaltyHandler) GetRules(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	rules, err := h.loyaltyService.GetRules(c.Request.Context(), restaurantID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLoyaltyRulesResponse(rules))
+}
+
+// UpdateRules sets the restaurant's accrual rules. Points already in ledgers are not
+// recalculated.
+func (h *LoyaltyHandler) UpdateRules(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	var req dto.LoyaltyRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	rules := &domain.LoyaltyRules{
+		RestaurantID:     restaurantID,
+		Enabled:          req.Enabled,
+		PointsPerBooking: req.PointsPerBooking,
+		PointsPerGuest:   req.PointsPerGuest,
+		NoShowPenalty:    req.NoShowPenalty,
+	}
+
+	if err := h.loyaltyService.UpdateRules(c.Request.Context(), rules); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLoyaltyRulesResponse(rules))
+}
+
+func (h *LoyaltyHandler) getAccount(c *gin.Context, userID int64) {
+	account, err := h.loyaltyService.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.LoyaltyAccountResponse{
+		Balance:        account.Balance,
+		LifetimePoints: account.LifetimePoints,
+		Tier:           toLoyaltyTierResponse(account.Tier),
+		NextTier:       toLoyaltyTierResponse(account.NextTier),
+	}
+	if account.NextTier != nil {
+		response.PointsToNextTier = account.NextTier.MinPoints - account.LifetimePoints
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LoyaltyHandler) getLedger(c *gin.Context, userID int64) {
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	entries, err := h.loyaltyService.ListEntries(c.Request.Context(), userID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.LoyaltyLedgerResponse{
+		Entries:      make([]dto.LoyaltyEntryResponse, len(entries.Items)),
+		PageResponse: pageResponse(c, page, entries),
+	}
+
+	for i, entry := range entries.Items {
+		response.Entries[i] = toLoyaltyEntryResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func toLoyaltyEntryResponse(entry *domain.LoyaltyEntry) dto.LoyaltyEntryResponse {
+	return dto.LoyaltyEntryResponse{
+		ID:           entry.ID,
+		Kind:         string(entry.Kind),
+		Points:       entry.Points,
+		BookingID:    entry.BookingID,
+		RestaurantID: entry.RestaurantID,
+		Reason:       entry.Reason,
+		CreatedBy:    entry.CreatedBy,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+func toLoyaltyTierResponse(tier *domain.LoyaltyTier) *dto.LoyaltyTierResponse {
+	if tier == nil {
+		return nil
+	}
+	return &dto.LoyaltyTierResponse{
+		Name:      tier.Name,
+		MinPoints: tier.MinPoints,
+		Perks:     tier.Perks,
+	}
+}
+
+func toLoyaltyRulesResponse(rules *domain.LoyaltyRules) dto.LoyaltyRulesResponse {
+	return dto.LoyaltyRulesResponse{
+		RestaurantID:     rules.RestaurantID,
+		Enabled:          rules.Enabled,
+		PointsPerBooking: rules.PointsPerBooking,
+		PointsPerGuest:   rules.PointsPerGuest,
+		NoShowPenalty:    rules.NoShowPenalty,
+	}
+}
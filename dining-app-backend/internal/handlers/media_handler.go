@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for the multipart framing around the uploaded file
+const multipartOverhead = 64 << 10
+
+type MediaHandler struct {
+	mediaService ports.MediaService
+	config       config.MediaConfig
+}
+
+func NewMediaHandler(mediaService ports.MediaService, config config.MediaConfig) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+		config:       config,
+	}
+}
+
+func (h *MediaHandler) ListImages(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	images, err := h.mediaService.ListImages(c.Request.Context(), restaurantID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRestaurantImagesResponse(images))
+}
+
+// UploadCover replaces the restaurant's cover with the "image" form file
+func (h *MediaHandler) UploadCover(c *gin.Context) {
+	h.upload(c, domain.ImageKindCover)
+}
+
+// UploadGalleryImage adds the "image" form file to the end of the restaurant's gallery
+func (h *MediaHandler) UploadGalleryImage(c *gin.Context) {
+	h.upload(c, domain.ImageKindGallery)
+}
+
+func (h *MediaHandler) DeleteImage(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid image id", err))
+		return
+	}
+
+	if err := h.mediaService.DeleteImage(c.Request.Context(), restaurantID, imageID); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "image deleted successfully"})
+}
+
+func (h *MediaHandler) upload(c *gin.Context, kind domain.ImageKind) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.config.MaxUploadBytes+multipartOverhead)
+	header, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, apperrors.NewError(apperrors.ErrorTypeValidation,
+				fmt.Sprintf("image must be at most %d bytes", h.config.MaxUploadBytes), nil))
+			return
+		}
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "image file is required", nil))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "failed to read upload", nil))
+		return
+	}
+	defer file.Close()
+
+	image, err := h.mediaService.UploadImage(c.Request.Context(), restaurantID, kind, file)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRestaurantImageResponse(image))
+}
+
+func toRestaurantImagesResponse(images []*domain.RestaurantImage) dto.ListRestaurantImagesResponse {
+	response := dto.ListRestaurantImagesResponse{
+		Gallery: []dto.RestaurantImageResponse{},
+	}
+
+	for _, image := range images {
+		if image.Kind == domain.ImageKindCover {
+			cover := toRestaurantImageResponse(image)
+			response.Cover = &cover
+			continue
+		}
+		response.Gallery = append(response.Gallery, toRestaurantImageResponse(image))
+	}
+
+	return response
+}
+
+func toRestaurantImageResponse(image *domain.RestaurantImage) dto.RestaurantImageResponse {
+	return dto.RestaurantImageResponse{
+		ID:           image.ID,
+		Kind:         string(image.Kind),
+		URL:          image.URL,
+		ThumbnailURL: image.ThumbnailURL,
+		ContentType:  image.ContentType,
+		Width:        image.Width,
+		Height:       image.Height,
+		SizeBytes:    image.SizeBytes,
+		Position:     image.Position,
+		CreatedAt:    image.CreatedAt,
+	}
+}
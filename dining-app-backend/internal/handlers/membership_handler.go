@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MembershipHandler struct {
+	membershipService ports.MembershipService
+	validator         *utils.CustomValidator
+}
+
+func NewMembershipHandler(membershipService ports.MembershipService, validator *utils.CustomValidator) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+		validator:         validator,
+	}
+}
+
+func (h *MembershipHandler) ListMembers(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.membershipService.ListMembers(c.Request.Context(), restaurantID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListMembersResponse{
+		Memberships:  make([]dto.MembershipResponse, len(memberships.Items)),
+		PageResponse: pageResponse(c, page, memberships),
+	}
+	for i, membership := range memberships.Items {
+		response.Memberships[i] = toMembershipResponse(membership)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *MembershipHandler) UpdateMember(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	var req dto.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	membership, err := h.membershipService.UpdateMemberRole(c.Request.Context(), restaurantID, userID, domain.MembershipRole(req.Role))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMembershipResponse(membership))
+}
+
+func (h *MembershipHandler) RemoveMember(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	if err := h.membershipService.RemoveMember(c.Request.Context(), restaurantID, userID); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "member removed successfully"})
+}
+
+// InviteMember emails an invite to join the restaurant's staff
+func (h *MembershipHandler) InviteMember(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	var req dto.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	inviterID := userID.(int64)
+	invite := &domain.MembershipInvite{
+		RestaurantID: restaurantID,
+		Email:        req.Email,
+		Role:         domain.MembershipRole(req.Role),
+		InvitedBy:    &inviterID,
+	}
+
+	if err := h.membershipService.InviteMember(c.Request.Context(), invite); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toInviteResponse(invite))
+}
+
+func (h *MembershipHandler) ListInvites(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	invites, err := h.membershipService.ListInvites(c.Request.Context(), restaurantID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListInvitesResponse{
+		Invites:      make([]dto.InviteResponse, len(invites.Items)),
+		PageResponse: pageResponse(c, page, invites),
+	}
+	for i, invite := range invites.Items {
+		response.Invites[i] = toInviteResponse(invite)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *MembershipHandler) RevokeInvite(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	inviteID, err := strconv.ParseInt(c.Param("inviteId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid invite id", err))
+		return
+	}
+
+	if err := h.membershipService.RevokeInvite(c.Request.Context(), restaurantID, inviteID); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invite revoked successfully"})
+}
+
+// AcceptInvite joins the signed-in user to the restaurant the emailed token invites them to
+func (h *MembershipHandler) AcceptInvite(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	membership, err := h.membershipService.AcceptInvite(c.Request.Context(), userID.(int64), req.Token)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toMembershipResponse(membership))
+}
+
+// GetUserMemberships lists the restaurants the signed-in user works at
+func (h *MembershipHandler) GetUserMemberships(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	memberships, err := h.membershipService.ListUserMemberships(c.Request.Context(), userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListMembershipsResponse(memberships))
+}
+
+func toListMembershipsResponse(memberships []*domain.Membership) dto.ListMembershipsResponse {
+	response := dto.ListMembershipsResponse{
+		Memberships: make([]dto.MembershipResponse, len(memberships)),
+	}
+	for i, membership := range memberships {
+		response.Memberships[i] = toMembershipResponse(membership)
+	}
+	return response
+}
+
+func toMembershipResponse(membership *domain.Membership) dto.MembershipResponse {
+	return dto.MembershipResponse{
+		RestaurantID:   membership.RestaurantID,
+		RestaurantName: membership.RestaurantName,
+		UserID:         membership.UserID,
+		UserName:       membership.UserName,
+		UserEmail:      membership.UserEmail,
+		Role:           string(membership.Role),
+		CreatedAt:      membership.CreatedAt,
+		UpdatedAt:      membership.UpdatedAt,
+	}
+}
+
+func toInviteResponse(invite *domain.MembershipInvite) dto.InviteResponse {
+	return dto.InviteResponse{
+		ID:           invite.ID,
+		RestaurantID: invite.RestaurantID,
+		Email:        invite.Email,
+		Role:         string(invite.Role),
+		InvitedBy:    invite.InvitedBy,
+		ExpiresAt:    invite.ExpiresAt,
+		Expired:      !invite.ExpiresAt.After(time.Now()),
+		CreatedAt:    invite.CreatedAt,
+	}
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/money"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MenuHandler struct {
+	menuService ports.MenuService
+	validator   *utils.CustomValidator
+}
+
+func NewMenuHandler(menuService ports.MenuService, validator *utils.CustomValidator) *MenuHandler {
+	return &MenuHandler{
+		menuService: menuService,
+		validator:   validator,
+	}
+}
+
+// GetMenu serves the published menu. It can be narrowed to a daypart and to items
+// carrying all of a comma-separated list of dietary flags.
+func (h *MenuHandler) GetMenu(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	filter := domain.MenuFilter{Daypart: c.Query("daypart")}
+	if dietary := c.Query("dietary"); dietary != "" {
+		filter.DietaryFlags = strings.Split(dietary, ",")
+	}
+
+	menu, err := h.menuService.GetMenu(c.Request.Context(), restaurantID, filter)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+func (h *MenuHandler) GetDraft(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	menu, err := h.menuService.GetDraft(c.Request.Context(), restaurantID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+// SaveDraft replaces the draft with the menu in the request
+func (h *MenuHandler) SaveDraft(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	var req dto.MenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	content, err := toMenuContent(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, err.Error(), nil))
+		return
+	}
+
+	menu := &domain.Menu{
+		RestaurantID: restaurantID,
+		Content:      content,
+		UpdatedBy:    userID.(int64),
+	}
+
+	if err := h.menuService.SaveDraft(c.Request.Context(), menu); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+func (h *MenuHandler) Publish(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	menu, err := h.menuService.Publish(c.Request.Context(), restaurantID, userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+func (h *MenuHandler) ListVersions(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	menus, err := h.menuService.ListVersions(c.Request.Context(), restaurantID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListMenuVersionsResponse{
+		Versions:     make([]dto.MenuVersionResponse, len(menus.Items)),
+		PageResponse: pageResponse(c, page, menus),
+	}
+	for i, menu := range menus.Items {
+		response.Versions[i] = dto.MenuVersionResponse{
+			Version:        menu.Version,
+			Status:         string(menu.Status),
+			BasedOnVersion: menu.BasedOnVersion,
+			UpdatedBy:      menu.UpdatedBy,
+			PublishedAt:    menu.PublishedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *MenuHandler) GetVersion(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid menu version", err))
+		return
+	}
+
+	menu, err := h.menuService.GetVersion(c.Request.Context(), restaurantID, version)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+// Rollback publishes an earlier version again, as a new version
+func (h *MenuHandler) Rollback(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid menu version", err))
+		return
+	}
+
+	menu, err := h.menuService.Rollback(c.Request.Context(), restaurantID, version, userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMenuResponse(menu))
+}
+
+func toMenuContent(req *dto.MenuRequest) (domain.MenuContent, error) {
+	content := domain.MenuContent{
+		Currency: strings.ToUpper(req.Currency),
+		Dayparts: make([]domain.Daypart, len(req.Dayparts)),
+		Sections: make([]domain.MenuSection, len(req.Sections)),
+	}
+
+	for i, daypart := range req.Dayparts {
+		content.Dayparts[i] = domain.Daypart(daypart)
+	}
+
+	for i, section := range req.Sections {
+		items := make([]domain.MenuItem, len(section.Items))
+		for j, item := range section.Items {
+			price, err := money.Parse(item.Price, content.Currency)
+			if err != nil {
+				return domain.MenuContent{}, fmt.Errorf("item %q: %w", item.Name, err)
+			}
+
+			items[j] = domain.MenuItem{
+				Name:         item.Name,
+				Description:  item.Description,
+				PriceMinor:   price,
+				DietaryFlags: nonNil(item.DietaryFlags),
+				Allergens:    nonNil(item.Allergens),
+				Dayparts:     nonNil(item.Dayparts),
+			}
+		}
+
+		content.Sections[i] = domain.MenuSection{
+			Name:        section.Name,
+			Description: section.Description,
+			Items:       items,
+		}
+	}
+
+	return content, nil
+}
+
+func toMenuResponse(menu *domain.Menu) dto.MenuResponse {
+	response := dto.MenuResponse{
+		RestaurantID:   menu.RestaurantID,
+		Version:        menu.Version,
+		Status:         string(menu.Status),
+		BasedOnVersion: menu.BasedOnVersion,
+		Currency:       menu.Content.Currency,
+		Dayparts:       make([]dto.Daypart, len(menu.Content.Dayparts)),
+		Sections:       make([]dto.MenuSectionResponse, len(menu.Content.Sections)),
+		UpdatedAt:      menu.UpdatedAt,
+		PublishedAt:    menu.PublishedAt,
+	}
+
+	for i, daypart := range menu.Content.Dayparts {
+		response.Dayparts[i] = dto.Daypart(daypart)
+	}
+
+	for i, section := range menu.Content.Sections {
+		items := make([]dto.MenuItemResponse, len(section.Items))
+		for j, item := range section.Items {
+			items[j] = dto.MenuItemResponse{
+				Name:         item.Name,
+				Description:  item.Description,
+				Price:        money.Format(item.PriceMinor, menu.Content.Currency),
+				PriceMinor:   item.PriceMinor,
+				DietaryFlags: nonNil(item.DietaryFlags),
+				Allergens:    nonNil(item.Allergens),
+				Dayparts:     nonNil(item.Dayparts),
+			}
+		}
+
+		response.Sections[i] = dto.MenuSectionResponse{
+			Name:        section.Name,
+			Description: section.Description,
+			Items:       items,
+		}
+	}
+
+	return response
+}
+
+// nonNil keeps empty lists as [] rather than null in JSON
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
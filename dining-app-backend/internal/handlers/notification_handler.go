@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService ports.NotificationService
+	validator           *utils.CustomValidator
+}
+
+func NewNotificationHandler(notificationService ports.NotificationService, validator *utils.CustomValidator) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		validator:           validator,
+	}
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(c.Request.Context(), userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toNotificationPreferencesResponse(preferences))
+}
+
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.NotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	preferences := &domain.NotificationPreferences{
+		UserID:           userID.(int64),
+		EmailEnabled:     req.EmailEnabled,
+		SMSEnabled:       req.SMSEnabled,
+		RemindersEnabled: req.RemindersEnabled,
+		Phone:            req.Phone,
+		Locale:           req.Locale,
+	}
+
+	if err := h.notificationService.UpdatePreferences(c.Request.Context(), preferences); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toNotificationPreferencesResponse(preferences))
+}
+
+func toNotificationPreferencesResponse(preferences *domain.NotificationPreferences) dto.NotificationPreferencesResponse {
+	return dto.NotificationPreferencesResponse{
+		EmailEnabled:     preferences.EmailEnabled,
+		SMSEnabled:       preferences.SMSEnabled,
+		RemindersEnabled: preferences.RemindersEnabled,
+		Phone:            preferences.Phone,
+		Locale:           preferences.Locale,
+	}
+}
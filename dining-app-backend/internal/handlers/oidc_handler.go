@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	oidcService ports.OIDCService
+	validator   *utils.CustomValidator
+}
+
+func NewOIDCHandler(oidcService ports.OIDCService, validator *utils.CustomValidator) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		validator:   validator,
+	}
+}
+
+// ListProviders lists the identity providers users can sign in with
+func (h *OIDCHandler) ListProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.oidcService.ListProviders(c.Request.Context())})
+}
+
+// StartLogin returns the provider's page to send the user to. The provider sends them
+// back to the configured redirect URL with a code and state for Callback.
+func (h *OIDCHandler) StartLogin(c *gin.Context) {
+	authorizationURL, err := h.oidcService.StartLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OIDCStartResponse{AuthorizationURL: authorizationURL})
+}
+
+// Callback finishes the sign-in and responds like Login
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	tokens, user, err := h.oidcService.FinishLogin(c.Request.Context(), c.Param("provider"), req.Code, req.State, requestDevice(c))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponse{
+		TokenResponse: toTokenResponse(tokens),
+		User:          toUserProfile(user),
+	})
+}
+
+// ListIdentities lists the provider accounts linked to the user
+func (h *OIDCHandler) ListIdentities(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	identities, err := h.oidcService.ListIdentities(c.Request.Context(), userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListIdentitiesResponse{
+		Identities: make([]dto.IdentityResponse, len(identities)),
+	}
+	for i, identity := range identities {
+		response.Identities[i] = dto.IdentityResponse{
+			ID:          identity.ID,
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			CreatedAt:   identity.CreatedAt,
+			LastLoginAt: identity.LastLoginAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Lists that grow without bound are paged with bindPage and pageResponse. A few lists
+// are returned whole because their size is bounded: OIDC providers and roles, a user's
+// linked identities and the restaurants they work at, a restaurant's gallery (capped
+// by media.maxGalleryImages), deals and webhook endpoints, which staff keep by hand, a
+// day's availability and bookings at a restaurant, a series' occurrences (capped by
+// MaxRecurrenceOccurrences), and an API key's usage (at most 24 months).
+
+// bindPage reads the page from the query string. On failure it has already answered
+// the request with 400.
+func bindPage(c *gin.Context, validator *utils.CustomValidator, defaultSize int) (domain.PageRequest, bool) {
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": validator.FormatValidationErrors(err),
+		})
+		return domain.PageRequest{}, false
+	}
+
+	page, err := pageRequest(query, defaultSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return domain.PageRequest{}, false
+	}
+	return page, true
+}
+
+func pageRequest(query dto.PageQuery, defaultSize int) (domain.PageRequest, *apperrors.Error) {
+	if query.PageSize == 0 {
+		query.PageSize = defaultSize
+	}
+	page := domain.PageRequest{Limit: query.PageSize}
+
+	if query.Cursor != "" {
+		if query.Page != 0 {
+			return page, apperrors.NewError(apperrors.ErrorTypeValidation, "page and cursor can't be combined", nil)
+		}
+		cursor, err := domain.ParseCursor(query.Cursor)
+		if err != nil {
+			return page, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid cursor", err)
+		}
+		page.Cursor = cursor
+		return page, nil
+	}
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	page.Offset = (query.Page - 1) * query.PageSize
+	return page, nil
+}
+
+// pageResponse describes the page for the response body and links its neighbours in
+// the Link header
+func pageResponse[T any](c *gin.Context, request domain.PageRequest, page *domain.Page[T]) dto.PageResponse {
+	response := dto.PageResponse{
+		PageSize: request.Limit,
+		Total:    page.Total,
+		HasMore:  page.HasMore,
+	}
+	if page.NextCursor != nil {
+		response.NextCursor = page.NextCursor.Encode()
+	}
+
+	var links []string
+	if request.Cursor == nil {
+		response.Page = request.Offset/request.Limit + 1
+
+		links = append(links, pageLink(c, "first", "page", "1"))
+		if response.Page > 1 {
+			links = append(links, pageLink(c, "prev", "page", strconv.Itoa(response.Page-1)))
+		}
+		if page.HasMore {
+			links = append(links, pageLink(c, "next", "page", strconv.Itoa(response.Page+1)))
+		}
+		if page.Total != nil && *page.Total > 0 {
+			last := (*page.Total + request.Limit - 1) / request.Limit
+			links = append(links, pageLink(c, "last", "page", strconv.Itoa(last)))
+		}
+	} else if response.NextCursor != "" {
+		links = append(links, pageLink(c, "next", "cursor", response.NextCursor))
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+	return response
+}
+
+// pageLink links to the current URL with one page parameter replaced, dropping the other
+func pageLink(c *gin.Context, rel, param, value string) string {
+	query := c.Request.URL.Query()
+	query.Del("page")
+	query.Del("cursor")
+	query.Set(param, value)
+
+	url := *c.Request.URL
+	url.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, url.RequestURI(), rel)
+}
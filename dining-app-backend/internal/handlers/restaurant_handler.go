@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
@@ -12,6 +14,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const defaultRestaurantPageSize = 10
+
 type RestaurantHandler struct {
 	restaurantService ports.RestaurantService
 	validator         *utils.CustomValidator
@@ -40,6 +44,9 @@ func (h *RestaurantHandler) Create(c *gin.Context) {
 		CuisineType: req.CuisineType,
 		OpeningTime: req.OpeningTime,
 		ClosingTime: req.ClosingTime,
+		Timezone:    req.Timezone,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
 	}
 
 	if err := h.validator.Validate(restaurant); err != nil {
@@ -75,11 +82,44 @@ func (h *RestaurantHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, toRestaurantResponse(restaurant))
 }
 
+// List searches restaurants by free text, cuisine, opening hours and distance
 func (h *RestaurantHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	var req dto.SearchRestaurantsQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	page, appErr := pageRequest(req.PageQuery, defaultRestaurantPageSize)
+	if appErr != nil {
+		c.JSON(http.StatusBadRequest, appErr)
+		return
+	}
+
+	search := domain.RestaurantSearch{
+		Query:   req.Query,
+		OpenNow: req.OpenNow,
+		Sort:    domain.RestaurantSort(req.Sort),
+		Page:    page,
+	}
+	for _, cuisine := range strings.Split(req.Cuisine, ",") {
+		if cuisine = strings.TrimSpace(cuisine); cuisine != "" {
+			search.Cuisines = append(search.Cuisines, cuisine)
+		}
+	}
+	if req.Near != "" {
+		near, err := domain.ParseGeoPoint(req.Near)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid near: "+err.Error(), err))
+			return
+		}
+		search.Near = &near
+		search.RadiusKm = req.RadiusKm
+	}
 
-	restaurants, err := h.restaurantService.List(c.Request.Context(), page, pageSize)
+	results, err := h.restaurantService.Search(c.Request.Context(), search)
 	if err != nil {
 		appErr := err.(*apperrors.Error)
 		c.JSON(apperrors.GetStatusCode(appErr), appErr)
@@ -87,13 +127,24 @@ func (h *RestaurantHandler) List(c *gin.Context) {
 	}
 
 	response := dto.ListRestaurantsResponse{
-		Restaurants: make([]dto.RestaurantResponse, len(restaurants)),
-		Page:        page,
-		PageSize:    pageSize,
-	}
-
-	for i, restaurant := range restaurants {
-		response.Restaurants[i] = toRestaurantResponse(restaurant)
+		Restaurants:  make([]dto.RestaurantResponse, len(results.Items)),
+		PageResponse: pageResponse(c, page, results),
+	}
+
+	for i, result := range results.Items {
+		restaurant := toRestaurantResponse(&result.Restaurant)
+		if search.Query != "" {
+			restaurant.Relevance = result.Rank
+			restaurant.Highlights = &dto.RestaurantHighlights{
+				Name:        result.NameHighlight,
+				Description: result.DescriptionHighlight,
+			}
+		}
+		if result.DistanceKm != nil {
+			distance := math.Round(*result.DistanceKm*100) / 100
+			restaurant.DistanceKm = &distance
+		}
+		response.Restaurants[i] = restaurant
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -122,6 +173,9 @@ func (h *RestaurantHandler) Update(c *gin.Context) {
 		CuisineType: req.CuisineType,
 		OpeningTime: req.OpeningTime,
 		ClosingTime: req.ClosingTime,
+		Timezone:    req.Timezone,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
 	}
 
 	if err := h.validator.Validate(restaurant); err != nil {
@@ -162,14 +216,23 @@ func toRestaurantResponse(restaurant *domain.Restaurant) dto.RestaurantResponse
 		tables[i] = toTableResponse(table)
 	}
 
+	images := toRestaurantImagesResponse(restaurant.Images)
+
 	return dto.RestaurantResponse{
-		ID:          restaurant.ID,
-		Name:        restaurant.Name,
-		Description: restaurant.Description,
-		Address:     restaurant.Address,
-		CuisineType: restaurant.CuisineType,
-		OpeningTime: restaurant.OpeningTime,
-		ClosingTime: restaurant.ClosingTime,
-		Tables:      tables,
+		ID:            restaurant.ID,
+		Name:          restaurant.Name,
+		Description:   restaurant.Description,
+		Address:       restaurant.Address,
+		CuisineType:   restaurant.CuisineType,
+		OpeningTime:   restaurant.OpeningTime,
+		ClosingTime:   restaurant.ClosingTime,
+		Timezone:      restaurant.Timezone,
+		Latitude:      restaurant.Latitude,
+		Longitude:     restaurant.Longitude,
+		Tables:        tables,
+		CoverImage:    images.Cover,
+		Gallery:       images.Gallery,
+		AverageRating: restaurant.AverageRating,
+		ReviewCount:   restaurant.ReviewCount,
 	}
 }
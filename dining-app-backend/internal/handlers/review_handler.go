@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultReviewPageSize = 10
+
+type ReviewHandler struct {
+	reviewService ports.ReviewService
+	validator     *utils.CustomValidator
+}
+
+func NewReviewHandler(reviewService ports.ReviewService, validator *utils.CustomValidator) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		validator:     validator,
+	}
+}
+
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	review := &domain.Review{
+		RestaurantID: restaurantID,
+		UserID:       userID.(int64),
+		BookingID:    req.BookingID,
+		Rating:       req.Rating,
+		Comment:      req.Comment,
+	}
+
+	if err := h.reviewService.CreateReview(c.Request.Context(), review); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReviewResponse(review))
+}
+
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, defaultReviewPageSize)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.reviewService.ListRestaurantReviews(c.Request.Context(), restaurantID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListReviewsResponse{
+		Reviews:      make([]dto.ReviewResponse, len(reviews.Items)),
+		PageResponse: pageResponse(c, page, reviews),
+	}
+
+	for i, review := range reviews.Items {
+		response.Reviews[i] = toReviewResponse(review)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid review id", err))
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	review := &domain.Review{
+		ID:      reviewID,
+		UserID:  userID.(int64),
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	}
+
+	if err := h.reviewService.UpdateReview(c.Request.Context(), review); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReviewResponse(review))
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid review id", err))
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Request.Context(), reviewID, userID.(int64)); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
+}
+
+// SetVisibility lets admins hide an abusive review or restore it
+func (h *ReviewHandler) SetVisibility(c *gin.Context) {
+	reviewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid review id", err))
+		return
+	}
+
+	var req dto.ReviewVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	review, err := h.reviewService.SetReviewHidden(c.Request.Context(), reviewID, req.Hidden, req.Reason)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReviewResponse(review))
+}
+
+func toReviewResponse(review *domain.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:           review.ID,
+		RestaurantID: review.RestaurantID,
+		BookingID:    review.BookingID,
+		UserName:     review.UserName,
+		Rating:       review.Rating,
+		Comment:      review.Comment,
+		Hidden:       review.Hidden,
+		HiddenReason: review.HiddenReason,
+		CreatedAt:    review.CreatedAt,
+		UpdatedAt:    review.UpdatedAt,
+	}
+}
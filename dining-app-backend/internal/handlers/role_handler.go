@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleService ports.RoleService
+	validator   *utils.CustomValidator
+}
+
+func NewRoleHandler(roleService ports.RoleService, validator *utils.CustomValidator) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		validator:   validator,
+	}
+}
+
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListRolesResponse{
+		Roles:       make([]dto.RoleResponse, len(roles)),
+		Permissions: permissionStrings(domain.Permissions),
+	}
+	for i, role := range roles {
+		response.Roles[i] = toRoleResponse(role)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	role, err := h.roleService.GetRole(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRoleResponse(role))
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: toPermissions(req.Permissions),
+	}
+
+	if err := h.roleService.CreateRole(c.Request.Context(), role); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRoleResponse(role))
+}
+
+// UpdateRole replaces the role's description and permissions
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	role := &domain.Role{
+		Name:        c.Param("name"),
+		Description: req.Description,
+		Permissions: toPermissions(req.Permissions),
+	}
+
+	if err := h.roleService.UpdateRole(c.Request.Context(), role); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRoleResponse(role))
+}
+
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	if err := h.roleService.DeleteRole(c.Request.Context(), c.Param("name")); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role deleted successfully"})
+}
+
+// AssignRole changes a user's role, effective on their next request
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	actorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.roleService.AssignRole(c.Request.Context(), actorID.(int64), userID, req.Role); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role assigned successfully"})
+}
+
+// ListRoleChanges returns the audit trail of a user's roles
+func (h *RoleHandler) ListRoleChanges(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid user id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	changes, err := h.roleService.ListRoleChanges(c.Request.Context(), userID, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListRoleChangesResponse{
+		Changes:      make([]dto.RoleChangeResponse, len(changes.Items)),
+		PageResponse: pageResponse(c, page, changes),
+	}
+	for i, change := range changes.Items {
+		response.Changes[i] = dto.RoleChangeResponse{
+			ID:        change.ID,
+			UserID:    change.UserID,
+			OldRole:   change.OldRole,
+			NewRole:   change.NewRole,
+			ChangedBy: change.ChangedBy,
+			Source:    string(change.Source),
+			CreatedAt: change.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// InviteToRole emails a single-use link that gives the invitee a role
+func (h *RoleHandler) InviteToRole(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.InviteToRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	inviterID := userID.(int64)
+	invite := &domain.RoleInvite{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: &inviterID,
+	}
+
+	if err := h.roleService.InviteToRole(c.Request.Context(), invite); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRoleInviteResponse(invite))
+}
+
+func (h *RoleHandler) ListRoleInvites(c *gin.Context) {
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	invites, err := h.roleService.ListRoleInvites(c.Request.Context(), page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListRoleInvitesResponse{
+		Invites:      make([]dto.RoleInviteResponse, len(invites.Items)),
+		PageResponse: pageResponse(c, page, invites),
+	}
+	for i, invite := range invites.Items {
+		response.Invites[i] = toRoleInviteResponse(invite)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *RoleHandler) RevokeRoleInvite(c *gin.Context) {
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid invite id", err))
+		return
+	}
+
+	if err := h.roleService.RevokeRoleInvite(c.Request.Context(), inviteID); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invite revoked successfully"})
+}
+
+// AcceptRoleInvite gives the signed-in user the role the emailed token invites them to
+func (h *RoleHandler) AcceptRoleInvite(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	role, err := h.roleService.AcceptRoleInvite(c.Request.Context(), userID.(int64), req.Token)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserPermissionsResponse{
+		Role:        role.Name,
+		Permissions: permissionStrings(role.Permissions),
+	})
+}
+
+// GetUserPermissions returns what the signed-in user's current role allows
+func (h *RoleHandler) GetUserPermissions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	role, err := h.roleService.GetUserRole(c.Request.Context(), userID.(int64))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserPermissionsResponse{
+		Role:        role.Name,
+		Permissions: permissionStrings(role.Permissions),
+	})
+}
+
+func toRoleResponse(role *domain.Role) dto.RoleResponse {
+	return dto.RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissionStrings(role.Permissions),
+		System:      role.System,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func toRoleInviteResponse(invite *domain.RoleInvite) dto.RoleInviteResponse {
+	return dto.RoleInviteResponse{
+		ID:        invite.ID,
+		Email:     invite.Email,
+		Role:      invite.Role,
+		InvitedBy: invite.InvitedBy,
+		ExpiresAt: invite.ExpiresAt,
+		Expired:   !invite.ExpiresAt.After(time.Now()),
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+func toPermissions(values []string) []domain.Permission {
+	permissions := make([]domain.Permission, len(values))
+	for i, value := range values {
+		permissions[i] = domain.Permission(value)
+	}
+	return permissions
+}
+
+func permissionStrings(permissions []domain.Permission) []string {
+	values := make([]string, len(permissions))
+	for i, permission := range permissions {
+		values[i] = string(permission)
+	}
+	return values
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService ports.SessionService
+	validator      *utils.CustomValidator
+}
+
+func NewSessionHandler(sessionService ports.SessionService, validator *utils.CustomValidator) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		validator:      validator,
+	}
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(c.Request.Context(), req.RefreshToken, requestDevice(c))
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTokenResponse(tokens))
+}
+
+// Logout revokes the session the access token belongs to
+func (h *SessionHandler) Logout(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID.(int64), c.GetInt64("sessionID")); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// LogoutAll revokes every session of the user, including the current one
+func (h *SessionHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	if err := h.sessionService.RevokeAllSessions(c.Request.Context(), userID.(int64), 0); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out of all sessions successfully"})
+}
+
+// ListSessions lists the devices the user is signed in on
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID.(int64), page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	current := c.GetInt64("sessionID")
+	response := dto.ListSessionsResponse{
+		Sessions:     make([]dto.SessionResponse, len(sessions.Items)),
+		PageResponse: pageResponse(c, page, sessions),
+	}
+	for i, session := range sessions.Items {
+		response.Sessions[i] = dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == current,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeSession signs one of the user's devices out
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid session id", err))
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID.(int64), sessionID); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked successfully"})
+}
+
+// requestDevice describes the device a request came from, for its session. The login
+// guard counts failures per IPAddress, so it must be ClientIP, which only believes
+// X-Forwarded-For from server.trustedProxies; a client can't spoof a fresh IP.
+func requestDevice(c *gin.Context) *domain.Session {
+	return &domain.Session{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+func toTokenResponse(tokens *domain.TokenPair) dto.TokenResponse {
+	return dto.TokenResponse{
+		Token:                 tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int64(time.Until(tokens.AccessTokenExpiresAt).Round(time.Second).Seconds()),
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		SessionID:             tokens.SessionID,
+	}
+}
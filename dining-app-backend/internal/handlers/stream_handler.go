@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+// StreamHandler serves Server-Sent Events. Each event carries its outbox ID, so a
+// reconnecting EventSource resumes where it left off through Last-Event-ID.
+type StreamHandler struct {
+	streamService ports.StreamService
+	config        config.StreamConfig
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func NewStreamHandler(streamService ports.StreamService, config config.StreamConfig) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+		config:        config,
+		closed:        make(chan struct{}),
+	}
+}
+
+// Close ends every open stream, so a shutting down server isn't kept waiting by them.
+// Clients reconnect to another replica and resume from their last event.
+func (h *StreamHandler) Close() {
+	h.closeOnce.Do(func() {
+		close(h.closed)
+	})
+}
+
+func (h *StreamHandler) Availability(c *gin.Context) {
+	restaurantID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid restaurant id", err))
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid last event id", err))
+		return
+	}
+
+	stream, err := h.streamService.StreamAvailability(c.Request.Context(), restaurantID, lastEventID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	h.serve(c, stream)
+}
+
+func (h *StreamHandler) Bookings(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid last event id", err))
+		return
+	}
+
+	stream, err := h.streamService.StreamBookings(c.Request.Context(), userID.(int64), lastEventID)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	h.serve(c, stream)
+}
+
+// serve writes the stream until the client goes away or the stream is closed for
+// falling behind, in which case the client reconnects and resumes
+func (h *StreamHandler) serve(c *gin.Context, stream *domain.EventStream) {
+	// Streams stay open far longer than the server's write timeout, so they're exempt.
+	// Were clearing it to fail, the client would reconnect and resume when cut off.
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", h.config.RetryMillis)
+
+	if stream.Reset {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+
+	replayed := make(map[int64]bool, len(stream.Replay))
+	for _, event := range stream.Replay {
+		writeEvent(w, event)
+		replayed[event.ID] = true
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(time.Duration(h.config.HeartbeatSeconds) * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.closed:
+			return
+		case event, ok := <-stream.Events:
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			writeEvent(w, event)
+			w.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			w.Flush()
+		}
+	}
+}
+
+// writeEvent writes one SSE message. Payloads are compact JSON, so they fit on a
+// single data line.
+func writeEvent(w gin.ResponseWriter, event *domain.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+}
+
+// parseLastEventID reads the header sent by a reconnecting EventSource, falling back to
+// a query parameter for clients that cannot set headers on the first connection
+func parseLastEventID(c *gin.Context) (int64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
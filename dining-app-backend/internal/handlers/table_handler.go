@@ -67,16 +67,24 @@ func (h *TableHandler) GetRestaurantTables(c *gin.Context) {
 		return
 	}
 
-	tables, err := h.tableService.GetRestaurantTables(c.Request.Context(), restaurantID)
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	tables, err := h.tableService.GetRestaurantTables(c.Request.Context(), restaurantID, page)
 	if err != nil {
 		appErr := err.(*apperrors.Error)
 		c.JSON(apperrors.GetStatusCode(appErr), appErr)
 		return
 	}
 
-	response := make([]dto.TableResponse, len(tables))
-	for i, table := range tables {
-		response[i] = toTableResponse(table)
+	response := dto.ListTablesResponse{
+		Tables:       make([]dto.TableResponse, len(tables.Items)),
+		PageResponse: pageResponse(c, page, tables),
+	}
+	for i, table := range tables.Items {
+		response.Tables[i] = toTableResponse(table)
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -35,19 +35,21 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
-	// Log the incoming request payload
-	h.logger.Info("Received registration request", zap.Any("request", req))
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	h.logger.Info("Received registration request", zap.String("email", req.Email))
 
 	user := &domain.User{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     req.Role, // Use the role from request
 	}
 
-	// Log the extracted role
-	h.logger.Info("Extracted role from request", zap.String("role", user.Role))
-
 	if err := h.validator.Validate(user); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"errors": h.validator.FormatValidationErrors(err),
@@ -70,12 +72,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "user registered successfully",
-		"user": dto.UserProfile{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
+		"user":    toUserProfile(user),
 	})
 }
 
@@ -88,7 +85,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.userService.Login(c.Request.Context(), req.Email, req.Password)
+	tokens, user, err := h.userService.Login(c.Request.Context(), req.Email, req.Password, requestDevice(c))
 	if err != nil {
 		if appErr, ok := err.(*apperrors.Error); ok {
 			c.JSON(apperrors.GetStatusCode(appErr), appErr)
@@ -103,13 +100,8 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, dto.LoginResponse{
-		Token: token,
-		User: dto.UserProfile{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
+		TokenResponse: toTokenResponse(tokens),
+		User:          toUserProfile(user),
 	})
 }
 
@@ -134,12 +126,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.UserProfile{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
-	})
+	c.JSON(http.StatusOK, toUserProfile(user))
 }
 
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
@@ -201,11 +188,99 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "profile updated successfully",
-		"user": dto.UserProfile{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
+		"user":    toUserProfile(user),
+	})
+}
+
+// ForgotPassword emails a reset link when the account exists. The response is the same
+// either way.
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.userService.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "if an account exists for this email, a password reset link has been sent",
 	})
 }
+
+// ResetPassword sets a new password with the emailed token
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully; please log in again"})
+}
+
+// ChangePassword sets a new password after checking the current one. Other sessions are
+// signed out.
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	err := h.userService.ChangePassword(c.Request.Context(), userID.(int64), c.GetInt64("sessionID"), req.CurrentPassword, req.NewPassword, c.ClientIP())
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password changed successfully"})
+}
+
+func toUserProfile(user *domain.User) dto.UserProfile {
+	return dto.UserProfile{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		PendingEmail:  user.PendingEmail,
+		Role:          user.Role,
+	}
+}
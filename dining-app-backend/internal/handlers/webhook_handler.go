@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/handlers/dto"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService ports.WebhookService
+	validator      *utils.CustomValidator
+}
+
+func NewWebhookHandler(webhookService ports.WebhookService, validator *utils.CustomValidator) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validator:      validator,
+	}
+}
+
+// CreateEndpoint registers an endpoint. The signing secret is only shown in this response.
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var req dto.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	endpoint := toWebhookEndpoint(&req)
+
+	if err := h.webhookService.CreateEndpoint(c.Request.Context(), endpoint); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := toWebhookEndpointResponse(endpoint)
+	response.Secret = endpoint.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context())
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := make([]dto.WebhookEndpointResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		response[i] = toWebhookEndpointResponse(endpoint)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid webhook id", err))
+		return
+	}
+
+	var req dto.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": h.validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	endpoint := toWebhookEndpoint(&req)
+	endpoint.ID = id
+
+	if err := h.webhookService.UpdateEndpoint(c.Request.Context(), endpoint); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookEndpointResponse(endpoint))
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid webhook id", err))
+		return
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted successfully"})
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid webhook id", err))
+		return
+	}
+
+	page, ok := bindPage(c, h.validator, domain.DefaultPageSize)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), id, page)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	response := dto.ListWebhookDeliveriesResponse{
+		Deliveries:   make([]dto.WebhookDeliveryResponse, len(deliveries.Items)),
+		PageResponse: pageResponse(c, page, deliveries),
+	}
+	for i, delivery := range deliveries.Items {
+		response.Deliveries[i] = toWebhookDeliveryResponse(delivery)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid delivery id", err))
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		appErr := err.(*apperrors.Error)
+		c.JSON(apperrors.GetStatusCode(appErr), appErr)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toWebhookDeliveryResponse(delivery))
+}
+
+func toWebhookEndpoint(req *dto.WebhookEndpointRequest) *domain.WebhookEndpoint {
+	events := make([]domain.WebhookEventType, len(req.Events))
+	for i, event := range req.Events {
+		events[i] = domain.WebhookEventType(event)
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &domain.WebhookEndpoint{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      events,
+		Active:      active,
+	}
+}
+
+func toWebhookEndpointResponse(endpoint *domain.WebhookEndpoint) dto.WebhookEndpointResponse {
+	events := make([]string, len(endpoint.Events))
+	for i, event := range endpoint.Events {
+		events[i] = string(event)
+	}
+
+	return dto.WebhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      events,
+		Active:      endpoint.Active,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *domain.WebhookDelivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Redelivery:     delivery.Redelivery,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -13,9 +13,9 @@ type PostgresDB struct {
     *sqlx.DB
 }
 
-// NewPostgresDB creates a new PostgreSQL database connection
-func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
-    dsn := fmt.Sprintf(
+// DSN builds the connection string for the configured database
+func DSN(cfg config.DatabaseConfig) string {
+    return fmt.Sprintf(
         "host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
         cfg.Host,
         cfg.Port,
@@ -24,8 +24,11 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
         cfg.DBName,
         cfg.SSLMode,
     )
+}
 
-    db, err := sqlx.Connect("postgres", dsn)
+// NewPostgresDB creates a new PostgreSQL database connection
+func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
+    db, err := sqlx.Connect("postgres", DSN(cfg))
     if err != nil {
         return nil, fmt.Errorf("error connecting to the database: %w", err)
     }
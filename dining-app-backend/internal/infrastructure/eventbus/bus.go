@@ -0,0 +1,73 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Bus is an in-process EventBus. Handlers run synchronously in registration order.
+type Bus struct {
+	mu         sync.RWMutex
+	handlers   map[domain.EventType][]ports.EventHandler
+	broadcasts map[domain.EventType][]ports.EventHandler
+	logger     *logger.Logger
+}
+
+func NewBus(logger *logger.Logger) *Bus {
+	return &Bus{
+		handlers:   make(map[domain.EventType][]ports.EventHandler),
+		broadcasts: make(map[domain.EventType][]ports.EventHandler),
+		logger:     logger,
+	}
+}
+
+func (b *Bus) Subscribe(eventType domain.EventType, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *Bus) SubscribeBroadcast(eventType domain.EventType, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.broadcasts[eventType] = append(b.broadcasts[eventType], handler)
+}
+
+// Dispatch runs every handler subscribed to the event, even if some fail, and returns
+// their combined errors
+func (b *Bus) Dispatch(ctx context.Context, event *domain.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Broadcast runs every broadcast handler subscribed to the event, logging failures
+func (b *Bus) Broadcast(ctx context.Context, event *domain.Event) {
+	b.mu.RLock()
+	handlers := b.broadcasts[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			b.logger.Error("Broadcast event handler failed",
+				zap.Int64("eventID", event.ID),
+				zap.String("eventType", string(event.Type)),
+				zap.Error(err),
+			)
+		}
+	}
+}
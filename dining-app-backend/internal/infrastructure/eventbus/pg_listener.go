@@ -0,0 +1,89 @@
+package eventbus
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// PGListener feeds events published with Postgres NOTIFY, by this or any other
+// replica, to the bus's broadcast subscribers. Notifications carry only the event's
+// outbox ID, and the event is loaded from the outbox.
+type PGListener struct {
+	dsn        string
+	channel    string
+	outboxRepo ports.OutboxRepository
+	bus        *Bus
+	logger     *logger.Logger
+}
+
+func NewPGListener(dsn, channel string, outboxRepo ports.OutboxRepository, bus *Bus, logger *logger.Logger) *PGListener {
+	return &PGListener{
+		dsn:        dsn,
+		channel:    channel,
+		outboxRepo: outboxRepo,
+		bus:        bus,
+		logger:     logger,
+	}
+}
+
+// Run listens until ctx is done, reconnecting after connection loss. Events sent
+// while disconnected are missed; broadcast subscribers only hold replica-local state.
+func (l *PGListener) Run(ctx context.Context) {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			l.logger.Error("Event listener disconnected", zap.Error(err))
+		case pq.ListenerEventReconnected:
+			l.logger.Info("Event listener reconnected")
+		case pq.ListenerEventConnectionAttemptFailed:
+			l.logger.Error("Event listener failed to connect", zap.Error(err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(l.channel); err != nil {
+		l.logger.Error("Failed to listen for events",
+			zap.String("channel", l.channel),
+			zap.Error(err),
+		)
+		return
+	}
+
+	// Ping periodically so a silently dropped connection is noticed
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			go listener.Ping()
+		case n := <-listener.Notify:
+			// A nil notification signals a reconnect
+			if n == nil {
+				continue
+			}
+
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				l.logger.Error("Failed to decode event notification", zap.Error(err))
+				continue
+			}
+
+			event, err := l.outboxRepo.GetByID(ctx, id)
+			if err != nil {
+				l.logger.Error("Failed to load notified event", zap.Int64("eventID", id), zap.Error(err))
+				continue
+			}
+
+			l.bus.Broadcast(ctx, event)
+		}
+	}
+}
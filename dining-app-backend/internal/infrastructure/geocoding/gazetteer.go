@@ -0,0 +1,98 @@
+package geocoding
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+//go:embed gazetteer.csv
+var gazetteerCSV []byte
+
+type place struct {
+	name  string
+	point domain.GeoPoint
+}
+
+// Gazetteer geocodes addresses offline against the bundled list of places. It finds
+// the area a restaurant is in rather than its street address.
+type Gazetteer struct {
+	places []place
+}
+
+func NewGazetteer() (*Gazetteer, error) {
+	gazetteer := &Gazetteer{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(gazetteerCSV))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("gazetteer line %d: expected name,latitude,longitude", line)
+		}
+
+		lat, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: %w", line, err)
+		}
+		lng, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: %w", line, err)
+		}
+
+		gazetteer.places = append(gazetteer.places, place{
+			name:  normalize(fields[0]),
+			point: domain.GeoPoint{Latitude: lat, Longitude: lng},
+		})
+	}
+
+	return gazetteer, scanner.Err()
+}
+
+// Geocode returns the coordinates of the first place named in the address, after the
+// street line. Addresses run from specific to general, so that is the most precise.
+func (g *Gazetteer) Geocode(ctx context.Context, address string) (*domain.GeoPoint, error) {
+	components := strings.Split(address, ",")
+	if len(components) > 1 {
+		components = components[1:]
+	}
+	text := " " + normalize(strings.Join(components, " ")) + " "
+
+	var best *place
+	bestAt := -1
+	for i := range g.places {
+		candidate := &g.places[i]
+		at := strings.Index(text, " "+candidate.name+" ")
+		if at < 0 {
+			continue
+		}
+		if best == nil || at < bestAt || (at == bestAt && len(candidate.name) > len(best.name)) {
+			best, bestAt = candidate, at
+		}
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+	point := best.point
+	return &point, nil
+}
+
+// normalize lowercases the text and reduces it to words separated by single spaces
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
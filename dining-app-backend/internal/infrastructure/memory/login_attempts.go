@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// LoginAttemptStore keeps failed logins in process memory. The counts are lost on
+// restart and aren't shared between instances, so it only suits a single replica.
+type LoginAttemptStore struct {
+	mu        sync.Mutex
+	attempts  map[string]*domain.LoginAttempts
+	lastSweep time.Time
+}
+
+func NewLoginAttemptStore() *LoginAttemptStore {
+	return &LoginAttemptStore{
+		attempts: make(map[string]*domain.LoginAttempts),
+	}
+}
+
+func (s *LoginAttemptStore) Get(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempts
+	return &copied, nil
+}
+
+func (s *LoginAttemptStore) RecordFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (*domain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now, policy.Window)
+
+	attempts, ok := s.attempts[key]
+	if !ok || expired(attempts, now, policy.Window) {
+		attempts = &domain.LoginAttempts{Key: key}
+		s.attempts[key] = attempts
+	}
+
+	attempts.Failures++
+	attempts.LastFailureAt = now
+	if attempts.Failures >= policy.MaxFailures && !attempts.Locked(now) {
+		lockedUntil := now.Add(policy.Lockout)
+		attempts.LockedUntil = &lockedUntil
+	}
+
+	copied := *attempts
+	return &copied, nil
+}
+
+func (s *LoginAttemptStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// sweep drops the keys whose failures are forgotten, at most once per window, so
+// attempts against many accounts or from many addresses don't pile up
+func (s *LoginAttemptStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+	s.lastSweep = now
+
+	for key, attempts := range s.attempts {
+		if expired(attempts, now, window) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// expired reports whether the failures no longer count: the last one is outside the
+// window, or they led to a lockout that has ended
+func expired(attempts *domain.LoginAttempts, now time.Time, window time.Duration) bool {
+	if attempts.LockedUntil != nil {
+		return !attempts.Locked(now)
+	}
+	return now.Sub(attempts.LastFailureAt) > window
+}
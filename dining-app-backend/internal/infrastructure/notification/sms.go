@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// SMSNotifier adapts an SMS gateway to the Notifier port
+type SMSNotifier struct {
+	provider ports.SMSProvider
+}
+
+func NewSMSNotifier(provider ports.SMSProvider) *SMSNotifier {
+	return &SMSNotifier{
+		provider: provider,
+	}
+}
+
+func (n *SMSNotifier) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelSMS
+}
+
+func (n *SMSNotifier) Send(ctx context.Context, notification *domain.Notification) error {
+	return n.provider.SendSMS(ctx, notification.Recipient, notification.Body)
+}
+
+// LogSMSProvider is a stub gateway that only logs messages
+type LogSMSProvider struct {
+	logger *logger.Logger
+}
+
+func NewLogSMSProvider(logger *logger.Logger) *LogSMSProvider {
+	return &LogSMSProvider{
+		logger: logger,
+	}
+}
+
+func (p *LogSMSProvider) SendSMS(ctx context.Context, to, body string) error {
+	p.logger.Info("SMS sent (log provider)",
+		zap.String("to", to),
+		zap.String("body", body),
+	)
+	return nil
+}
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+// SMTPNotifier sends email through a plain SMTP relay. Any local mail catcher such as
+// MailHog can be used in development.
+type SMTPNotifier struct {
+	config    config.SMTPConfig
+	messageID string
+}
+
+func NewSMTPNotifier(config config.SMTPConfig, messageIDDomain string) *SMTPNotifier {
+	return &SMTPNotifier{
+		config:    config,
+		messageID: messageIDDomain,
+	}
+}
+
+func (n *SMTPNotifier) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelEmail
+}
+
+func (n *SMTPNotifier) Send(ctx context.Context, notification *domain.Notification) error {
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	// A stable Message-ID lets mail clients drop a copy resent after a lost acknowledgement
+	headers := []string{
+		"From: " + n.config.From,
+		"To: " + notification.Recipient,
+		"Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <notification-%d@%s>", notification.ID, n.messageID),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(notification.Body, "\n", "\r\n")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- smtp.SendMail(addr, auth, n.config.From, []string{notification.Recipient}, []byte(message))
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// TemplateRenderer renders the embedded templates/<locale>/<kind>.tmpl files. Each file
+// defines "subject", "body" and "sms" templates.
+type TemplateRenderer struct {
+	defaultLocale string
+	templates     map[string]map[domain.NotificationKind]*template.Template
+}
+
+func NewTemplateRenderer(defaultLocale string) (*TemplateRenderer, error) {
+	renderer := &TemplateRenderer{
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]map[domain.NotificationKind]*template.Template),
+	}
+
+	err := fs.WalkDir(templateFS, "templates", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		locale := path.Base(path.Dir(file))
+		kind := domain.NotificationKind(strings.TrimSuffix(path.Base(file), ".tmpl"))
+
+		tmpl, err := template.ParseFS(templateFS, file)
+		if err != nil {
+			return fmt.Errorf("parsing template %s: %w", file, err)
+		}
+
+		if renderer.templates[locale] == nil {
+			renderer.templates[locale] = make(map[domain.NotificationKind]*template.Template)
+		}
+		renderer.templates[locale][kind] = tmpl
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := renderer.templates[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+
+	return renderer, nil
+}
+
+// Render uses the requested locale, then its base language, then the default locale
+func (r *TemplateRenderer) Render(kind domain.NotificationKind, locale string, data interface{}) (*domain.MessageContent, error) {
+	tmpl := r.lookup(kind, locale)
+	if tmpl == nil {
+		return nil, fmt.Errorf("no template for notification %q", kind)
+	}
+
+	content := &domain.MessageContent{}
+	for name, target := range map[string]*string{
+		"subject": &content.Subject,
+		"body":    &content.Body,
+		"sms":     &content.Short,
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, fmt.Errorf("rendering %s of %q: %w", name, kind, err)
+		}
+		*target = strings.TrimSpace(buf.String())
+	}
+
+	return content, nil
+}
+
+func (r *TemplateRenderer) lookup(kind domain.NotificationKind, locale string) *template.Template {
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, r.defaultLocale)
+
+	for _, candidate := range candidates {
+		if tmpl, ok := r.templates[strings.ToLower(candidate)][kind]; ok {
+			return tmpl
+		}
+	}
+	return nil
+}
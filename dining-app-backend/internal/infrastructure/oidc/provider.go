@@ -0,0 +1,309 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// maxResponseBody is how much of a provider's answer is read
+	maxResponseBody = 1 << 20
+	// keyRefreshInterval is how often an unknown key ID may refetch the provider's keys
+	keyRefreshInterval = time.Minute
+)
+
+// signingMethods are the ID token algorithms accepted from providers
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "ES512", "EdDSA"}
+
+// discovery is the part of a provider's metadata the code flow needs
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	Nonce           string          `json:"nonce"`
+	AuthorizedParty string          `json:"azp"`
+	Email           string          `json:"email"`
+	EmailVerified   json.RawMessage `json:"email_verified"`
+	Name            string          `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// Provider signs users in with an OpenID Connect provider. Its metadata is discovered
+// on first use and its keys are cached, so a provider that is down when the API starts
+// only fails the sign-ins through it.
+type Provider struct {
+	config config.OIDCProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	discovery     *discovery
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewProvider(config config.OIDCProviderConfig, timeout time.Duration) *Provider {
+	return &Provider{
+		config: config,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (p *Provider) Info() domain.IdentityProviderInfo {
+	displayName := p.config.DisplayName
+	if displayName == "" {
+		displayName = p.config.Name
+	}
+	return domain.IdentityProviderInfo{
+		Name:        p.config.Name,
+		DisplayName: displayName,
+	}
+}
+
+func (p *Provider) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := link.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", p.scope())
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalClaims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var token tokenResponse
+	if err := p.do(req, &token); err != nil && token.Error == "" {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("token request: %s: %s", token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no ID token")
+	}
+
+	return p.verify(ctx, meta, token.IDToken, nonce)
+}
+
+// verify checks the ID token's signature against the provider's keys, and that it was
+// issued by the provider for this client and this sign-in
+func (p *Provider) verify(ctx context.Context, meta *discovery, idToken, nonce string) (*domain.ExternalClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods))
+	token, err := parser.ParseWithClaims(idToken, &idTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, meta, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*idTokenClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid ID token")
+	}
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("ID token has no expiry")
+	}
+	if !claims.VerifyIssuer(meta.Issuer, true) {
+		return nil, errors.New("ID token has the wrong issuer")
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, errors.New("ID token has the wrong audience")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, errors.New("ID token was issued to another client")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("ID token has the wrong nonce")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("ID token has no subject")
+	}
+
+	return &domain.ExternalClaims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: emailVerified(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// key returns the provider's key with the ID, refetching the keys when it's unknown
+// since the provider may have rotated them. A token without a key ID is accepted when
+// the provider has a single key.
+func (p *Provider) key(ctx context.Context, meta *discovery, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.lookup(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < keyRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set jwks
+	if err := p.do(req, &set); err != nil {
+		return nil, fmt.Errorf("fetching keys: %w", err)
+	}
+	keys, err := set.publicKeys()
+	if err != nil {
+		return nil, fmt.Errorf("fetching keys: %w", err)
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key := p.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *Provider) lookup(kid string) crypto.PublicKey {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+// discover fetches the provider's metadata, which must name the configured issuer
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta discovery
+	if err := p.do(req, &meta); err != nil {
+		return nil, fmt.Errorf("discovering %s: %w", issuer, err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovering %s: provider names issuer %q", issuer, meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discovering %s: provider metadata is incomplete", issuer)
+	}
+
+	p.discovery = &meta
+	return p.discovery, nil
+}
+
+// do sends the request and decodes its JSON answer. The answer is decoded even when
+// the status isn't 2xx, since token errors come in the body.
+func (p *Provider) do(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return err
+	}
+	decodeErr := json.Unmarshal(body, v)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("provider returned %s", resp.Status)
+	}
+	return decodeErr
+}
+
+func (p *Provider) scope() string {
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// emailVerified reads the email_verified claim, which some providers send as a string
+func emailVerified(raw json.RawMessage) bool {
+	var verified bool
+	if err := json.Unmarshal(raw, &verified); err == nil {
+		return verified
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text == "true"
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as files under a root directory, which the API serves
+// at baseURL
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+func NewLocalStore(root, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+	return &LocalStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Put writes to a temporary file first, so readers never see a partial blob
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, data io.Reader) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write blob: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("store blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete blob: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix removes a whole directory for prefixes ending in a slash, or the
+// matching files in the prefix's directory otherwise
+func (s *LocalStore) DeletePrefix(ctx context.Context, prefix string) error {
+	if strings.HasSuffix(prefix, "/") {
+		dir, err := s.path(strings.TrimSuffix(prefix, "/"))
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("delete blobs: %w", err)
+		}
+		return nil
+	}
+
+	target, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(target))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("list blobs: %w", err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), filepath.Base(target)) {
+			if err := os.RemoveAll(filepath.Join(filepath.Dir(target), entry.Name())); err != nil {
+				return fmt.Errorf("delete blobs: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps the key into the root, rejecting keys that would escape it
+func (s *LocalStore) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || path.IsAbs(key) ||
+		key == ".." || strings.HasPrefix(key, "../") || strings.Contains(key, `\`) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	// maxResponseBody is how much of the endpoint's answer is read
+	maxResponseBody = 4096
+)
+
+// HTTPSender posts deliveries as JSON, signed with the endpoint's secret
+type HTTPSender struct {
+	client    *http.Client
+	userAgent string
+}
+
+func NewHTTPSender(timeout time.Duration, userAgent string) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		userAgent: userAgent,
+	}
+}
+
+// Send makes one delivery attempt. Any status outside 2xx is reported as an error
+// together with the response.
+func (s *HTTPSender) Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (*domain.WebhookResponse, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	response := &domain.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(respBody),
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return response, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return response, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body". Receivers recompute it with
+// their secret and should reject stale timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -4,11 +4,14 @@ import (
     "net/http"
     "strings"
     "github.com/gin-gonic/gin"
+    "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+    "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
     "github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
 )
 
-func AuthMiddleware(authService *auth.Service) gin.HandlerFunc {
+// AuthMiddleware accepts access tokens whose session hasn't been revoked
+func AuthMiddleware(authService *auth.Service, sessions ports.SessionChecker) gin.HandlerFunc {
     return func(c *gin.Context) {
         authHeader := c.GetHeader("Authorization")
         if authHeader == "" {
@@ -31,17 +34,53 @@ func AuthMiddleware(authService *auth.Service) gin.HandlerFunc {
             return
         }
 
+        if err := sessions.CheckSession(c.Request.Context(), claims.UserID, claims.SessionID); err != nil {
+            appErr := err.(*apperrors.Error)
+            c.JSON(apperrors.GetStatusCode(appErr), appErr)
+            c.Abort()
+            return
+        }
+
+        // The role in the token may be out of date; permissions are checked against the
+        // user's current role by RequirePermission
         c.Set("userID", claims.UserID)
-        c.Set("userRole", claims.Role)
+        c.Set("sessionID", claims.SessionID)
         c.Next()
     }
 }
 
-func AdminMiddleware() gin.HandlerFunc {
+// StreamAuthMiddleware also accepts the token as an access_token query parameter, since
+// browsers' EventSource can't send an Authorization header
+func StreamAuthMiddleware(authService *auth.Service, sessions ports.SessionChecker) gin.HandlerFunc {
+    authenticate := AuthMiddleware(authService, sessions)
     return func(c *gin.Context) {
-        userRole, exists := c.Get("userRole")
-        if !exists || userRole.(string) != "admin" {
-            c.JSON(http.StatusForbidden, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "admin access required", nil))
+        if c.GetHeader("Authorization") == "" {
+            if token := c.Query("access_token"); token != "" {
+                c.Request.Header.Set("Authorization", "Bearer "+token)
+            }
+        }
+        authenticate(c)
+    }
+}
+
+// RequirePermission lets a request through only when the user's current role grants
+// the permission. It must run after AuthMiddleware.
+func RequirePermission(authorizer ports.Authorizer, permission domain.Permission) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        userID, exists := c.Get("userID")
+        if !exists {
+            c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+            c.Abort()
+            return
+        }
+
+        if err := authorizer.Authorize(c.Request.Context(), userID.(int64), permission); err != nil {
+            appErr := err.(*apperrors.Error)
+            status := apperrors.GetStatusCode(appErr)
+            if appErr.Type == apperrors.ErrorTypeUnauthorized {
+                status = http.StatusForbidden
+            }
+            c.JSON(status, appErr)
             c.Abort()
             return
         }
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+// RestaurantRoleMiddleware lets a request through when the user holds at least the
+// required role at the restaurant owning the resource identified by the path parameter.
+// Users whose role grants the resource's manage permission are always let through. It
+// must run after AuthMiddleware.
+func RestaurantRoleMiddleware(memberships ports.MembershipService, resource domain.RestaurantResource, param string, required domain.MembershipRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+			c.Abort()
+			return
+		}
+
+		resourceID, err := strconv.ParseInt(c.Param(param), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, apperrors.NewError(apperrors.ErrorTypeValidation, "invalid "+string(resource)+" id", err))
+			c.Abort()
+			return
+		}
+
+		if err := memberships.Authorize(c.Request.Context(), userID.(int64), resource, resourceID, required); err != nil {
+			appErr := err.(*apperrors.Error)
+			status := apperrors.GetStatusCode(appErr)
+			if appErr.Type == apperrors.ErrorTypeUnauthorized {
+				status = http.StatusForbidden
+			}
+			c.JSON(status, appErr)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header clients send their API key in
+const APIKeyHeader = "X-API-Key"
+
+// RateLimiter builds the rate limiting middleware of each route group
+type RateLimiter struct {
+	store  ports.RateLimitStore
+	config config.RateLimitConfig
+}
+
+// NewRateLimiter checks the configured rules, so a bad rule stops the server from
+// starting instead of failing requests
+func NewRateLimiter(store ports.RateLimitStore, config config.RateLimitConfig) (*RateLimiter, error) {
+	for group, rule := range config.Groups {
+		if rule.Burst < 1 || rule.RequestsPerMinute < 1 {
+			return nil, fmt.Errorf("rate limit group %q needs a positive burst and requestsPerMinute", group)
+		}
+		switch rule.KeyBy {
+		case "ip", "user", "apikey":
+		default:
+			return nil, fmt.Errorf("rate limit group %q has unknown keyBy %q", group, rule.KeyBy)
+		}
+	}
+
+	return &RateLimiter{
+		store:  store,
+		config: config,
+	}, nil
+}
+
+// Limit rate limits the routes of the group. Limiting by user must run after
+// AuthMiddleware. Every response carries the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers, and a turned away request also gets Retry-After. If the
+// store fails the request is let through, so an outage of the store doesn't take the
+// API down with it.
+func (l *RateLimiter) Limit(group string) gin.HandlerFunc {
+	rule, ok := l.config.Groups[group]
+	if !l.config.Enabled || !ok {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	limit := domain.RateLimit{
+		Burst: rule.Burst,
+		Rate:  float64(rule.RequestsPerMinute) / 60,
+	}
+
+	return func(c *gin.Context) {
+		l.take(c, group+":"+clientKey(c, rule.KeyBy), limit)
+	}
+}
+
+// LimitAPIKey rate limits each partner's requests by the limit of its API key. It
+// must run after APIKeyMiddleware.
+func (l *RateLimiter) LimitAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("apiKey")
+		if !l.config.Enabled || !exists {
+			c.Next()
+			return
+		}
+
+		key := value.(*domain.APIKey)
+		l.take(c, "partner:"+strconv.FormatInt(key.ID, 10), key.RateLimit())
+	}
+}
+
+// take lets the request through if the bucket has a token for it, and turns it away
+// otherwise
+func (l *RateLimiter) take(c *gin.Context, key string, limit domain.RateLimit) {
+	result, err := l.store.Take(c.Request.Context(), key, limit)
+	if err != nil {
+		c.Error(err)
+		c.Next()
+		return
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.FormatInt(seconds(result.Reset), 10))
+
+	if !result.Allowed {
+		retryAfter := seconds(result.RetryAfter)
+		c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+		c.JSON(http.StatusTooManyRequests, apperrors.NewError(
+			apperrors.ErrorTypeRateLimited,
+			fmt.Sprintf("rate limit exceeded; try again in %d seconds", retryAfter),
+			map[string]int64{"retry_after_seconds": retryAfter},
+		))
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// clientKey identifies the client a request counts against. ClientIP only believes
+// X-Forwarded-For from the configured trusted proxies, so clients can't pick their key.
+func clientKey(c *gin.Context, keyBy string) string {
+	switch keyBy {
+	case "user":
+		if userID, exists := c.Get("userID"); exists {
+			return "user:" + strconv.FormatInt(userID.(int64), 10)
+		}
+	case "apikey":
+		// Only a hash of the key is kept in the store
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			return "apikey:" + auth.HashToken(key)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds up, so clients that wait as long as told aren't turned away again
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/ports"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireVerifiedEmail keeps users who haven't verified their email from the action,
+// when the action is configured to need it. It must run after AuthMiddleware or
+// APIKeyMiddleware.
+func RequireVerifiedEmail(verifier ports.EmailVerifier, action domain.VerifiedAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, apperrors.NewError(apperrors.ErrorTypeUnauthorized, "unauthorized", nil))
+			c.Abort()
+			return
+		}
+
+		if err := verifier.RequireVerified(c.Request.Context(), userID.(int64), action); err != nil {
+			appErr := err.(*apperrors.Error)
+			status := apperrors.GetStatusCode(appErr)
+			if appErr.Type == apperrors.ErrorTypeUnauthorized {
+				status = http.StatusForbidden
+			}
+			c.JSON(status, appErr)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// apiKeyRow maps the scopes TEXT[] column
+type apiKeyRow struct {
+	domain.APIKey
+	ScopeList pq.StringArray `db:"scopes"`
+}
+
+func (row *apiKeyRow) toDomain() *domain.APIKey {
+	key := row.APIKey
+	key.Scopes = make([]domain.APIKeyScope, len(row.ScopeList))
+	for i, scope := range row.ScopeList {
+		key.Scopes[i] = domain.APIKeyScope(scope)
+	}
+	return &key
+}
+
+func scopeList(scopes []domain.APIKeyScope) pq.StringArray {
+	list := make(pq.StringArray, len(scopes))
+	for i, scope := range scopes {
+		list[i] = string(scope)
+	}
+	return list
+}
+
+const apiKeyColumns = `id, partner, user_id, prefix, key_hash, scopes, requests_per_minute, burst,
+        monthly_quota, created_by, created_at, revoked_at`
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+        INSERT INTO api_keys (
+            partner, user_id, prefix, key_hash, scopes, requests_per_minute, burst,
+            monthly_quota, created_by
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		key.Partner,
+		key.UserID,
+		key.Prefix,
+		key.KeyHash,
+		scopeList(key.Scopes),
+		key.RequestsPerMinute,
+		key.Burst,
+		key.MonthlyQuota,
+		key.CreatedBy,
+	).Scan(&key.ID, &key.CreatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "user not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create api key", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id int64) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+func (r *apiKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+	return r.get(ctx, query, keyHash)
+}
+
+func (r *apiKeyRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.APIKey, error) {
+	var row apiKeyRow
+	err := conn(ctx, r.db).GetContext(ctx, &row, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "api key not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get api key", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// List pages through every key, oldest first
+func (r *apiKeyRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.APIKey], error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE ($1::bigint IS NULL OR id > $1)
+        ORDER BY id
+        LIMIT $2 OFFSET $3`
+
+	_, id := cursorArgs(page)
+	rows := []apiKeyRow{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list api keys", err)
+	}
+
+	keys := make([]*domain.APIKey, len(rows))
+	for i := range rows {
+		keys[i] = rows[i].toDomain()
+	}
+
+	result := newPage(keys, page, func(key *domain.APIKey) domain.Cursor {
+		return domain.Cursor{ID: key.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM api_keys`
+	if err := countTotal(ctx, r.db, result, page, countQuery); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Revoke is idempotent; revoking a revoked key keeps its first revocation time
+func (r *apiKeyRepository) Revoke(ctx context.Context, id int64) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to revoke api key", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "api key not found", nil)
+	}
+
+	return nil
+}
+
+// RecordRequest counts the request in a single statement, so concurrent requests
+// can't take the count past the quota
+func (r *apiKeyRepository) RecordRequest(ctx context.Context, id int64, month time.Time, quota int) (*domain.APIKeyUsage, bool, error) {
+	query := `
+        INSERT INTO api_key_usage (api_key_id, month, requests, last_request_at)
+        VALUES ($1, $2, 1, CURRENT_TIMESTAMP)
+        ON CONFLICT (api_key_id, month) DO UPDATE
+        SET requests = api_key_usage.requests + 1, last_request_at = CURRENT_TIMESTAMP
+        WHERE $3 = 0 OR api_key_usage.requests < $3
+        RETURNING api_key_id, month, requests, last_request_at`
+
+	var usage domain.APIKeyUsage
+	err := conn(ctx, r.db).GetContext(ctx, &usage, query, id, month, quota)
+	if err == nil {
+		return &usage, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record api key usage", err)
+	}
+
+	// The quota is used up; the row was left as it was
+	usageQuery := `
+        SELECT api_key_id, month, requests, last_request_at
+        FROM api_key_usage
+        WHERE api_key_id = $1 AND month = $2`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &usage, usageQuery, id, month); err != nil {
+		return nil, false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get api key usage", err)
+	}
+
+	return &usage, false, nil
+}
+
+func (r *apiKeyRepository) ListUsage(ctx context.Context, id int64, months int) ([]*domain.APIKeyUsage, error) {
+	query := `
+        SELECT api_key_id, month, requests, last_request_at
+        FROM api_key_usage
+        WHERE api_key_id = $1
+        ORDER BY month DESC
+        LIMIT $2`
+
+	usage := []*domain.APIKeyUsage{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &usage, query, id, months); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list api key usage", err)
+	}
+
+	return usage, nil
+}
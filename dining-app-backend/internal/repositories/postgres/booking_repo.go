@@ -21,7 +21,7 @@ func NewBookingRepository(db *sqlx.DB) *bookingRepository {
 }
 
 func (r *bookingRepository) Create(ctx context.Context, booking *domain.Booking) error {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
 	}
@@ -31,9 +31,9 @@ func (r *bookingRepository) Create(ctx context.Context, booking *domain.Booking)
 	query := `
         INSERT INTO bookings (
             user_id, table_id, booking_date, start_time, end_time,
-            number_of_guests, status, special_requests
+            number_of_guests, status, special_requests, api_key_id
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id, created_at, updated_at`
 
 	err = tx.QueryRowContext(
@@ -47,6 +47,7 @@ func (r *bookingRepository) Create(ctx context.Context, booking *domain.Booking)
 		booking.NumberOfGuests,
 		booking.Status,
 		booking.SpecialRequests,
+		booking.APIKeyID,
 	).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
 
 	if err != nil {
@@ -73,7 +74,8 @@ func (r *bookingRepository) Create(ctx context.Context, booking *domain.Booking)
 
 func (r *bookingRepository) GetByID(ctx context.Context, id int64) (*domain.Booking, error) {
 	query := `
-        SELECT b.*, t.table_number, r.name as restaurant_name
+        SELECT b.*, t.table_number, t.restaurant_id, r.name as restaurant_name,
+               r.address as restaurant_address, r.timezone as restaurant_timezone
         FROM bookings b
         JOIN tables t ON b.table_id = t.id
         JOIN restaurants r ON t.restaurant_id = r.id
@@ -94,9 +96,10 @@ func (r *bookingRepository) GetByID(ctx context.Context, id int64) (*domain.Book
 func (r *bookingRepository) GetUserBookings(ctx context.Context, userID int64) ([]*domain.Booking, error) {
 	query := `
         SELECT 
-            b.id, b.user_id, b.table_id, b.booking_date, b.start_time, b.end_time,
+            b.id, b.user_id, b.table_id, b.series_id, b.booking_date, b.start_time, b.end_time,
             b.number_of_guests, b.status, b.special_requests, b.created_at, b.updated_at,
-            t.table_number as "table_number", r.name as "restaurant_name"
+            t.table_number as "table_number", t.restaurant_id as "restaurant_id", r.name as "restaurant_name",
+            r.address as "restaurant_address", r.timezone as "restaurant_timezone"
         FROM bookings b
         LEFT JOIN tables t ON b.table_id = t.id
         LEFT JOIN restaurants r ON t.restaurant_id = r.id
@@ -112,6 +115,41 @@ func (r *bookingRepository) GetUserBookings(ctx context.Context, userID int64) (
 	return bookings, nil
 }
 
+// ListUserBookings pages through the user's bookings, latest first
+func (r *bookingRepository) ListUserBookings(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Booking], error) {
+	query := `
+        SELECT
+            b.id, b.user_id, b.table_id, b.series_id, b.booking_date, b.start_time, b.end_time,
+            b.number_of_guests, b.status, b.special_requests, b.created_at, b.updated_at,
+            t.table_number as "table_number", t.restaurant_id as "restaurant_id", r.name as "restaurant_name",
+            r.address as "restaurant_address", r.timezone as "restaurant_timezone"
+        FROM bookings b
+        LEFT JOIN tables t ON b.table_id = t.id
+        LEFT JOIN restaurants r ON t.restaurant_id = r.id
+        WHERE b.user_id = $1
+        AND ($2::timestamp IS NULL OR (b.booking_date + b.start_time, b.id) < ($2::timestamp, $3))
+        ORDER BY b.booking_date DESC, b.start_time DESC, b.id DESC
+        LIMIT $4 OFFSET $5`
+
+	key, id := cursorArgs(page)
+	bookings := []*domain.Booking{}
+	err := r.db.SelectContext(ctx, &bookings, query, userID, key, id, fetchLimit(page), page.Offset)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list user bookings", err)
+	}
+
+	result := newPage(bookings, page, func(booking *domain.Booking) domain.Cursor {
+		return domain.Cursor{Key: booking.BookingDate.Format("2006-01-02") + " " + booking.StartTime, ID: booking.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM bookings WHERE user_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (r *bookingRepository) CheckTableAvailability(ctx context.Context, tableID int64, date time.Time, startTime, endTime string) (bool, error) {
 	query := `
         SELECT COUNT(*)
@@ -135,7 +173,7 @@ func (r *bookingRepository) CheckTableAvailability(ctx context.Context, tableID
 }
 
 func (r *bookingRepository) UpdateStatus(ctx context.Context, bookingID int64, status domain.BookingStatus) error {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
 	}
@@ -177,6 +215,94 @@ func (r *bookingRepository) UpdateStatus(ctx context.Context, bookingID int64, s
 	return nil
 }
 
+// GetStartingBetween returns active bookings whose start, in the restaurant's time zone,
+// falls within [from, to]
+func (r *bookingRepository) GetStartingBetween(ctx context.Context, from, to time.Time) ([]*domain.Booking, error) {
+	query := `
+        SELECT
+            b.id, b.user_id, b.table_id, b.series_id, b.booking_date, b.start_time, b.end_time,
+            b.number_of_guests, b.status, b.special_requests, b.created_at, b.updated_at,
+            t.table_number as "table_number", t.restaurant_id as "restaurant_id", r.name as "restaurant_name",
+            r.address as "restaurant_address", r.timezone as "restaurant_timezone"
+        FROM bookings b
+        JOIN tables t ON b.table_id = t.id
+        JOIN restaurants r ON t.restaurant_id = r.id
+        WHERE b.status != 'cancelled'
+        AND ((b.booking_date + b.start_time) AT TIME ZONE r.timezone) BETWEEN $1 AND $2
+        ORDER BY b.booking_date, b.start_time`
+
+	bookings := []*domain.Booking{}
+	err := r.db.SelectContext(ctx, &bookings, query, from, to)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get upcoming bookings", err)
+	}
+
+	return bookings, nil
+}
+
+// LockPendingCreatedBefore locks up to limit pending bookings made before the time,
+// oldest first, until the transaction in ctx ends. Bookings another transaction has
+// locked are skipped, so replicas sweeping at once take different bookings.
+func (r *bookingRepository) LockPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Booking, error) {
+	query := `
+        SELECT b.*, t.table_number, t.restaurant_id, r.name as restaurant_name,
+               r.address as restaurant_address, r.timezone as restaurant_timezone
+        FROM bookings b
+        JOIN tables t ON b.table_id = t.id
+        JOIN restaurants r ON t.restaurant_id = r.id
+        WHERE b.status = 'pending' AND b.created_at < $1
+        ORDER BY b.created_at, b.id
+        LIMIT $2
+        FOR UPDATE OF b SKIP LOCKED`
+
+	bookings := []*domain.Booking{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, before, limit); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to lock pending bookings", err)
+	}
+
+	return bookings, nil
+}
+
+// GetRestaurantBookings returns the bookings at the restaurant on the date that aren't cancelled
+func (r *bookingRepository) GetRestaurantBookings(ctx context.Context, restaurantID int64, date time.Time) ([]*domain.Booking, error) {
+	query := `
+        SELECT
+            b.id, b.user_id, b.table_id, b.series_id, b.booking_date, b.start_time, b.end_time,
+            b.number_of_guests, b.status, b.special_requests, b.created_at, b.updated_at,
+            t.table_number as "table_number", t.restaurant_id as "restaurant_id"
+        FROM bookings b
+        JOIN tables t ON b.table_id = t.id
+        WHERE t.restaurant_id = $1 AND b.booking_date = $2 AND b.status != 'cancelled'
+        ORDER BY b.start_time`
+
+	bookings := []*domain.Booking{}
+	err := r.db.SelectContext(ctx, &bookings, query, restaurantID, date)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get restaurant bookings", err)
+	}
+
+	return bookings, nil
+}
+
+// HasBookedRestaurant reports whether the user has a booking at the restaurant that
+// isn't cancelled
+func (r *bookingRepository) HasBookedRestaurant(ctx context.Context, userID, restaurantID int64) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM bookings b
+            JOIN tables t ON b.table_id = t.id
+            WHERE b.user_id = $1 AND t.restaurant_id = $2 AND b.status != 'cancelled'
+        )`
+
+	var exists bool
+	if err := conn(ctx, r.db).GetContext(ctx, &exists, query, userID, restaurantID); err != nil {
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to check previous bookings", err)
+	}
+
+	return exists, nil
+}
+
 func (r *bookingRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM bookings WHERE id = $1`
 
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type bookingSeriesRepository struct {
+	db *sqlx.DB
+}
+
+func NewBookingSeriesRepository(db *sqlx.DB) *bookingSeriesRepository {
+	return &bookingSeriesRepository{
+		db: db,
+	}
+}
+
+// Create stores the series and all of its occurrences in a single transaction
+func (r *bookingSeriesRepository) Create(ctx context.Context, series *domain.BookingSeries, bookings []*domain.Booking) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	seriesQuery := `
+        INSERT INTO booking_series (
+            user_id, table_id, frequency, start_date, until_date, occurrence_count,
+            start_time, end_time, number_of_guests, special_requests, status
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		seriesQuery,
+		series.UserID,
+		series.TableID,
+		series.Frequency,
+		series.StartDate,
+		series.UntilDate,
+		series.OccurrenceCount,
+		series.StartTime,
+		series.EndTime,
+		series.NumberOfGuests,
+		series.SpecialRequests,
+		series.Status,
+	).Scan(&series.ID, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create booking series", err)
+	}
+
+	bookingQuery := `
+        INSERT INTO bookings (
+            user_id, table_id, series_id, booking_date, start_time, end_time,
+            number_of_guests, status, special_requests
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at, updated_at`
+
+	for _, booking := range bookings {
+		booking.SeriesID = &series.ID
+		err = tx.QueryRowContext(
+			ctx,
+			bookingQuery,
+			booking.UserID,
+			booking.TableID,
+			booking.SeriesID,
+			booking.BookingDate,
+			booking.StartTime,
+			booking.EndTime,
+			booking.NumberOfGuests,
+			booking.Status,
+			booking.SpecialRequests,
+		).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
+		if err != nil {
+			return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create booking", err)
+		}
+	}
+
+	// Update table availability
+	updateQuery := `
+        UPDATE tables
+        SET is_available = false
+        WHERE id = $1`
+
+	_, err = tx.ExecContext(ctx, updateQuery, series.TableID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update table availability", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+func (r *bookingSeriesRepository) GetByID(ctx context.Context, id int64) (*domain.BookingSeries, error) {
+	query := `
+        SELECT id, user_id, table_id, frequency, start_date, until_date, occurrence_count,
+               start_time, end_time, number_of_guests, COALESCE(special_requests, '') AS special_requests,
+               status, created_at, updated_at
+        FROM booking_series
+        WHERE id = $1`
+
+	var series domain.BookingSeries
+	err := r.db.GetContext(ctx, &series, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "booking series not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get booking series", err)
+	}
+
+	return &series, nil
+}
+
+func (r *bookingSeriesRepository) GetOccurrences(ctx context.Context, seriesID int64) ([]*domain.Booking, error) {
+	query := `
+        SELECT
+            b.id, b.user_id, b.table_id, b.series_id, b.booking_date, b.start_time, b.end_time,
+            b.number_of_guests, b.status, b.special_requests, b.created_at, b.updated_at,
+            t.table_number as "table_number", t.restaurant_id as "restaurant_id", r.name as "restaurant_name",
+            r.address as "restaurant_address", r.timezone as "restaurant_timezone"
+        FROM bookings b
+        LEFT JOIN tables t ON b.table_id = t.id
+        LEFT JOIN restaurants r ON t.restaurant_id = r.id
+        WHERE b.series_id = $1
+        ORDER BY b.booking_date, b.start_time`
+
+	bookings := []*domain.Booking{}
+	err := r.db.SelectContext(ctx, &bookings, query, seriesID)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get series occurrences", err)
+	}
+
+	return bookings, nil
+}
+
+// CancelFrom cancels every pending or confirmed occurrence on or after from and returns
+// the IDs of the bookings it cancelled. A series left active is truncated so that it ends the day
+// before from.
+func (r *bookingSeriesRepository) CancelFrom(ctx context.Context, seriesID int64, from time.Time, status domain.BookingSeriesStatus) ([]int64, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	cancelQuery := `
+        UPDATE bookings
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE series_id = $2 AND booking_date >= $3 AND status IN ('pending', 'confirmed')
+        RETURNING id`
+
+	cancelled := []int64{}
+	err = tx.SelectContext(ctx, &cancelled, cancelQuery, domain.BookingStatusCancelled, seriesID, from)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to cancel series occurrences", err)
+	}
+
+	seriesQuery := `
+        UPDATE booking_series
+        SET status = $1,
+            until_date = CASE WHEN $1 = 'active' THEN $2::date - 1 ELSE until_date END,
+            occurrence_count = CASE WHEN $1 = 'active' THEN 0 ELSE occurrence_count END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3`
+
+	_, err = tx.ExecContext(ctx, seriesQuery, status, from, seriesID)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update booking series", err)
+	}
+
+	// Make the table available again, as for single cancellations
+	updateTableQuery := `
+        UPDATE tables t
+        SET is_available = true
+        FROM booking_series s
+        WHERE s.id = $1 AND t.id = s.table_id`
+
+	_, err = tx.ExecContext(ctx, updateTableQuery, seriesID)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update table availability", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return cancelled, nil
+}
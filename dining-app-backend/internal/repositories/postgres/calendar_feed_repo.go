@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type calendarFeedRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarFeedRepository(db *sqlx.DB) *calendarFeedRepository {
+	return &calendarFeedRepository{
+		db: db,
+	}
+}
+
+// Create stores a new feed token and revokes any token the user had before
+func (r *calendarFeedRepository) Create(ctx context.Context, token *domain.CalendarFeedToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	revokeQuery := `
+        UPDATE calendar_feed_tokens
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, revokeQuery, token.UserID); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to revoke calendar feed token", err)
+	}
+
+	insertQuery := `
+        INSERT INTO calendar_feed_tokens (user_id, token_hash)
+        VALUES ($1, $2)
+        RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, insertQuery, token.UserID, token.TokenHash).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create calendar feed token", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+func (r *calendarFeedRepository) GetActiveByHash(ctx context.Context, tokenHash string) (*domain.CalendarFeedToken, error) {
+	query := `
+        SELECT id, user_id, token_hash, created_at, revoked_at
+        FROM calendar_feed_tokens
+        WHERE token_hash = $1 AND revoked_at IS NULL`
+
+	var token domain.CalendarFeedToken
+	err := r.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "calendar feed not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get calendar feed token", err)
+	}
+
+	return &token, nil
+}
+
+func (r *calendarFeedRepository) RevokeForUser(ctx context.Context, userID int64) error {
+	query := `
+        UPDATE calendar_feed_tokens
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to revoke calendar feed token", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "calendar feed not found", nil)
+	}
+
+	return nil
+}
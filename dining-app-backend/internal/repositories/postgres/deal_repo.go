@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type dealRepository struct {
+	db *sqlx.DB
+}
+
+func NewDealRepository(db *sqlx.DB) *dealRepository {
+	return &dealRepository{
+		db: db,
+	}
+}
+
+const dealColumns = `
+            id, restaurant_id, title, description, benefit_type, discount_percent, applies_to,
+            free_item, days_of_week,
+            COALESCE(to_char(start_time, 'HH24:MI'), '') AS start_time,
+            COALESCE(to_char(end_time, 'HH24:MI'), '') AS end_time,
+            min_party_size, max_party_size, first_time_guests_only, valid_from, valid_until,
+            max_redemptions, max_per_guest, active, created_at, updated_at`
+
+// dealRow holds the days as stored, before they are converted
+type dealRow struct {
+	domain.Deal
+	Days pq.Int64Array `db:"days_of_week"`
+}
+
+func (row *dealRow) toDeal() *domain.Deal {
+	deal := row.Deal
+	deal.DaysOfWeek = make([]int, len(row.Days))
+	for i, day := range row.Days {
+		deal.DaysOfWeek[i] = int(day)
+	}
+	return &deal
+}
+
+func daysOfWeek(days []int) pq.Int64Array {
+	array := make(pq.Int64Array, len(days))
+	for i, day := range days {
+		array[i] = int64(day)
+	}
+	return array
+}
+
+func (r *dealRepository) Create(ctx context.Context, deal *domain.Deal) error {
+	query := `
+        INSERT INTO deals (
+            restaurant_id, title, description, benefit_type, discount_percent, applies_to,
+            free_item, days_of_week, start_time, end_time, min_party_size, max_party_size,
+            first_time_guests_only, valid_from, valid_until, max_redemptions, max_per_guest, active
+        )
+        VALUES (
+            $1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::time, NULLIF($10, '')::time,
+            $11, $12, $13, $14, $15, $16, $17, $18
+        )
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		deal.RestaurantID,
+		deal.Title,
+		deal.Description,
+		deal.BenefitType,
+		deal.DiscountPercent,
+		deal.AppliesTo,
+		deal.FreeItem,
+		daysOfWeek(deal.DaysOfWeek),
+		deal.StartTime,
+		deal.EndTime,
+		deal.MinPartySize,
+		deal.MaxPartySize,
+		deal.FirstTimeGuestsOnly,
+		deal.ValidFrom,
+		deal.ValidUntil,
+		deal.MaxRedemptions,
+		deal.MaxPerGuest,
+		deal.Active,
+	).Scan(&deal.ID, &deal.CreatedAt, &deal.UpdatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create deal", err)
+	}
+
+	return nil
+}
+
+func (r *dealRepository) GetByID(ctx context.Context, id int64) (*domain.Deal, error) {
+	var row dealRow
+	query := `SELECT ` + dealColumns + ` FROM deals WHERE id = $1`
+
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "deal not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get deal", err)
+	}
+
+	return row.toDeal(), nil
+}
+
+func (r *dealRepository) ListByRestaurant(ctx context.Context, restaurantID int64, activeOnly bool) ([]*domain.Deal, error) {
+	query := `
+        SELECT ` + dealColumns + `
+        FROM deals
+        WHERE restaurant_id = $1 AND (active OR NOT $2)
+        ORDER BY id`
+
+	rows := []*dealRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, restaurantID, activeOnly); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list deals", err)
+	}
+
+	deals := make([]*domain.Deal, len(rows))
+	for i, row := range rows {
+		deals[i] = row.toDeal()
+	}
+
+	return deals, nil
+}
+
+func (r *dealRepository) Update(ctx context.Context, deal *domain.Deal) error {
+	query := `
+        UPDATE deals
+        SET title = $1, description = $2, benefit_type = $3, discount_percent = $4,
+            applies_to = $5, free_item = $6, days_of_week = $7,
+            start_time = NULLIF($8, '')::time, end_time = NULLIF($9, '')::time,
+            min_party_size = $10, max_party_size = $11, first_time_guests_only = $12,
+            valid_from = $13, valid_until = $14, max_redemptions = $15, max_per_guest = $16,
+            active = $17, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $18
+        RETURNING restaurant_id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		deal.Title,
+		deal.Description,
+		deal.BenefitType,
+		deal.DiscountPercent,
+		deal.AppliesTo,
+		deal.FreeItem,
+		daysOfWeek(deal.DaysOfWeek),
+		deal.StartTime,
+		deal.EndTime,
+		deal.MinPartySize,
+		deal.MaxPartySize,
+		deal.FirstTimeGuestsOnly,
+		deal.ValidFrom,
+		deal.ValidUntil,
+		deal.MaxRedemptions,
+		deal.MaxPerGuest,
+		deal.Active,
+		deal.ID,
+	).Scan(&deal.RestaurantID, &deal.CreatedAt, &deal.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "deal not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update deal", err)
+	}
+
+	return nil
+}
+
+func (r *dealRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM deals WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete deal", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "deal not found", nil)
+	}
+
+	return nil
+}
+
+// Lock holds the deals until the surrounding transaction ends, so that concurrent
+// bookings check usage caps one at a time
+func (r *dealRepository) Lock(ctx context.Context, dealIDs []int64) error {
+	ids := []int64{}
+	query := `SELECT id FROM deals WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &ids, query, pq.Int64Array(dealIDs)); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to lock deals", err)
+	}
+
+	return nil
+}
+
+// GetUsage counts the bookings that aren't cancelled each deal is attached to, in total
+// and for the user
+func (r *dealRepository) GetUsage(ctx context.Context, dealIDs []int64, userID int64) (map[int64]domain.DealUsage, error) {
+	query := `
+        SELECT bd.deal_id,
+               COUNT(*) AS total,
+               COUNT(*) FILTER (WHERE b.user_id = $2) AS for_guest
+        FROM booking_deals bd
+        JOIN bookings b ON b.id = bd.booking_id
+        WHERE bd.deal_id = ANY($1) AND b.status != 'cancelled'
+        GROUP BY bd.deal_id`
+
+	rows := []struct {
+		DealID   int64 `db:"deal_id"`
+		Total    int   `db:"total"`
+		ForGuest int   `db:"for_guest"`
+	}{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, pq.Int64Array(dealIDs), userID); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get deal usage", err)
+	}
+
+	usage := make(map[int64]domain.DealUsage, len(rows))
+	for _, row := range rows {
+		usage[row.DealID] = domain.DealUsage{Total: row.Total, ForGuest: row.ForGuest}
+	}
+
+	return usage, nil
+}
+
+func (r *dealRepository) Attach(ctx context.Context, bookingID int64, deals []*domain.BookingDeal) error {
+	query := `
+        INSERT INTO booking_deals (booking_id, deal_id, title, benefit)
+        VALUES ($1, $2, $3, $4)`
+
+	for _, deal := range deals {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, bookingID, deal.DealID, deal.Title, deal.Benefit); err != nil {
+			return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to attach deal to booking", err)
+		}
+		deal.BookingID = bookingID
+	}
+
+	return nil
+}
+
+func (r *dealRepository) GetBookingDeals(ctx context.Context, bookingIDs []int64) ([]*domain.BookingDeal, error) {
+	query := `
+        SELECT booking_id, deal_id, title, benefit
+        FROM booking_deals
+        WHERE booking_id = ANY($1)
+        ORDER BY id`
+
+	deals := []*domain.BookingDeal{}
+	if err := r.db.SelectContext(ctx, &deals, query, pq.Int64Array(bookingIDs)); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get booking deals", err)
+	}
+
+	return deals, nil
+}
@@ -5,7 +5,8 @@ import (
 )
 
 const (
-    uniqueViolationCode = "23505"
+    uniqueViolationCode     = "23505"
+    foreignKeyViolationCode = "23503"
 )
 
 // isPgUniqueViolation checks if the error is a PostgreSQL unique constraint violation
@@ -14,4 +15,12 @@ func isPgUniqueViolation(err error) bool {
         return pqErr.Code == uniqueViolationCode
     }
     return false
+}
+
+// isPgForeignKeyViolation checks if the error is a PostgreSQL foreign key violation
+func isPgForeignKeyViolation(err error) bool {
+    if pqErr, ok := err.(*pq.Error); ok {
+        return pqErr.Code == foreignKeyViolationCode
+    }
+    return false
 }
\ No newline at end of file
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type identityRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdentityRepository(db *sqlx.DB) *identityRepository {
+	return &identityRepository{
+		db: db,
+	}
+}
+
+// CreateLogin stores the started sign-in, and clears out sign-ins that were never
+// finished
+func (r *identityRepository) CreateLogin(ctx context.Context, login *domain.OIDCLogin) error {
+	cleanupQuery := `DELETE FROM oidc_logins WHERE expires_at <= CURRENT_TIMESTAMP`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, cleanupQuery); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to clear expired logins", err)
+	}
+
+	insertQuery := `
+        INSERT INTO oidc_logins (state_hash, provider, code_verifier, nonce, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, insertQuery,
+		login.StateHash,
+		login.Provider,
+		login.CodeVerifier,
+		login.Nonce,
+		login.ExpiresAt,
+	).Scan(&login.ID, &login.CreatedAt)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create login", err)
+	}
+
+	return nil
+}
+
+// ConsumeLogin deletes the sign-in as it returns it, so a state works once
+func (r *identityRepository) ConsumeLogin(ctx context.Context, stateHash string) (*domain.OIDCLogin, error) {
+	query := `
+        DELETE FROM oidc_logins
+        WHERE state_hash = $1 AND expires_at > CURRENT_TIMESTAMP
+        RETURNING *`
+
+	var login domain.OIDCLogin
+	if err := conn(ctx, r.db).GetContext(ctx, &login, query, stateHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "sign-in is invalid or has expired", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get login", err)
+	}
+
+	return &login, nil
+}
+
+func (r *identityRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	query := `
+        SELECT *
+        FROM user_identities
+        WHERE provider = $1 AND subject = $2`
+
+	var identity domain.ExternalIdentity
+	if err := conn(ctx, r.db).GetContext(ctx, &identity, query, provider, subject); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "identity not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get identity", err)
+	}
+
+	return &identity, nil
+}
+
+func (r *identityRepository) CreateIdentity(ctx context.Context, identity *domain.ExternalIdentity) error {
+	query := `
+        INSERT INTO user_identities (user_id, provider, subject, email)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, last_login_at`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "identity is already linked", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create identity", err)
+	}
+
+	return nil
+}
+
+// RecordIdentityLogin notes a sign-in through the identity, and the email the provider
+// now reports
+func (r *identityRepository) RecordIdentityLogin(ctx context.Context, id int64, email string) error {
+	query := `
+        UPDATE user_identities
+        SET email = $2, last_login_at = CURRENT_TIMESTAMP
+        WHERE id = $1`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, id, email); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record identity login", err)
+	}
+
+	return nil
+}
+
+func (r *identityRepository) ListIdentities(ctx context.Context, userID int64) ([]*domain.ExternalIdentity, error) {
+	query := `
+        SELECT *
+        FROM user_identities
+        WHERE user_id = $1
+        ORDER BY created_at`
+
+	identities := []*domain.ExternalIdentity{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &identities, query, userID); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list identities", err)
+	}
+
+	return identities, nil
+}
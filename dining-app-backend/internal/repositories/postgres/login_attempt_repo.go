@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+// loginAttemptRepository keeps failed logins in Postgres, so every replica sees the
+// same counts
+type loginAttemptRepository struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttemptRepository(db *sqlx.DB) *loginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	query := `
+        SELECT key, failures, last_failure_at, locked_until
+        FROM login_attempts
+        WHERE key = $1`
+
+	var attempts domain.LoginAttempts
+	if err := r.db.GetContext(ctx, &attempts, query, key); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get login attempts", err)
+	}
+
+	return &attempts, nil
+}
+
+// RecordFailure counts the failure in a single upsert, so concurrent failures from
+// different replicas are all counted. The database clock is used throughout.
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (*domain.LoginAttempts, error) {
+	// An existing row's failures are forgotten once its lockout has ended or, when it
+	// isn't locked out, once the last failure is outside the window
+	query := `
+        INSERT INTO login_attempts (key, failures, last_failure_at, locked_until)
+        VALUES ($1, 1, NOW(), CASE WHEN 1 >= $3 THEN NOW() + make_interval(secs => $4) END)
+        ON CONFLICT (key) DO UPDATE SET
+            failures = CASE
+                WHEN login_attempts.locked_until <= NOW()
+                    OR (login_attempts.locked_until IS NULL
+                        AND login_attempts.last_failure_at < NOW() - make_interval(secs => $2)) THEN 1
+                ELSE login_attempts.failures + 1
+            END,
+            last_failure_at = NOW(),
+            locked_until = CASE
+                WHEN login_attempts.locked_until > NOW() THEN login_attempts.locked_until
+                WHEN login_attempts.locked_until <= NOW()
+                    OR login_attempts.last_failure_at < NOW() - make_interval(secs => $2) THEN
+                    CASE WHEN 1 >= $3 THEN NOW() + make_interval(secs => $4) END
+                WHEN login_attempts.failures + 1 >= $3 THEN NOW() + make_interval(secs => $4)
+            END
+        RETURNING key, failures, last_failure_at, locked_until`
+
+	var attempts domain.LoginAttempts
+	err := r.db.GetContext(ctx, &attempts, query,
+		key,
+		policy.Window.Seconds(),
+		policy.MaxFailures,
+		policy.Lockout.Seconds(),
+	)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record login failure", err)
+	}
+
+	return &attempts, nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	query := `DELETE FROM login_attempts WHERE key = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, key); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to reset login attempts", err)
+	}
+
+	return nil
+}
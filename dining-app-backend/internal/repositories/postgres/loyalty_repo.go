@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type loyaltyRepository struct {
+	db *sqlx.DB
+}
+
+func NewLoyaltyRepository(db *sqlx.DB) *loyaltyRepository {
+	return &loyaltyRepository{
+		db: db,
+	}
+}
+
+// Append adds an entry to the ledger. A booking gets at most one entry of each kind,
+// so appending it again reports false and leaves the ledger as it was.
+func (r *loyaltyRepository) Append(ctx context.Context, entry *domain.LoyaltyEntry) (bool, error) {
+	query := `
+        INSERT INTO loyalty_ledger (user_id, kind, points, booking_id, restaurant_id, reason, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (booking_id, kind) WHERE booking_id IS NOT NULL DO NOTHING
+        RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		entry.UserID,
+		entry.Kind,
+		entry.Points,
+		entry.BookingID,
+		entry.RestaurantID,
+		entry.Reason,
+		entry.CreatedBy,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to append loyalty entry", err)
+	}
+
+	return true, nil
+}
+
+func (r *loyaltyRepository) GetBookingEntry(ctx context.Context, bookingID int64, kind domain.LoyaltyEntryKind) (*domain.LoyaltyEntry, error) {
+	query := `SELECT * FROM loyalty_ledger WHERE booking_id = $1 AND kind = $2`
+
+	var entry domain.LoyaltyEntry
+	if err := conn(ctx, r.db).GetContext(ctx, &entry, query, bookingID, kind); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "loyalty entry not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get loyalty entry", err)
+	}
+
+	return &entry, nil
+}
+
+// GetTotals returns the user's balance and lifetime points
+func (r *loyaltyRepository) GetTotals(ctx context.Context, userID int64) (int, int, error) {
+	query := `
+        SELECT COALESCE(SUM(points), 0) AS balance,
+               COALESCE(SUM(points) FILTER (WHERE kind IN ('earned', 'revoked')), 0) AS lifetime
+        FROM loyalty_ledger
+        WHERE user_id = $1`
+
+	var totals struct {
+		Balance  int `db:"balance"`
+		Lifetime int `db:"lifetime"`
+	}
+	if err := r.db.GetContext(ctx, &totals, query, userID); err != nil {
+		return 0, 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get loyalty balance", err)
+	}
+
+	return totals.Balance, totals.Lifetime, nil
+}
+
+// ListEntries returns the user's ledger, newest first
+func (r *loyaltyRepository) ListEntries(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.LoyaltyEntry], error) {
+	query := `
+        SELECT *
+        FROM loyalty_ledger
+        WHERE user_id = $1
+        AND ($2::bigint IS NULL OR id < $2)
+        ORDER BY id DESC
+        LIMIT $3 OFFSET $4`
+
+	_, id := cursorArgs(page)
+	entries := []*domain.LoyaltyEntry{}
+	if err := r.db.SelectContext(ctx, &entries, query, userID, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list loyalty entries", err)
+	}
+
+	result := newPage(entries, page, func(entry *domain.LoyaltyEntry) domain.Cursor {
+		return domain.Cursor{ID: entry.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM loyalty_ledger WHERE user_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *loyaltyRepository) GetRules(ctx context.Context, restaurantID int64) (*domain.LoyaltyRules, error) {
+	query := `SELECT * FROM loyalty_rules WHERE restaurant_id = $1`
+
+	var rules domain.LoyaltyRules
+	if err := r.db.GetContext(ctx, &rules, query, restaurantID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "loyalty rules not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get loyalty rules", err)
+	}
+
+	return &rules, nil
+}
+
+func (r *loyaltyRepository) SaveRules(ctx context.Context, rules *domain.LoyaltyRules) error {
+	query := `
+        INSERT INTO loyalty_rules (restaurant_id, enabled, points_per_booking, points_per_guest, no_show_penalty)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (restaurant_id) DO UPDATE
+        SET enabled = EXCLUDED.enabled,
+            points_per_booking = EXCLUDED.points_per_booking,
+            points_per_guest = EXCLUDED.points_per_guest,
+            no_show_penalty = EXCLUDED.no_show_penalty,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		rules.RestaurantID,
+		rules.Enabled,
+		rules.PointsPerBooking,
+		rules.PointsPerGuest,
+		rules.NoShowPenalty,
+	).Scan(&rules.UpdatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to save loyalty rules", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+// restaurantOfQueries find the restaurant each kind of resource belongs to
+var restaurantOfQueries = map[domain.RestaurantResource]string{
+	domain.ResourceRestaurant: `SELECT id FROM restaurants WHERE id = $1`,
+	domain.ResourceTable:      `SELECT restaurant_id FROM tables WHERE id = $1`,
+	domain.ResourceDeal:       `SELECT restaurant_id FROM deals WHERE id = $1`,
+	domain.ResourceBooking: `
+        SELECT t.restaurant_id
+        FROM bookings b
+        JOIN tables t ON b.table_id = t.id
+        WHERE b.id = $1`,
+}
+
+type membershipRepository struct {
+	db *sqlx.DB
+}
+
+func NewMembershipRepository(db *sqlx.DB) *membershipRepository {
+	return &membershipRepository{
+		db: db,
+	}
+}
+
+// GetRestaurantID returns the restaurant the resource belongs to
+func (r *membershipRepository) GetRestaurantID(ctx context.Context, resource domain.RestaurantResource, id int64) (int64, error) {
+	query, ok := restaurantOfQueries[resource]
+	if !ok {
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, fmt.Sprintf("unknown resource %q", resource), nil)
+	}
+
+	var restaurantID int64
+	if err := r.db.GetContext(ctx, &restaurantID, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperrors.NewError(apperrors.ErrorTypeNotFound, fmt.Sprintf("%s not found", resource), nil)
+		}
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, fmt.Sprintf("failed to get %s", resource), err)
+	}
+
+	return restaurantID, nil
+}
+
+func (r *membershipRepository) Create(ctx context.Context, membership *domain.Membership) error {
+	query := `
+        INSERT INTO restaurant_memberships (restaurant_id, user_id, role)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at, updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		membership.RestaurantID,
+		membership.UserID,
+		membership.Role,
+	).Scan(&membership.ID, &membership.CreatedAt, &membership.UpdatedAt)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "user is already a member of this restaurant", err)
+		}
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create membership", err)
+	}
+
+	return nil
+}
+
+func (r *membershipRepository) Get(ctx context.Context, restaurantID, userID int64) (*domain.Membership, error) {
+	query := `
+        SELECT m.*, u.name as user_name, u.email as user_email
+        FROM restaurant_memberships m
+        JOIN users u ON m.user_id = u.id
+        WHERE m.restaurant_id = $1 AND m.user_id = $2`
+
+	var membership domain.Membership
+	if err := conn(ctx, r.db).GetContext(ctx, &membership, query, restaurantID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "membership not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get membership", err)
+	}
+
+	return &membership, nil
+}
+
+// ListByRestaurant returns the restaurant's staff, owners first
+// ListByRestaurant pages through the restaurant's staff by offset, owners first. The
+// ordering by role has no keyset, so there are no cursors.
+func (r *membershipRepository) ListByRestaurant(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Membership], error) {
+	query := `
+        SELECT m.*, u.name as user_name, u.email as user_email
+        FROM restaurant_memberships m
+        JOIN users u ON m.user_id = u.id
+        WHERE m.restaurant_id = $1
+        ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'manager' THEN 1 ELSE 2 END, u.name, m.id
+        LIMIT $2 OFFSET $3`
+
+	memberships := []*domain.Membership{}
+	if err := r.db.SelectContext(ctx, &memberships, query, restaurantID, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list memberships", err)
+	}
+
+	result := newPage(memberships, page, nil)
+
+	countQuery := `SELECT COUNT(*) FROM restaurant_memberships WHERE restaurant_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListByUser returns the restaurants the user works at
+func (r *membershipRepository) ListByUser(ctx context.Context, userID int64) ([]*domain.Membership, error) {
+	query := `
+        SELECT m.*, r.name as restaurant_name
+        FROM restaurant_memberships m
+        JOIN restaurants r ON m.restaurant_id = r.id
+        WHERE m.user_id = $1
+        ORDER BY r.name, m.id`
+
+	memberships := []*domain.Membership{}
+	if err := r.db.SelectContext(ctx, &memberships, query, userID); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list memberships", err)
+	}
+
+	return memberships, nil
+}
+
+// LockOwners locks the restaurant's owner memberships until the transaction ends and
+// returns their user IDs, so that concurrent changes can't remove the last owner
+func (r *membershipRepository) LockOwners(ctx context.Context, restaurantID int64) ([]int64, error) {
+	query := `
+        SELECT user_id
+        FROM restaurant_memberships
+        WHERE restaurant_id = $1 AND role = 'owner'
+        ORDER BY id
+        FOR UPDATE`
+
+	owners := []int64{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &owners, query, restaurantID); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to lock owners", err)
+	}
+
+	return owners, nil
+}
+
+func (r *membershipRepository) UpdateRole(ctx context.Context, restaurantID, userID int64, role domain.MembershipRole) error {
+	query := `
+        UPDATE restaurant_memberships
+        SET role = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE restaurant_id = $2 AND user_id = $3`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, role, restaurantID, userID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update membership", err)
+	}
+
+	return expectMembershipRow(result)
+}
+
+func (r *membershipRepository) Delete(ctx context.Context, restaurantID, userID int64) error {
+	query := `DELETE FROM restaurant_memberships WHERE restaurant_id = $1 AND user_id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, restaurantID, userID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete membership", err)
+	}
+
+	return expectMembershipRow(result)
+}
+
+// CreateInvite stores the invite and withdraws any earlier pending invite to the same
+// email at the restaurant, so only the newest link works
+func (r *membershipRepository) CreateInvite(ctx context.Context, invite *domain.MembershipInvite) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	withdrawQuery := `
+        DELETE FROM membership_invites
+        WHERE restaurant_id = $1 AND email = $2 AND accepted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, withdrawQuery, invite.RestaurantID, invite.Email); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to withdraw invites", err)
+	}
+
+	insertQuery := `
+        INSERT INTO membership_invites (restaurant_id, email, role, token_hash, invited_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		insertQuery,
+		invite.RestaurantID,
+		invite.Email,
+		invite.Role,
+		invite.TokenHash,
+		invite.InvitedBy,
+		invite.ExpiresAt,
+	).Scan(&invite.ID, &invite.CreatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create invite", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// GetPendingInvite returns the unaccepted, unexpired invite with the token hash
+func (r *membershipRepository) GetPendingInvite(ctx context.Context, tokenHash string) (*domain.MembershipInvite, error) {
+	query := `
+        SELECT *
+        FROM membership_invites
+        WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+
+	var invite domain.MembershipInvite
+	if err := conn(ctx, r.db).GetContext(ctx, &invite, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "invite not found or expired", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get invite", err)
+	}
+
+	return &invite, nil
+}
+
+// ListPendingInvites returns the restaurant's unaccepted invites, newest first,
+// including expired ones
+// ListPendingInvites pages through the restaurant's unaccepted invites, latest first
+func (r *membershipRepository) ListPendingInvites(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.MembershipInvite], error) {
+	query := `
+        SELECT *
+        FROM membership_invites
+        WHERE restaurant_id = $1 AND accepted_at IS NULL
+        AND ($2::bigint IS NULL OR id < $2)
+        ORDER BY id DESC
+        LIMIT $3 OFFSET $4`
+
+	_, id := cursorArgs(page)
+	invites := []*domain.MembershipInvite{}
+	if err := r.db.SelectContext(ctx, &invites, query, restaurantID, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list invites", err)
+	}
+
+	result := newPage(invites, page, func(invite *domain.MembershipInvite) domain.Cursor {
+		return domain.Cursor{ID: invite.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM membership_invites WHERE restaurant_id = $1 AND accepted_at IS NULL`
+	if err := countTotal(ctx, r.db, result, page, countQuery, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkInviteAccepted fails with not found when the invite was accepted or withdrawn
+// in the meantime
+func (r *membershipRepository) MarkInviteAccepted(ctx context.Context, id int64) error {
+	query := `
+        UPDATE membership_invites
+        SET accepted_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND accepted_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to accept invite", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "invite not found or expired", nil)
+	}
+
+	return nil
+}
+
+// DeleteInvite withdraws a pending invite
+func (r *membershipRepository) DeleteInvite(ctx context.Context, restaurantID, inviteID int64) error {
+	query := `
+        DELETE FROM membership_invites
+        WHERE id = $1 AND restaurant_id = $2 AND accepted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, inviteID, restaurantID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete invite", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "invite not found", nil)
+	}
+
+	return nil
+}
+
+func expectMembershipRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "membership not found", nil)
+	}
+
+	return nil
+}
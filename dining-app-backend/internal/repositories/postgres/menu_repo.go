@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type menuRepository struct {
+	db *sqlx.DB
+}
+
+func NewMenuRepository(db *sqlx.DB) *menuRepository {
+	return &menuRepository{
+		db: db,
+	}
+}
+
+const menuColumns = `
+            id, restaurant_id, version, status, content, based_on_version,
+            COALESCE(updated_by, 0) AS updated_by, created_at, updated_at, published_at`
+
+// menuRow holds the content as stored, before it is decoded
+type menuRow struct {
+	domain.Menu
+	RawContent string `db:"content"`
+}
+
+func (row *menuRow) toMenu() (*domain.Menu, error) {
+	menu := row.Menu
+	if err := json.Unmarshal([]byte(row.RawContent), &menu.Content); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to decode menu", err)
+	}
+	return &menu, nil
+}
+
+func (r *menuRepository) get(ctx context.Context, q queryer, where string, args ...interface{}) (*domain.Menu, error) {
+	var row menuRow
+	query := `SELECT ` + menuColumns + ` FROM menu_versions WHERE ` + where
+
+	if err := q.GetContext(ctx, &row, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "menu not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get menu", err)
+	}
+
+	return row.toMenu()
+}
+
+func (r *menuRepository) GetDraft(ctx context.Context, restaurantID int64) (*domain.Menu, error) {
+	return r.get(ctx, r.db, `restaurant_id = $1 AND status = $2`, restaurantID, domain.MenuStatusDraft)
+}
+
+func (r *menuRepository) GetPublished(ctx context.Context, restaurantID int64) (*domain.Menu, error) {
+	return r.get(ctx, r.db, `restaurant_id = $1 AND status = $2`, restaurantID, domain.MenuStatusPublished)
+}
+
+func (r *menuRepository) GetVersion(ctx context.Context, restaurantID int64, version int) (*domain.Menu, error) {
+	return r.get(ctx, r.db, `restaurant_id = $1 AND version = $2 AND status != $3`, restaurantID, version, domain.MenuStatusDraft)
+}
+
+// ListVersions pages through the published and archived versions, newest first
+func (r *menuRepository) ListVersions(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Menu], error) {
+	query := `
+        SELECT ` + menuColumns + `
+        FROM menu_versions
+        WHERE restaurant_id = $1 AND status != $2
+        AND ($3::int IS NULL OR version < $3::int)
+        ORDER BY version DESC
+        LIMIT $4 OFFSET $5`
+
+	key, _ := cursorArgs(page)
+	rows := []*menuRow{}
+	err := r.db.SelectContext(ctx, &rows, query, restaurantID, domain.MenuStatusDraft, key, fetchLimit(page), page.Offset)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list menu versions", err)
+	}
+
+	menus := make([]*domain.Menu, len(rows))
+	for i, row := range rows {
+		menu, err := row.toMenu()
+		if err != nil {
+			return nil, err
+		}
+		menus[i] = menu
+	}
+
+	result := newPage(menus, page, func(menu *domain.Menu) domain.Cursor {
+		return domain.Cursor{Key: strconv.Itoa(menu.Version), ID: menu.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM menu_versions WHERE restaurant_id = $1 AND status != $2`
+	if err := countTotal(ctx, r.db, result, page, countQuery, restaurantID, domain.MenuStatusDraft); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveDraft creates the restaurant's draft or replaces its content
+func (r *menuRepository) SaveDraft(ctx context.Context, menu *domain.Menu) error {
+	content, err := json.Marshal(menu.Content)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to encode menu", err)
+	}
+
+	query := `
+        INSERT INTO menu_versions (restaurant_id, status, content, updated_by)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (restaurant_id) WHERE status = 'draft'
+        DO UPDATE SET content = EXCLUDED.content,
+                      updated_by = EXCLUDED.updated_by,
+                      updated_at = CURRENT_TIMESTAMP
+        RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		menu.RestaurantID,
+		domain.MenuStatusDraft,
+		string(content),
+		menu.UpdatedBy,
+	).Scan(&menu.ID, &menu.CreatedAt, &menu.UpdatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to save menu draft", err)
+	}
+
+	menu.Status = domain.MenuStatusDraft
+	return nil
+}
+
+// PublishDraft turns the draft into the next published version, archiving the current one
+func (r *menuRepository) PublishDraft(ctx context.Context, restaurantID int64, userID int64) (*domain.Menu, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	version, err := r.prepareVersion(ctx, tx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE menu_versions
+        SET status = $1, version = $2, updated_by = $3,
+            updated_at = CURRENT_TIMESTAMP, published_at = CURRENT_TIMESTAMP
+        WHERE restaurant_id = $4 AND status = $5
+        RETURNING id`
+
+	var id int64
+	err = tx.QueryRowContext(ctx, query, domain.MenuStatusPublished, version, userID, restaurantID, domain.MenuStatusDraft).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "there is no draft to publish", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to publish menu", err)
+	}
+
+	menu, err := r.get(ctx, tx, `id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return menu, nil
+}
+
+// Republish publishes a copy of an earlier version as the next version, so that the
+// history stays linear
+func (r *menuRepository) Republish(ctx context.Context, restaurantID int64, fromVersion int, userID int64) (*domain.Menu, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	source, err := r.get(ctx, tx, `restaurant_id = $1 AND version = $2 AND status != $3`, restaurantID, fromVersion, domain.MenuStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Status == domain.MenuStatusPublished {
+		return nil, apperrors.NewError(apperrors.ErrorTypeConflict, "menu version is already published", nil)
+	}
+
+	version, err := r.prepareVersion(ctx, tx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO menu_versions (
+            restaurant_id, version, status, content, based_on_version, updated_by, published_at
+        )
+        SELECT restaurant_id, $1, $2, content, version, $3, CURRENT_TIMESTAMP
+        FROM menu_versions
+        WHERE id = $4
+        RETURNING id`
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, query, version, domain.MenuStatusPublished, userID, source.ID).Scan(&id); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to republish menu", err)
+	}
+
+	menu, err := r.get(ctx, tx, `id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return menu, nil
+}
+
+// prepareVersion serialises publishing per restaurant, archives the published version
+// and returns the number for the next one
+func (r *menuRepository) prepareVersion(ctx context.Context, tx *scopedTx, restaurantID int64) (int, error) {
+	var locked int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM restaurants WHERE id = $1 FOR UPDATE`, restaurantID).Scan(&locked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", nil)
+		}
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to lock restaurant", err)
+	}
+
+	archiveQuery := `
+        UPDATE menu_versions
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE restaurant_id = $2 AND status = $3`
+
+	if _, err := tx.ExecContext(ctx, archiveQuery, domain.MenuStatusArchived, restaurantID, domain.MenuStatusPublished); err != nil {
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to archive menu", err)
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) + 1 FROM menu_versions WHERE restaurant_id = $1`, restaurantID).Scan(&version)
+	if err != nil {
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to number menu version", err)
+	}
+
+	return version, nil
+}
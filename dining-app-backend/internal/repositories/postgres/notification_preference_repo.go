@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type notificationPreferenceRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPreferenceRepository(db *sqlx.DB) *notificationPreferenceRepository {
+	return &notificationPreferenceRepository{
+		db: db,
+	}
+}
+
+func (r *notificationPreferenceRepository) Get(ctx context.Context, userID int64) (*domain.NotificationPreferences, error) {
+	query := `
+        SELECT user_id, email_enabled, sms_enabled, reminders_enabled,
+               COALESCE(phone, '') AS phone, locale, updated_at
+        FROM notification_preferences
+        WHERE user_id = $1`
+
+	var preferences domain.NotificationPreferences
+	err := r.db.GetContext(ctx, &preferences, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "notification preferences not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get notification preferences", err)
+	}
+
+	return &preferences, nil
+}
+
+func (r *notificationPreferenceRepository) Upsert(ctx context.Context, preferences *domain.NotificationPreferences) error {
+	query := `
+        INSERT INTO notification_preferences (
+            user_id, email_enabled, sms_enabled, reminders_enabled, phone, locale
+        )
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (user_id) DO UPDATE
+        SET email_enabled = EXCLUDED.email_enabled,
+            sms_enabled = EXCLUDED.sms_enabled,
+            reminders_enabled = EXCLUDED.reminders_enabled,
+            phone = EXCLUDED.phone,
+            locale = EXCLUDED.locale,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		preferences.UserID,
+		preferences.EmailEnabled,
+		preferences.SMSEnabled,
+		preferences.RemindersEnabled,
+		preferences.Phone,
+		preferences.Locale,
+	).Scan(&preferences.UpdatedAt)
+
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to save notification preferences", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *notificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// Enqueue stores the notification unless one with the same dedup key already exists.
+// It reports whether a new row was created.
+func (r *notificationRepository) Enqueue(ctx context.Context, notification *domain.Notification) (bool, error) {
+	query := `
+        INSERT INTO notifications (
+            user_id, booking_id, kind, channel, recipient, subject, body, dedup_key, status
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (dedup_key) DO NOTHING
+        RETURNING id, next_attempt_at, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		notification.UserID,
+		notification.BookingID,
+		notification.Kind,
+		notification.Channel,
+		notification.Recipient,
+		notification.Subject,
+		notification.Body,
+		notification.DedupKey,
+		domain.NotificationStatusPending,
+	).Scan(&notification.ID, &notification.NextAttemptAt, &notification.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to enqueue notification", err)
+	}
+
+	notification.Status = domain.NotificationStatusPending
+	return true, nil
+}
+
+// ClaimDue leases up to limit pending notifications to this replica. A claimed row is
+// not due again until the lease expires, so a crashed sender's work is picked up later.
+func (r *notificationRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.Notification, error) {
+	query := `
+        UPDATE notifications
+        SET attempts = attempts + 1,
+            next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM notifications
+            WHERE status = $3 AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY next_attempt_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, user_id, booking_id, kind, channel, recipient, subject, body, dedup_key,
+                  status, attempts, COALESCE(last_error, '') AS last_error, next_attempt_at,
+                  sent_at, created_at`
+
+	notifications := []*domain.Notification{}
+	err := r.db.SelectContext(ctx, &notifications, query, limit, lease.Seconds(), domain.NotificationStatusPending)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to claim notifications", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) MarkSent(ctx context.Context, id int64) error {
+	query := `
+        UPDATE notifications
+        SET status = $1, sent_at = CURRENT_TIMESTAMP, last_error = NULL
+        WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, domain.NotificationStatusSent, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark notification as sent", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed attempt. Without a next attempt the notification is
+// given up on.
+func (r *notificationRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	query := `
+        UPDATE notifications
+        SET status = $1, last_error = $2, next_attempt_at = COALESCE($3, next_attempt_at)
+        WHERE id = $4`
+
+	status := domain.NotificationStatusPending
+	if nextAttemptAt == nil {
+		status = domain.NotificationStatusFailed
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, status, lastError, nextAttemptAt, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark notification as failed", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type outboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+const outboxColumns = `id, event_type, aggregate_type, aggregate_id, payload, attempts, created_at`
+
+// Append records the events. Call it inside Transactor.WithinTransaction so that they
+// are only stored if the change they describe commits.
+func (r *outboxRepository) Append(ctx context.Context, events ...*domain.Event) error {
+	query := `
+        INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at`
+
+	for _, event := range events {
+		err := conn(ctx, r.db).QueryRowContext(
+			ctx,
+			query,
+			event.Type,
+			event.AggregateType,
+			event.AggregateID,
+			event.Payload,
+		).Scan(&event.ID, &event.CreatedAt)
+
+		if err != nil {
+			return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record event", err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimUnpublished leases the oldest unpublished events to this replica, like
+// notifications. Events are returned in the order they were recorded.
+func (r *outboxRepository) ClaimUnpublished(ctx context.Context, limit int, lease time.Duration) ([]*domain.Event, error) {
+	query := `
+        UPDATE outbox_events
+        SET attempts = attempts + 1,
+            next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM outbox_events
+            WHERE published_at IS NULL AND failed_at IS NULL
+            AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + outboxColumns
+
+	events := []*domain.Event{}
+	if err := r.db.SelectContext(ctx, &events, query, limit, lease.Seconds()); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to claim events", err)
+	}
+
+	// RETURNING does not preserve the subquery order
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	query := `
+        UPDATE outbox_events
+        SET published_at = CURRENT_TIMESTAMP, last_error = NULL
+        WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark event as published", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed dispatch. Without a next attempt the event is given up on.
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	query := `
+        UPDATE outbox_events
+        SET last_error = $1,
+            next_attempt_at = COALESCE($2, next_attempt_at),
+            failed_at = CASE WHEN $2::timestamptz IS NULL THEN CURRENT_TIMESTAMP END
+        WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, nextAttemptAt, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark event as failed", err)
+	}
+
+	return nil
+}
+
+// Notify tells every replica listening on the channel about the event. Only its ID is
+// sent; listeners load the event with GetByID, so payloads of any size get through.
+func (r *outboxRepository) Notify(ctx context.Context, channel string, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, channel, strconv.FormatInt(id, 10)); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to notify event", err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id int64) (*domain.Event, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox_events WHERE id = $1`
+
+	var event domain.Event
+	if err := r.db.GetContext(ctx, &event, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "event not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get event", err)
+	}
+
+	return &event, nil
+}
+
+// ListDispatched returns events that have already been handed to broadcast subscribers,
+// in the order they were recorded
+func (r *outboxRepository) ListDispatched(ctx context.Context, filter domain.EventFilter) ([]*domain.Event, error) {
+	query := `
+        SELECT ` + outboxColumns + `
+        FROM outbox_events
+        WHERE id > $1 AND attempts > 0
+        AND (cardinality($2::text[]) = 0 OR event_type = ANY($2))
+        AND ($3::bigint = 0 OR (payload->>'restaurant_id')::bigint = $3)
+        AND ($4::bigint = 0 OR (payload->>'user_id')::bigint = $4)
+        ORDER BY id
+        LIMIT NULLIF($5::int, 0)`
+
+	types := make(pq.StringArray, len(filter.Types))
+	for i, eventType := range filter.Types {
+		types[i] = string(eventType)
+	}
+
+	events := []*domain.Event{}
+	err := r.db.SelectContext(ctx, &events, query, filter.AfterID, types, filter.RestaurantID, filter.UserID, filter.Limit)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list events", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+// fetchLimit is one row more than the page holds, so that the extra row tells whether
+// another page follows
+func fetchLimit(page domain.PageRequest) int {
+	return page.Limit + 1
+}
+
+// newPage trims the row fetched beyond the page and points the next cursor at the
+// page's last row. cursorOf is nil for orderings that don't support cursors.
+func newPage[T any](items []T, page domain.PageRequest, cursorOf func(T) domain.Cursor) *domain.Page[T] {
+	result := &domain.Page[T]{Items: items}
+	if len(items) > page.Limit {
+		result.Items = items[:page.Limit]
+		result.HasMore = true
+		if cursorOf != nil {
+			next := cursorOf(result.Items[len(result.Items)-1])
+			result.NextCursor = &next
+		}
+	}
+	return result
+}
+
+// countTotal fills in the page's total for offset pages
+func countTotal[T any](ctx context.Context, db *sqlx.DB, result *domain.Page[T], page domain.PageRequest, query string, args ...interface{}) error {
+	if page.Cursor != nil {
+		return nil
+	}
+
+	// A partial page needs no count unless it was reached by skipping past the end
+	if !result.HasMore && (len(result.Items) > 0 || page.Offset == 0) {
+		total := page.Offset + len(result.Items)
+		result.Total = &total
+		return nil
+	}
+
+	var total int
+	if err := db.GetContext(ctx, &total, query, args...); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to count rows", err)
+	}
+	result.Total = &total
+	return nil
+}
+
+// cursorArgs returns the cursor's key and ID as query arguments, or NULLs without one
+func cursorArgs(page domain.PageRequest) (interface{}, interface{}) {
+	if page.Cursor == nil {
+		return nil, nil
+	}
+	return page.Cursor.Key, page.Cursor.ID
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type passwordResetRepository struct {
+	db *sqlx.DB
+}
+
+func NewPasswordResetRepository(db *sqlx.DB) *passwordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+// Create stores the reset and withdraws the user's earlier unused resets, so only the
+// newest link works
+func (r *passwordResetRepository) Create(ctx context.Context, reset *domain.PasswordReset) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	withdrawQuery := `DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, withdrawQuery, reset.UserID); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to withdraw password resets", err)
+	}
+
+	insertQuery := `
+        INSERT INTO password_resets (user_id, token_hash, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, insertQuery, reset.UserID, reset.TokenHash, reset.ExpiresAt).
+		Scan(&reset.ID, &reset.CreatedAt)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create password reset", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// LockPending returns the unused, unexpired reset with the token hash and locks it, so
+// the token can't be used twice concurrently. It must run in a transaction.
+func (r *passwordResetRepository) LockPending(ctx context.Context, tokenHash string) (*domain.PasswordReset, error) {
+	query := `
+        SELECT *
+        FROM password_resets
+        WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+        FOR UPDATE`
+
+	var reset domain.PasswordReset
+	if err := conn(ctx, r.db).GetContext(ctx, &reset, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "reset link is invalid or has expired", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get password reset", err)
+	}
+
+	return &reset, nil
+}
+
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, id int64) error {
+	query := `UPDATE password_resets SET used_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to use password reset", err)
+	}
+
+	return nil
+}
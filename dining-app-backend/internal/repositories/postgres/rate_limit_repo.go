@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+// rateLimitRepository keeps token buckets in Postgres, so every replica takes from the
+// same buckets
+type rateLimitRepository struct {
+	db *sqlx.DB
+}
+
+func NewRateLimitRepository(db *sqlx.DB) *rateLimitRepository {
+	return &rateLimitRepository{
+		db: db,
+	}
+}
+
+// Take refills the bucket and takes a token in one upsert, which only updates the row
+// when a token is left. A turned away request reads the bucket to say when to retry.
+// The database clock is used throughout.
+func (r *rateLimitRepository) Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	takeQuery := `
+        INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+        VALUES ($1, $2::float8 - 1, NOW())
+        ON CONFLICT (key) DO UPDATE SET
+            tokens = LEAST($2::float8, rate_limit_buckets.tokens
+                + EXTRACT(EPOCH FROM NOW() - rate_limit_buckets.updated_at) * $3::float8) - 1,
+            updated_at = NOW()
+        WHERE LEAST($2::float8, rate_limit_buckets.tokens
+            + EXTRACT(EPOCH FROM NOW() - rate_limit_buckets.updated_at) * $3::float8) >= 1
+        RETURNING tokens`
+
+	var tokens float64
+	err := r.db.QueryRowContext(ctx, takeQuery, key, limit.Burst, limit.Rate).Scan(&tokens)
+	if err == nil {
+		return limit.Result(tokens, true), nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to take rate limit token", err)
+	}
+
+	bucketQuery := `
+        SELECT LEAST($2::float8, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) * $3::float8)
+        FROM rate_limit_buckets
+        WHERE key = $1`
+
+	if err := r.db.QueryRowContext(ctx, bucketQuery, key, limit.Burst, limit.Rate).Scan(&tokens); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get rate limit bucket", err)
+	}
+
+	return limit.Result(tokens, false), nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type restaurantImageRepository struct {
+	db *sqlx.DB
+}
+
+func NewRestaurantImageRepository(db *sqlx.DB) *restaurantImageRepository {
+	return &restaurantImageRepository{
+		db: db,
+	}
+}
+
+// Create stores the image after the restaurant's last one of the same kind
+func (r *restaurantImageRepository) Create(ctx context.Context, image *domain.RestaurantImage) error {
+	query := `
+        INSERT INTO restaurant_images (
+            restaurant_id, kind, blob_key, thumbnail_key, content_type,
+            width, height, size_bytes, position
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (
+            SELECT COALESCE(MAX(position) + 1, 0)
+            FROM restaurant_images
+            WHERE restaurant_id = $1 AND kind = $2
+        ))
+        RETURNING id, position, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		image.RestaurantID,
+		image.Kind,
+		image.BlobKey,
+		image.ThumbnailKey,
+		image.ContentType,
+		image.Width,
+		image.Height,
+		image.SizeBytes,
+	).Scan(&image.ID, &image.Position, &image.CreatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "restaurant not found", err)
+		}
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "restaurant already has a cover image", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create restaurant image", err)
+	}
+
+	return nil
+}
+
+func (r *restaurantImageRepository) GetByID(ctx context.Context, id int64) (*domain.RestaurantImage, error) {
+	query := `SELECT * FROM restaurant_images WHERE id = $1`
+
+	var image domain.RestaurantImage
+	if err := conn(ctx, r.db).GetContext(ctx, &image, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "image not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get image", err)
+	}
+
+	return &image, nil
+}
+
+func (r *restaurantImageRepository) GetCover(ctx context.Context, restaurantID int64) (*domain.RestaurantImage, error) {
+	query := `SELECT * FROM restaurant_images WHERE restaurant_id = $1 AND kind = 'cover'`
+
+	var image domain.RestaurantImage
+	if err := conn(ctx, r.db).GetContext(ctx, &image, query, restaurantID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "cover image not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get cover image", err)
+	}
+
+	return &image, nil
+}
+
+// ListByRestaurants returns the restaurants' images, covers first and the gallery in
+// order. An empty kind lists both.
+func (r *restaurantImageRepository) ListByRestaurants(ctx context.Context, restaurantIDs []int64, kind domain.ImageKind) ([]*domain.RestaurantImage, error) {
+	query := `
+        SELECT *
+        FROM restaurant_images
+        WHERE restaurant_id = ANY($1)
+        AND ($2 = '' OR kind = $2)
+        ORDER BY restaurant_id, kind, position, id`
+
+	images := []*domain.RestaurantImage{}
+	if err := r.db.SelectContext(ctx, &images, query, pq.Int64Array(restaurantIDs), kind); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list restaurant images", err)
+	}
+
+	return images, nil
+}
+
+func (r *restaurantImageRepository) Count(ctx context.Context, restaurantID int64, kind domain.ImageKind) (int, error) {
+	query := `SELECT COUNT(*) FROM restaurant_images WHERE restaurant_id = $1 AND kind = $2`
+
+	var count int
+	if err := conn(ctx, r.db).GetContext(ctx, &count, query, restaurantID, kind); err != nil {
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to count restaurant images", err)
+	}
+
+	return count, nil
+}
+
+func (r *restaurantImageRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM restaurant_images WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete image", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "image not found", nil)
+	}
+
+	return nil
+}
@@ -3,10 +3,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type RestaurantRepository struct {
@@ -19,15 +24,23 @@ func NewRestaurantRepository(db *sqlx.DB) *RestaurantRepository {
 	}
 }
 
+// restaurantRatingColumns aggregates visible reviews for the restaurant row in scope
+const restaurantRatingColumns = `
+               COALESCE((SELECT ROUND(AVG(rating), 2) FROM reviews
+                         WHERE restaurant_id = restaurants.id AND NOT hidden), 0)::float8 AS average_rating,
+               (SELECT COUNT(*) FROM reviews
+                WHERE restaurant_id = restaurants.id AND NOT hidden) AS review_count`
+
 func (r *RestaurantRepository) Create(ctx context.Context, restaurant *domain.Restaurant) error {
 	query := `
         INSERT INTO restaurants (
-            name, description, address, cuisine_type, opening_time, closing_time
+            name, description, address, cuisine_type, opening_time, closing_time, timezone,
+            latitude, longitude
         )
-        VALUES ($1, $2, $3, $4, $5, $6)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		restaurant.Name,
@@ -36,6 +49,9 @@ func (r *RestaurantRepository) Create(ctx context.Context, restaurant *domain.Re
 		restaurant.CuisineType,
 		restaurant.OpeningTime,
 		restaurant.ClosingTime,
+		restaurant.Timezone,
+		restaurant.Latitude,
+		restaurant.Longitude,
 	).Scan(&restaurant.ID, &restaurant.CreatedAt, &restaurant.UpdatedAt)
 
 	if err != nil {
@@ -52,7 +68,8 @@ func (r *RestaurantRepository) GetByID(ctx context.Context, id int64) (*domain.R
 	var restaurant domain.Restaurant
 	query := `
         SELECT id, name, description, address, cuisine_type, 
-               opening_time, closing_time, created_at, updated_at
+               opening_time, closing_time, timezone, latitude, longitude,
+               created_at, updated_at,` + restaurantRatingColumns + `
         FROM restaurants
         WHERE id = $1`
 
@@ -82,21 +99,164 @@ func (r *RestaurantRepository) GetByID(ctx context.Context, id int64) (*domain.R
 	return &restaurant, nil
 }
 
-func (r *RestaurantRepository) List(ctx context.Context, offset, limit int) ([]*domain.Restaurant, error) {
-	query := `
-        SELECT id, name, description, address, cuisine_type, 
-               opening_time, closing_time, created_at, updated_at
+// restaurantSearchOrder maps each sort to its ORDER BY clause
+var restaurantSearchOrder = map[domain.RestaurantSort]string{
+	domain.RestaurantSortRelevance: "rank DESC, name, id",
+	domain.RestaurantSortName:      "name, id",
+	domain.RestaurantSortRating:    "average_rating DESC, review_count DESC, name, id",
+	domain.RestaurantSortNewest:    "created_at DESC, id DESC",
+	domain.RestaurantSortDistance:  "distance_km, name, id",
+}
+
+// restaurantSearchKeyset maps the sorts that support cursors to the condition that
+// continues after the cursor's row
+var restaurantSearchKeyset = map[domain.RestaurantSort]string{
+	domain.RestaurantSortName:   "(name, id) > ($%d, $%d)",
+	domain.RestaurantSortNewest: "(created_at, id) < ($%d::timestamptz, $%d)",
+}
+
+// restaurantSearchCursor returns the cursor pointing at the result, for the sort
+func restaurantSearchCursor(sort domain.RestaurantSort) func(*domain.RestaurantSearchResult) domain.Cursor {
+	switch sort {
+	case domain.RestaurantSortName:
+		return func(result *domain.RestaurantSearchResult) domain.Cursor {
+			return domain.Cursor{Sort: string(sort), Key: result.Name, ID: result.ID}
+		}
+	case domain.RestaurantSortNewest:
+		return func(result *domain.RestaurantSearchResult) domain.Cursor {
+			return domain.Cursor{Sort: string(sort), Key: result.CreatedAt.Format(time.RFC3339Nano), ID: result.ID}
+		}
+	}
+	return nil
+}
+
+// restaurantSearchFrom holds the search's joins and filters, shared by the page and
+// its count
+const restaurantSearchFrom = `
         FROM restaurants
-        ORDER BY name
-        LIMIT $1 OFFSET $2`
+        CROSS JOIN search
+        CROSS JOIN LATERAL (
+            SELECT CASE WHEN $4::float8 IS NULL THEN NULL
+                        ELSE 2 * 6371 * asin(sqrt(LEAST(1,
+                            power(sin(radians(latitude - $4) / 2), 2) +
+                            cos(radians($4)) * cos(radians(latitude)) *
+                            power(sin(radians(longitude - $5) / 2), 2))))
+                   END AS distance_km
+        ) geo
+        WHERE ($1 = '' OR search_vector @@ search.query OR $1 <% search_text)
+        AND (cardinality($2::text[]) = 0 OR cuisine_type = ANY($2))
+        AND (NOT $3 OR CASE
+                WHEN closing_time::time > opening_time::time THEN
+                    (CURRENT_TIMESTAMP AT TIME ZONE timezone)::time >= opening_time::time
+                    AND (CURRENT_TIMESTAMP AT TIME ZONE timezone)::time < closing_time::time
+                ELSE
+                    (CURRENT_TIMESTAMP AT TIME ZONE timezone)::time >= opening_time::time
+                    OR (CURRENT_TIMESTAMP AT TIME ZONE timezone)::time < closing_time::time
+            END)
+        AND ($4::float8 IS NULL OR (
+                latitude BETWEEN $6 AND $7
+                AND longitude BETWEEN $8 AND $9
+                AND geo.distance_km <= $10))`
+
+// ts_headline doesn't escape the text it highlights, so matches are delimited with
+// private use characters and the text is HTML-escaped before they become <mark> tags
+const (
+	highlightStart   = "\uE000"
+	highlightStop    = "\uE001"
+	highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+var highlightMarks = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// markHighlight turns a ts_headline fragment into HTML that is safe to render
+func markHighlight(fragment string) string {
+	return highlightMarks.Replace(html.EscapeString(fragment))
+}
 
-	restaurants := []*domain.Restaurant{}
-	err := r.db.SelectContext(ctx, &restaurants, query, limit, offset)
+// Search matches the query against the weighted search document, falling back to
+// trigram similarity so that misspelt terms still find restaurants. Near a point, the
+// bounding box narrows the candidates before the haversine distance is computed.
+func (r *RestaurantRepository) Search(ctx context.Context, search domain.RestaurantSearch) (*domain.Page[*domain.RestaurantSearchResult], error) {
+	order, ok := restaurantSearchOrder[search.Sort]
+	if !ok {
+		order = restaurantSearchOrder[domain.RestaurantSortName]
+	}
+
+	var lat, lng *float64
+	var bounds domain.GeoBounds
+	if search.Near != nil {
+		lat, lng = &search.Near.Latitude, &search.Near.Longitude
+		bounds = search.Near.Bounds(search.RadiusKm)
+	}
+
+	args := []interface{}{
+		search.Query,
+		pq.StringArray(search.Cuisines),
+		search.OpenNow,
+		lat,
+		lng,
+		bounds.MinLatitude,
+		bounds.MaxLatitude,
+		bounds.MinLongitude,
+		bounds.MaxLongitude,
+		search.RadiusKm,
+	}
+	filters := len(args)
+
+	where := restaurantSearchFrom
+	if cursor := search.Page.Cursor; cursor != nil {
+		keyset, ok := restaurantSearchKeyset[search.Sort]
+		if !ok {
+			return nil, apperrors.NewError(apperrors.ErrorTypeValidation, "this sort does not support cursors", nil)
+		}
+		where += "\n        AND " + fmt.Sprintf(keyset, len(args)+1, len(args)+2)
+		args = append(args, cursor.Key, cursor.ID)
+	}
+
+	query := `
+        WITH search AS (
+            SELECT websearch_to_tsquery('english', $1) AS query
+        )
+        SELECT id, name, description, address, cuisine_type,
+               opening_time, closing_time, timezone, latitude, longitude,
+               created_at, updated_at,` + restaurantRatingColumns + `,
+               geo.distance_km,
+               CASE WHEN $1 = '' THEN 0
+                    ELSE ts_rank_cd(search_vector, search.query) + word_similarity($1, search_text)
+               END AS rank,
+               CASE WHEN $1 = '' THEN ''
+                    ELSE ts_headline('english', name, search.query, '` + highlightOptions + `, HighlightAll=true')
+               END AS name_highlight,
+               CASE WHEN $1 = '' THEN ''
+                    ELSE ts_headline('english', coalesce(description, ''), search.query,
+                                     '` + highlightOptions + `, MaxFragments=2, MaxWords=20, MinWords=8')
+               END AS description_highlight` + where + `
+        ORDER BY ` + order + fmt.Sprintf(`
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	results := []*domain.RestaurantSearchResult{}
+	err := r.db.SelectContext(ctx, &results, query, append(args, fetchLimit(search.Page), search.Page.Offset)...)
 	if err != nil {
-		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list restaurants", err)
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to search restaurants", err)
+	}
+
+	for _, result := range results {
+		result.NameHighlight = markHighlight(result.NameHighlight)
+		result.DescriptionHighlight = markHighlight(result.DescriptionHighlight)
+	}
+
+	page := newPage(results, search.Page, restaurantSearchCursor(search.Sort))
+
+	countQuery := `
+        WITH search AS (
+            SELECT websearch_to_tsquery('english', $1) AS query
+        )
+        SELECT COUNT(*)` + restaurantSearchFrom
+	if err := countTotal(ctx, r.db, page, search.Page, countQuery, args[:filters]...); err != nil {
+		return nil, err
 	}
 
-	return restaurants, nil
+	return page, nil
 }
 
 func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Restaurant) error {
@@ -104,11 +264,13 @@ func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Re
         UPDATE restaurants
         SET name = $1, description = $2, address = $3, 
             cuisine_type = $4, opening_time = $5, closing_time = $6,
+            timezone = COALESCE(NULLIF($7, ''), timezone),
+            latitude = $8, longitude = $9,
             updated_at = CURRENT_TIMESTAMP
-        WHERE id = $7
-        RETURNING updated_at`
+        WHERE id = $10
+        RETURNING timezone, updated_at,` + restaurantRatingColumns
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		restaurant.Name,
@@ -117,8 +279,11 @@ func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Re
 		restaurant.CuisineType,
 		restaurant.OpeningTime,
 		restaurant.ClosingTime,
+		restaurant.Timezone,
+		restaurant.Latitude,
+		restaurant.Longitude,
 		restaurant.ID,
-	).Scan(&restaurant.UpdatedAt)
+	).Scan(&restaurant.Timezone, &restaurant.UpdatedAt, &restaurant.AverageRating, &restaurant.ReviewCount)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -133,7 +298,7 @@ func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Re
 func (r *RestaurantRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM restaurants WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete restaurant", err)
 	}
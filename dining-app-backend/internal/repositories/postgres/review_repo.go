@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type reviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) *reviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+const reviewColumns = `
+            rv.id, rv.restaurant_id, rv.user_id, rv.booking_id, rv.rating, rv.comment,
+            rv.hidden, COALESCE(rv.hidden_reason, '') AS hidden_reason,
+            rv.created_at, rv.updated_at, u.name AS user_name`
+
+func (r *reviewRepository) Create(ctx context.Context, review *domain.Review) error {
+	query := `
+        INSERT INTO reviews (restaurant_id, user_id, booking_id, rating, comment)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		review.RestaurantID,
+		review.UserID,
+		review.BookingID,
+		review.Rating,
+		review.Comment,
+	).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "booking has already been reviewed", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create review", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id int64) (*domain.Review, error) {
+	var review domain.Review
+	query := `
+        SELECT` + reviewColumns + `
+        FROM reviews rv
+        JOIN users u ON u.id = rv.user_id
+        WHERE rv.id = $1`
+
+	err := r.db.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "review not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get review", err)
+	}
+
+	return &review, nil
+}
+
+// ListVisible returns the restaurant's reviews that are not hidden, newest first
+func (r *reviewRepository) ListVisible(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Review], error) {
+	query := `
+        SELECT` + reviewColumns + `
+        FROM reviews rv
+        JOIN users u ON u.id = rv.user_id
+        WHERE rv.restaurant_id = $1 AND NOT rv.hidden
+        AND ($2::timestamptz IS NULL OR (rv.created_at, rv.id) < ($2::timestamptz, $3))
+        ORDER BY rv.created_at DESC, rv.id DESC
+        LIMIT $4 OFFSET $5`
+
+	key, id := cursorArgs(page)
+	reviews := []*domain.Review{}
+	if err := r.db.SelectContext(ctx, &reviews, query, restaurantID, key, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list reviews", err)
+	}
+
+	result := newPage(reviews, page, func(review *domain.Review) domain.Cursor {
+		return domain.Cursor{Key: review.CreatedAt.Format(time.RFC3339Nano), ID: review.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM reviews WHERE restaurant_id = $1 AND NOT hidden`
+	if err := countTotal(ctx, r.db, result, page, countQuery, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *domain.Review) error {
+	query := `
+        UPDATE reviews
+        SET rating = $1, comment = $2, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, review.Rating, review.Comment, review.ID).Scan(&review.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "review not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update review", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM reviews WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete review", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "review not found", nil)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) SetHidden(ctx context.Context, id int64, hidden bool, reason string) error {
+	query := `
+        UPDATE reviews
+        SET hidden = $1,
+            hidden_reason = CASE WHEN $1 THEN NULLIF($2, '') END
+        WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, hidden, reason, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update review visibility", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "review not found", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,368 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type roleRepository struct {
+	db *sqlx.DB
+}
+
+func NewRoleRepository(db *sqlx.DB) *roleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+// roleRow maps the permissions TEXT[] column
+type roleRow struct {
+	domain.Role
+	PermissionList pq.StringArray `db:"permissions"`
+}
+
+func (row *roleRow) toDomain() *domain.Role {
+	role := row.Role
+	role.Permissions = make([]domain.Permission, len(row.PermissionList))
+	for i, permission := range row.PermissionList {
+		role.Permissions[i] = domain.Permission(permission)
+	}
+	return &role
+}
+
+func permissionList(permissions []domain.Permission) pq.StringArray {
+	list := make(pq.StringArray, len(permissions))
+	for i, permission := range permissions {
+		list[i] = string(permission)
+	}
+	return list
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*domain.Role, error) {
+	query := `SELECT * FROM roles ORDER BY system DESC, name`
+
+	var rows []roleRow
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list roles", err)
+	}
+
+	roles := make([]*domain.Role, len(rows))
+	for i := range rows {
+		roles[i] = rows[i].toDomain()
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) Get(ctx context.Context, name string) (*domain.Role, error) {
+	query := `SELECT * FROM roles WHERE name = $1`
+
+	var row roleRow
+	if err := conn(ctx, r.db).GetContext(ctx, &row, query, name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "role not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get role", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// GetUserRole returns the role the user currently has
+func (r *roleRepository) GetUserRole(ctx context.Context, userID int64) (*domain.Role, error) {
+	query := `
+        SELECT r.*
+        FROM users u
+        JOIN roles r ON u.role = r.name
+        WHERE u.id = $1`
+
+	var row roleRow
+	if err := conn(ctx, r.db).GetContext(ctx, &row, query, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "user not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get user role", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *domain.Role) error {
+	query := `
+        INSERT INTO roles (name, description, permissions)
+        VALUES ($1, $2, $3)
+        RETURNING system, created_at, updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		role.Name,
+		role.Description,
+		permissionList(role.Permissions),
+	).Scan(&role.System, &role.CreatedAt, &role.UpdatedAt)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "role already exists", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create role", err)
+	}
+
+	return nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *domain.Role) error {
+	query := `
+        UPDATE roles
+        SET description = $1, permissions = $2, updated_at = CURRENT_TIMESTAMP
+        WHERE name = $3
+        RETURNING system, created_at, updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		role.Description,
+		permissionList(role.Permissions),
+		role.Name,
+	).Scan(&role.System, &role.CreatedAt, &role.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "role not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update role", err)
+	}
+
+	return nil
+}
+
+// Delete removes a role no user has. System roles are never deleted.
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	query := `DELETE FROM roles WHERE name = $1 AND NOT system`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, name)
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "role is still assigned to users", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete role", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "role not found", nil)
+	}
+
+	return nil
+}
+
+// CountUsersWithPermission counts the users whose role grants the permission, leaving
+// out users with exceptRole and the user exceptUserID
+func (r *roleRepository) CountUsersWithPermission(ctx context.Context, permission domain.Permission, exceptRole string, exceptUserID int64) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM users u
+        JOIN roles r ON u.role = r.name
+        WHERE $1 = ANY(r.permissions) AND r.name <> $2 AND u.id <> $3`
+
+	var count int
+	if err := conn(ctx, r.db).GetContext(ctx, &count, query, permission, exceptRole, exceptUserID); err != nil {
+		return 0, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to count users with permission", err)
+	}
+
+	return count, nil
+}
+
+// CreateInvite stores the invite and withdraws any earlier pending invite to the same
+// email, so only the newest link works
+func (r *roleRepository) CreateInvite(ctx context.Context, invite *domain.RoleInvite) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	withdrawQuery := `
+        DELETE FROM role_invites
+        WHERE email = $1 AND accepted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, withdrawQuery, invite.Email); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to withdraw invites", err)
+	}
+
+	insertQuery := `
+        INSERT INTO role_invites (email, role, token_hash, invited_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		insertQuery,
+		invite.Email,
+		invite.Role,
+		invite.TokenHash,
+		invite.InvitedBy,
+		invite.ExpiresAt,
+	).Scan(&invite.ID, &invite.CreatedAt)
+
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "role not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create invite", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// GetPendingInvite returns the unaccepted, unexpired invite with the token hash
+func (r *roleRepository) GetPendingInvite(ctx context.Context, tokenHash string) (*domain.RoleInvite, error) {
+	query := `
+        SELECT *
+        FROM role_invites
+        WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+
+	var invite domain.RoleInvite
+	if err := conn(ctx, r.db).GetContext(ctx, &invite, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "invite not found or expired", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get invite", err)
+	}
+
+	return &invite, nil
+}
+
+// ListPendingInvites pages through the unaccepted invites, newest first, including
+// expired ones
+func (r *roleRepository) ListPendingInvites(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.RoleInvite], error) {
+	query := `
+        SELECT *
+        FROM role_invites
+        WHERE accepted_at IS NULL
+        AND ($1::bigint IS NULL OR id < $1)
+        ORDER BY id DESC
+        LIMIT $2 OFFSET $3`
+
+	_, id := cursorArgs(page)
+	invites := []*domain.RoleInvite{}
+	if err := r.db.SelectContext(ctx, &invites, query, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list invites", err)
+	}
+
+	result := newPage(invites, page, func(invite *domain.RoleInvite) domain.Cursor {
+		return domain.Cursor{ID: invite.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM role_invites WHERE accepted_at IS NULL`
+	if err := countTotal(ctx, r.db, result, page, countQuery); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkInviteAccepted fails with not found when the invite was accepted or withdrawn
+// in the meantime
+func (r *roleRepository) MarkInviteAccepted(ctx context.Context, id int64) error {
+	query := `
+        UPDATE role_invites
+        SET accepted_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND accepted_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to accept invite", err)
+	}
+
+	return expectInviteRow(result)
+}
+
+// DeleteInvite withdraws a pending invite
+func (r *roleRepository) DeleteInvite(ctx context.Context, id int64) error {
+	query := `DELETE FROM role_invites WHERE id = $1 AND accepted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete invite", err)
+	}
+
+	return expectInviteRow(result)
+}
+
+func (r *roleRepository) RecordChange(ctx context.Context, change *domain.RoleChange) error {
+	query := `
+        INSERT INTO role_changes (user_id, old_role, new_role, changed_by, source)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		change.UserID,
+		change.OldRole,
+		change.NewRole,
+		change.ChangedBy,
+		change.Source,
+	).Scan(&change.ID, &change.CreatedAt)
+
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record role change", err)
+	}
+
+	return nil
+}
+
+// ListChanges pages through the user's role changes, newest first
+func (r *roleRepository) ListChanges(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.RoleChange], error) {
+	query := `
+        SELECT *
+        FROM role_changes
+        WHERE user_id = $1
+        AND ($2::bigint IS NULL OR id < $2)
+        ORDER BY id DESC
+        LIMIT $3 OFFSET $4`
+
+	_, id := cursorArgs(page)
+	changes := []*domain.RoleChange{}
+	if err := r.db.SelectContext(ctx, &changes, query, userID, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list role changes", err)
+	}
+
+	result := newPage(changes, page, func(change *domain.RoleChange) domain.Cursor {
+		return domain.Cursor{ID: change.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM role_changes WHERE user_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func expectInviteRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "invite not found or expired", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+)
+
+type sessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *sessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	query := `
+        INSERT INTO sessions (user_id, user_agent, ip_address, expires_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, last_used_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		session.UserID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt, &session.LastUsedAt)
+
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create session", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) Get(ctx context.Context, id int64) (*domain.Session, error) {
+	query := `SELECT * FROM sessions WHERE id = $1`
+
+	var session domain.Session
+	if err := conn(ctx, r.db).GetContext(ctx, &session, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "session not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get session", err)
+	}
+
+	return &session, nil
+}
+
+// IsActive reports whether the user's session exists and is neither revoked nor expired
+func (r *sessionRepository) IsActive(ctx context.Context, userID, id int64) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM sessions
+            WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+        )`
+
+	var active bool
+	if err := r.db.GetContext(ctx, &active, query, id, userID); err != nil {
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to check session", err)
+	}
+
+	return active, nil
+}
+
+// ListActive pages through the user's usable sessions, most recently used first
+func (r *sessionRepository) ListActive(ctx context.Context, userID int64, page domain.PageRequest) (*domain.Page[*domain.Session], error) {
+	query := `
+        SELECT *
+        FROM sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+        AND ($2::timestamptz IS NULL OR (last_used_at, id) < ($2::timestamptz, $3))
+        ORDER BY last_used_at DESC, id DESC
+        LIMIT $4 OFFSET $5`
+
+	key, id := cursorArgs(page)
+	sessions := []*domain.Session{}
+	if err := r.db.SelectContext(ctx, &sessions, query, userID, key, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list sessions", err)
+	}
+
+	result := newPage(sessions, page, func(session *domain.Session) domain.Cursor {
+		return domain.Cursor{Key: session.LastUsedAt.Format(time.RFC3339Nano), ID: session.ID}
+	})
+
+	countQuery := `
+        SELECT COUNT(*)
+        FROM sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	if err := countTotal(ctx, r.db, result, page, countQuery, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Touch records that the session was just refreshed from the device and extends it
+func (r *sessionRepository) Touch(ctx context.Context, session *domain.Session) error {
+	query := `
+        UPDATE sessions
+        SET user_agent = $1, ip_address = $2, expires_at = $3, last_used_at = CURRENT_TIMESTAMP
+        WHERE id = $4
+        RETURNING last_used_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+		session.ID,
+	).Scan(&session.LastUsedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "session not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update session", err)
+	}
+
+	return nil
+}
+
+// Revoke signs the user's session out. It fails with not found when the session was
+// already revoked.
+func (r *sessionRepository) Revoke(ctx context.Context, userID, id int64) error {
+	query := `
+        UPDATE sessions
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to revoke session", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "session not found", nil)
+	}
+
+	return nil
+}
+
+// RevokeAll signs the user out everywhere but the session exceptID and returns the
+// revoked sessions' IDs
+func (r *sessionRepository) RevokeAll(ctx context.Context, userID, exceptID int64) ([]int64, error) {
+	query := `
+        UPDATE sessions
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+        RETURNING id`
+
+	ids := []int64{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &ids, query, userID, exceptID); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to revoke sessions", err)
+	}
+
+	return ids, nil
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+        INSERT INTO refresh_tokens (session_id, token_hash, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		token.SessionID,
+		token.TokenHash,
+		token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create refresh token", err)
+	}
+
+	return nil
+}
+
+// LockRefreshToken returns the refresh token with the hash, used or not, and locks it
+// so concurrent refreshes with the same token are serialized. It must run in a
+// transaction.
+func (r *sessionRepository) LockRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `SELECT * FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE`
+
+	var token domain.RefreshToken
+	if err := conn(ctx, r.db).GetContext(ctx, &token, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "refresh token not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get refresh token", err)
+	}
+
+	return &token, nil
+}
+
+func (r *sessionRepository) MarkRefreshTokenUsed(ctx context.Context, id int64) error {
+	query := `UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, id); err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to use refresh token", err)
+	}
+
+	return nil
+}
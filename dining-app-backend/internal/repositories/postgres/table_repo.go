@@ -27,7 +27,7 @@ func (r *TableRepository) Create(ctx context.Context, table *domain.Table) error
         VALUES ($1, $2, $3, $4)
         RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		table.RestaurantID,
@@ -82,6 +82,36 @@ func (r *TableRepository) GetByRestaurantID(ctx context.Context, restaurantID in
 	return tables, nil
 }
 
+// ListByRestaurantID pages through the restaurant's tables in table number order
+func (r *TableRepository) ListByRestaurantID(ctx context.Context, restaurantID int64, page domain.PageRequest) (*domain.Page[*domain.Table], error) {
+	query := `
+        SELECT id, restaurant_id, table_number, capacity,
+               is_available, created_at, updated_at
+        FROM tables
+        WHERE restaurant_id = $1
+        AND ($2::text IS NULL OR (table_number, id) > ($2, $3))
+        ORDER BY table_number, id
+        LIMIT $4 OFFSET $5`
+
+	key, id := cursorArgs(page)
+	tables := []*domain.Table{}
+	err := r.db.SelectContext(ctx, &tables, query, restaurantID, key, id, fetchLimit(page), page.Offset)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list restaurant tables", err)
+	}
+
+	result := newPage(tables, page, func(table *domain.Table) domain.Cursor {
+		return domain.Cursor{Key: table.TableNumber, ID: table.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM tables WHERE restaurant_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, restaurantID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (r *TableRepository) UpdateAvailability(ctx context.Context, tableID int64, isAvailable bool) error {
 	query := `
         UPDATE tables
@@ -90,7 +120,7 @@ func (r *TableRepository) UpdateAvailability(ctx context.Context, tableID int64,
         RETURNING updated_at`
 
 	var updatedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, query, isAvailable, tableID).Scan(&updatedAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, isAvailable, tableID).Scan(&updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return apperrors.NewError(apperrors.ErrorTypeNotFound, "table not found", nil)
@@ -104,7 +134,7 @@ func (r *TableRepository) UpdateAvailability(ctx context.Context, tableID int64,
 func (r *TableRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM tables WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete table", err)
 	}
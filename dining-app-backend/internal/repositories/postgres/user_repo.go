@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
@@ -25,7 +26,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
         VALUES ($1, $2, $3, $4)
         RETURNING id, role, created_at, updated_at`
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		user.Name,
@@ -39,6 +40,9 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		if isPgUniqueViolation(err) {
 			return apperrors.NewError(apperrors.ErrorTypeValidation, "email already exists", err)
 		}
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeValidation, "unknown role", err)
+		}
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create user", err)
 	}
 
@@ -48,7 +52,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 func (r *userRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
 	var user domain.User
 	query := `
-        SELECT id, name, email, role, created_at, updated_at
+        SELECT id, name, email, role, email_verified_at, pending_email, created_at, updated_at
         FROM users
         WHERE id = $1`
 
@@ -66,7 +70,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	query := `
-        SELECT id, name, email, password, role, created_at, updated_at
+        SELECT id, name, email, password, role, email_verified_at, pending_email, created_at, updated_at
         FROM users
         WHERE email = $1`
 
@@ -88,7 +92,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
         WHERE id = $3
         RETURNING updated_at`
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		user.Name,
@@ -109,10 +113,140 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdatePassword replaces the user's password hash
+func (r *userRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	query := `
+        UPDATE users
+        SET password = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, passwordHash, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update password", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "user not found", nil)
+	}
+
+	return nil
+}
+
+// SetPendingEmail records the address the user is changing to, which takes effect once
+// verified. A nil email cancels the change.
+func (r *userRepository) SetPendingEmail(ctx context.Context, id int64, email *string) error {
+	query := `
+        UPDATE users
+        SET pending_email = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, email, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update pending email", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "user not found", nil)
+	}
+
+	return nil
+}
+
+// ClaimVerificationSend records that a verification email is being sent, unless one was
+// sent within the cooldown, in which case it reports false
+func (r *userRepository) ClaimVerificationSend(ctx context.Context, id int64, cooldown time.Duration) (bool, error) {
+	query := `
+        UPDATE users
+        SET verification_sent_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+          AND (verification_sent_at IS NULL
+               OR verification_sent_at <= CURRENT_TIMESTAMP - $2 * INTERVAL '1 second')`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, cooldown.Seconds())
+	if err != nil {
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to record verification email", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// MarkEmailVerified verifies the address, which must be the user's current or pending
+// email. Verifying the pending email makes it the user's email.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id int64, email string) error {
+	query := `
+        UPDATE users
+        SET email = $2,
+            pending_email = CASE WHEN pending_email = $2 THEN NULL ELSE pending_email END,
+            email_verified_at = CURRENT_TIMESTAMP,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND (email = $2 OR pending_email = $2)`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, email)
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeConflict, "email already taken", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to verify email", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "verification link is no longer valid", nil)
+	}
+
+	return nil
+}
+
+// UpdateRole assigns the user another role, which must exist
+func (r *userRepository) UpdateRole(ctx context.Context, id int64, role string) error {
+	query := `
+        UPDATE users
+        SET role = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, role, id)
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "role not found", err)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update user role", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "user not found", nil)
+	}
+
+	return nil
+}
+
 func (r *userRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM users WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete user", err)
 	}
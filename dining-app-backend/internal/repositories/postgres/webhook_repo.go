@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/domain"
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/pkg/apperrors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// maxStoredResponseBody limits how much of an endpoint's answer is kept in the log
+const maxStoredResponseBody = 2048
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *webhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// webhookEndpointRow maps the events TEXT[] column
+type webhookEndpointRow struct {
+	domain.WebhookEndpoint
+	EventList pq.StringArray `db:"events"`
+}
+
+func (row *webhookEndpointRow) toDomain() *domain.WebhookEndpoint {
+	endpoint := row.WebhookEndpoint
+	endpoint.Events = make([]domain.WebhookEventType, len(row.EventList))
+	for i, event := range row.EventList {
+		endpoint.Events[i] = domain.WebhookEventType(event)
+	}
+	return &endpoint
+}
+
+func eventList(events []domain.WebhookEventType) pq.StringArray {
+	list := make(pq.StringArray, len(events))
+	for i, event := range events {
+		list[i] = string(event)
+	}
+	return list
+}
+
+const webhookEndpointColumns = `id, url, COALESCE(description, '') AS description, secret, events, active, created_at, updated_at`
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	query := `
+        INSERT INTO webhook_endpoints (url, description, secret, events, active)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		endpoint.URL,
+		endpoint.Description,
+		endpoint.Secret,
+		eventList(endpoint.Events),
+		endpoint.Active,
+	).Scan(&endpoint.ID, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to create webhook endpoint", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE id = $1`
+
+	var row webhookEndpointRow
+	err := r.db.GetContext(ctx, &row, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "webhook endpoint not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get webhook endpoint", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints ORDER BY id`
+	return r.selectEndpoints(ctx, query)
+}
+
+func (r *webhookRepository) ListSubscribedEndpoints(ctx context.Context, eventType domain.WebhookEventType) ([]*domain.WebhookEndpoint, error) {
+	query := `
+        SELECT ` + webhookEndpointColumns + `
+        FROM webhook_endpoints
+        WHERE active AND ($1 = ANY(events) OR '*' = ANY(events))
+        ORDER BY id`
+	return r.selectEndpoints(ctx, query, eventType)
+}
+
+func (r *webhookRepository) selectEndpoints(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookEndpoint, error) {
+	rows := []webhookEndpointRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list webhook endpoints", err)
+	}
+
+	endpoints := make([]*domain.WebhookEndpoint, len(rows))
+	for i := range rows {
+		endpoints[i] = rows[i].toDomain()
+	}
+	return endpoints, nil
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	query := `
+        UPDATE webhook_endpoints
+        SET url = $1, description = $2, events = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $5
+        RETURNING secret, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		endpoint.URL,
+		endpoint.Description,
+		eventList(endpoint.Events),
+		endpoint.Active,
+		endpoint.ID,
+	).Scan(&endpoint.Secret, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NewError(apperrors.ErrorTypeNotFound, "webhook endpoint not found", nil)
+		}
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to update webhook endpoint", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to delete webhook endpoint", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get affected rows", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewError(apperrors.ErrorTypeNotFound, "webhook endpoint not found", nil)
+	}
+
+	return nil
+}
+
+const webhookDeliveryColumns = `
+        id, endpoint_id, event_id, event_type, redelivery, payload, status, attempts, response_status,
+        COALESCE(response_body, '') AS response_body, COALESCE(last_error, '') AS last_error,
+        next_attempt_at, delivered_at, created_at`
+
+func (r *webhookRepository) EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+        INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, redelivery, payload, status)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (endpoint_id, event_id) WHERE NOT redelivery DO NOTHING
+        RETURNING id, next_attempt_at, created_at`
+
+	delivery.Status = domain.WebhookDeliveryPending
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		delivery.EndpointID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Redelivery,
+		delivery.Payload,
+		delivery.Status,
+	).Scan(&delivery.ID, &delivery.NextAttemptAt, &delivery.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		// The endpoint already has a delivery of the event
+		return nil
+	}
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to enqueue webhook delivery", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	var delivery domain.WebhookDelivery
+	err := r.db.GetContext(ctx, &delivery, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NewError(apperrors.ErrorTypeNotFound, "webhook delivery not found", nil)
+		}
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to get webhook delivery", err)
+	}
+
+	return &delivery, nil
+}
+
+// ListDeliveries pages through the endpoint's deliveries, latest first
+func (r *webhookRepository) ListDeliveries(ctx context.Context, endpointID int64, page domain.PageRequest) (*domain.Page[*domain.WebhookDelivery], error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE endpoint_id = $1
+        AND ($2::bigint IS NULL OR id < $2)
+        ORDER BY id DESC
+        LIMIT $3 OFFSET $4`
+
+	_, id := cursorArgs(page)
+	deliveries := []*domain.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, endpointID, id, fetchLimit(page), page.Offset); err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to list webhook deliveries", err)
+	}
+
+	result := newPage(deliveries, page, func(delivery *domain.WebhookDelivery) domain.Cursor {
+		return domain.Cursor{ID: delivery.ID}
+	})
+
+	countQuery := `SELECT COUNT(*) FROM webhook_deliveries WHERE endpoint_id = $1`
+	if err := countTotal(ctx, r.db, result, page, countQuery, endpointID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ClaimDueDeliveries leases due deliveries to this replica, like notifications
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	query := `
+        UPDATE webhook_deliveries
+        SET attempts = attempts + 1,
+            next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM webhook_deliveries
+            WHERE status = $3 AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY next_attempt_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + webhookDeliveryColumns
+
+	deliveries := []*domain.WebhookDelivery{}
+	err := r.db.SelectContext(ctx, &deliveries, query, limit, lease.Seconds(), domain.WebhookDeliveryPending)
+	if err != nil {
+		return nil, apperrors.NewError(apperrors.ErrorTypeInternal, "failed to claim webhook deliveries", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) MarkDeliverySucceeded(ctx context.Context, id int64, response *domain.WebhookResponse) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $1, response_status = $2, response_body = $3, last_error = NULL,
+            delivered_at = CURRENT_TIMESTAMP
+        WHERE id = $4`
+
+	_, err := r.db.ExecContext(ctx, query, domain.WebhookDeliverySucceeded, response.StatusCode, truncate(response.Body), id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark webhook delivery as succeeded", err)
+	}
+
+	return nil
+}
+
+// MarkDeliveryFailed records a failed attempt. Without a next attempt the delivery is
+// given up on.
+func (r *webhookRepository) MarkDeliveryFailed(ctx context.Context, id int64, response *domain.WebhookResponse, lastError string, nextAttemptAt *time.Time) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $1, response_status = $2, response_body = $3, last_error = $4,
+            next_attempt_at = COALESCE($5, next_attempt_at)
+        WHERE id = $6`
+
+	status := domain.WebhookDeliveryPending
+	if nextAttemptAt == nil {
+		status = domain.WebhookDeliveryFailed
+	}
+
+	var responseStatus *int
+	var responseBody string
+	if response != nil {
+		responseStatus = &response.StatusCode
+		responseBody = truncate(response.Body)
+	}
+
+	_, err := r.db.ExecContext(ctx, query, status, responseStatus, responseBody, lastError, nextAttemptAt, id)
+	if err != nil {
+		return apperrors.NewError(apperrors.ErrorTypeInternal, "failed to mark webhook delivery as failed", err)
+	}
+
+	return nil
+}
+
+func truncate(body string) string {
+	if len(body) > maxStoredResponseBody {
+		return body[:maxStoredResponseBody]
+	}
+	return body
+}
@@ -8,6 +8,8 @@ const (
     ErrorTypeValidation   ErrorType = "VALIDATION_ERROR"
     ErrorTypeNotFound     ErrorType = "NOT_FOUND"
     ErrorTypeUnauthorized ErrorType = "UNAUTHORIZED"
+    ErrorTypeConflict     ErrorType = "CONFLICT"
+    ErrorTypeRateLimited  ErrorType = "RATE_LIMITED"
     ErrorTypeInternal     ErrorType = "INTERNAL_ERROR"
 )
 
@@ -37,6 +39,10 @@ func GetStatusCode(err *Error) int {
         return http.StatusNotFound
     case ErrorTypeUnauthorized:
         return http.StatusUnauthorized
+    case ErrorTypeConflict:
+        return http.StatusConflict
+    case ErrorTypeRateLimited:
+        return http.StatusTooManyRequests
     default:
         return http.StatusInternalServerError
     }
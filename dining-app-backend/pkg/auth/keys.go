@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// key is one of the asymmetric keys access tokens are signed or verified with
+type key struct {
+	id      string
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// JWK is a public key in JSON Web Key form
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA keys
+	Modulus  string `json:"n,omitempty"`
+	Exponent string `json:"e,omitempty"`
+	// Ed25519 keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is the set of public keys that verify access tokens
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// signingMethod returns the method of the configured algorithm
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case "HS256":
+		return jwt.SigningMethodHS256, nil
+	case "RS256":
+		return jwt.SigningMethodRS256, nil
+	case "EdDSA":
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", algorithm)
+	}
+}
+
+// loadKey reads the key's PEM files. A key given only a private key gets its public
+// key from it; a key given only a public key can verify but not sign.
+func loadKey(algorithm string, cfg config.JWTKeyConfig) (*key, error) {
+	k := &key{id: cfg.ID}
+
+	if cfg.PrivateKeyFile != "" {
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %q: %w", cfg.ID, err)
+		}
+		switch algorithm {
+		case "RS256":
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("JWT key %q: %w", cfg.ID, err)
+			}
+			k.private, k.public = private, &private.PublicKey
+		case "EdDSA":
+			private, err := jwt.ParseEdPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("JWT key %q: %w", cfg.ID, err)
+			}
+			k.private, k.public = private, private.(ed25519.PrivateKey).Public()
+		}
+	}
+
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %q: %w", cfg.ID, err)
+		}
+		switch algorithm {
+		case "RS256":
+			k.public, err = jwt.ParseRSAPublicKeyFromPEM(data)
+		case "EdDSA":
+			k.public, err = jwt.ParseEdPublicKeyFromPEM(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %q: %w", cfg.ID, err)
+		}
+	}
+
+	if k.public == nil {
+		return nil, fmt.Errorf("JWT key %q has neither a private nor a public key file", cfg.ID)
+	}
+
+	return k, nil
+}
+
+// jwk describes the key's public half
+func (k *key) jwk(algorithm string) JWK {
+	jwk := JWK{
+		KeyID:     k.id,
+		Use:       "sig",
+		Algorithm: algorithm,
+	}
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+
+	return jwk
+}
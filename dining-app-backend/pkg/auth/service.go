@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/arnavsingh03/AutoScaleOps-AI-Powered-DevOps-for-Go-Applications-/dining-app-backend/internal/core/config"
@@ -9,27 +12,163 @@ import (
 
 type Service struct {
 	config *config.JWTConfig
+	method jwt.SigningMethod
+	// signing is the key new access tokens are signed with, unless they use the secret
+	signing *key
+	// keys holds every key access tokens are verified with, by ID
+	keys map[string]*key
 }
 
 type Claims struct {
+	UserID    int64  `json:"user_id"`
+	Role      string `json:"role"`
+	SessionID int64  `json:"sid"`
+	jwt.StandardClaims
+}
+
+// emailAudience marks email verification tokens, so they can't pass as access tokens
+const emailAudience = "email-verification"
+
+// EmailClaims are carried by email verification links
+type EmailClaims struct {
 	UserID int64  `json:"user_id"`
-	Role   string `json:"role"`
+	Email  string `json:"email"`
 	jwt.StandardClaims
 }
 
-func NewAuthService(config *config.JWTConfig) *Service {
-	return &Service{
+// NewAuthService loads the configured signing keys. Access tokens are signed with the
+// secret under HS256, or with the signing key under RS256 and EdDSA. The secret is
+// required whatever the algorithm, since it always signs email verification tokens.
+func NewAuthService(config *config.JWTConfig) (*Service, error) {
+	if config.Secret == "" {
+		return nil, errors.New("JWT secret is required; it signs email verification links")
+	}
+
+	method, err := signingMethod(config.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
 		config: config,
+		method: method,
+		keys:   make(map[string]*key),
+	}
+	if method == jwt.SigningMethodHS256 {
+		return s, nil
+	}
+
+	for _, keyConfig := range config.Keys {
+		k, err := loadKey(config.Algorithm, keyConfig)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := s.keys[k.id]; exists {
+			return nil, fmt.Errorf("JWT key %q is configured twice", k.id)
+		}
+		s.keys[k.id] = k
+	}
+
+	s.signing = s.keys[config.SigningKeyID]
+	if s.signing == nil || s.signing.private == nil {
+		return nil, fmt.Errorf("JWT signing key %q needs a private key file", config.SigningKeyID)
 	}
+
+	return s, nil
 }
 
-func (s *Service) GenerateToken(userID int64, role string) (string, error) {
+// GenerateToken issues a short-lived access token for the session and returns when it
+// expires
+func (s *Service) GenerateToken(userID int64, role string, sessionID int64) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(time.Minute * time.Duration(s.config.AccessTokenMinutes))
 	claims := &Claims{
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    s.config.Issuer,
+			Audience:  s.config.Audience,
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(s.method, claims)
+	var signingKey interface{} = []byte(s.config.Secret)
+	if s.signing != nil {
+		token.Header["kid"] = s.signing.id
+		signingKey = s.signing.private
+	}
+
+	signed, err := token.SignedString(signingKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ValidateToken accepts access tokens signed with the configured algorithm by one of
+// the configured keys, and issued by and for this API
+func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{s.method.Alg()}))
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, s.verificationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	if !claims.VerifyIssuer(s.config.Issuer, true) {
+		return nil, errors.New("token has the wrong issuer")
+	}
+	if !claims.VerifyAudience(s.config.Audience, true) {
+		return nil, errors.New("token has the wrong audience")
+	}
+
+	return claims, nil
+}
+
+// verificationKey picks the key a token was signed with by its kid header
+func (s *Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.signing == nil {
+		return []byte(s.config.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return k.public, nil
+}
+
+// JWKS returns the public keys that verify access tokens, for other services to check
+// them with. It's empty under HS256, whose secret must never be shared.
+func (s *Service) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, k := range s.keys {
+		jwks.Keys = append(jwks.Keys, k.jwk(s.config.Algorithm))
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID })
+	return jwks
+}
+
+// GenerateEmailToken signs a token proving that whoever holds it receives mail at the
+// user's email address. Only this API checks these tokens, so they are always signed
+// with the secret.
+func (s *Service) GenerateEmailToken(userID int64, email string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &EmailClaims{
 		UserID: userID,
-		Role:   role,
+		Email:  email,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * time.Duration(s.config.TokenExpiry)).Unix(),
-			IssuedAt:  time.Now().Unix(),
+			Audience:  emailAudience,
+			ExpiresAt: now.Add(ttl).Unix(),
+			IssuedAt:  now.Unix(),
 		},
 	}
 
@@ -37,8 +176,9 @@ func (s *Service) GenerateToken(userID int64, role string) (string, error) {
 	return token.SignedString([]byte(s.config.Secret))
 }
 
-func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+func (s *Service) ValidateEmailToken(tokenString string) (*EmailClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	token, err := parser.ParseWithClaims(tokenString, &EmailClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.config.Secret), nil
 	})
 
@@ -46,7 +186,7 @@ func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	if claims, ok := token.Claims.(*EmailClaims); ok && token.Valid && claims.VerifyAudience(emailAudience, true) {
 		return claims, nil
 	}
 
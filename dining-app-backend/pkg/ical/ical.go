@@ -0,0 +1,139 @@
+// Package ical renders a minimal RFC 5545 iCalendar document
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+
+	MethodPublish = "PUBLISH"
+
+	dateTimeFormat = "20060102T150405"
+	maxLineOctets  = 75
+)
+
+// Event is a single VEVENT component
+type Event struct {
+	UID          string
+	Sequence     int
+	Stamp        time.Time
+	LastModified time.Time
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Location     string
+	Description  string
+	Status       string
+}
+
+// Calendar is a VCALENDAR document
+type Calendar struct {
+	ProductID string
+	Name      string
+	Method    string
+	Events    []Event
+}
+
+// Encode writes the calendar with CRLF line endings and folded content lines
+func (c *Calendar) Encode(w io.Writer) error {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeFolded(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProductID)
+	line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		line("METHOD", c.Method)
+	}
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("SEQUENCE", fmt.Sprint(event.Sequence))
+		line("DTSTAMP", formatUTC(event.Stamp))
+		if !event.LastModified.IsZero() {
+			line("LAST-MODIFIED", formatUTC(event.LastModified))
+		}
+		writeFolded(&buf, formatDateTime("DTSTART", event.Start))
+		writeFolded(&buf, formatDateTime("DTEND", event.End))
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.Status != "" {
+			line("STATUS", event.Status)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Bytes returns the encoded calendar
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	c.Encode(&buf)
+	return buf.Bytes()
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat) + "Z"
+}
+
+// formatDateTime keeps local times in their zone using a TZID parameter
+func formatDateTime(name string, t time.Time) string {
+	if t.Location() == time.UTC {
+		return name + ":" + formatUTC(t)
+	}
+	return fmt.Sprintf("%s;TZID=%s:%s", name, t.Location().String(), t.Format(dateTimeFormat))
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeFolded splits content lines longer than 75 octets without breaking UTF-8 sequences
+func writeFolded(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
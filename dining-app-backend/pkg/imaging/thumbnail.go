@@ -0,0 +1,110 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	// Register the decoders for the formats uploads may use
+	_ "image/gif"
+	_ "image/png"
+)
+
+// ErrTooLarge is returned for images with more pixels than allowed, before they are
+// decoded, so that a small file can't expand into a huge bitmap
+var ErrTooLarge = errors.New("image dimensions are too large")
+
+// Info describes a decoded image
+type Info struct {
+	Format string
+	Width  int
+	Height int
+}
+
+// Inspect reads the image's format and dimensions without decoding its pixels
+func Inspect(data []byte, maxPixels int) (Info, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Info{}, err
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return Info{}, errors.New("image has no pixels")
+	}
+	if maxPixels > 0 && config.Width*config.Height > maxPixels {
+		return Info{}, ErrTooLarge
+	}
+	return Info{Format: format, Width: config.Width, Height: config.Height}, nil
+}
+
+// Thumbnail decodes the image and writes a JPEG that fits within size x size pixels,
+// keeping the aspect ratio. Images already small enough are re-encoded at their own
+// size. Transparent areas are flattened onto white.
+func Thumbnail(w io.Writer, data []byte, size int, quality int) error {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	thumb := downscale(src, fit(src.Bounds().Size(), size))
+	return jpeg.Encode(w, thumb, &jpeg.Options{Quality: quality})
+}
+
+// fit scales the size down to fit within a square of the given side
+func fit(src image.Point, side int) image.Point {
+	if src.X <= side && src.Y <= side {
+		return src
+	}
+	if src.X >= src.Y {
+		return image.Point{X: side, Y: max(1, src.Y*side/src.X)}
+	}
+	return image.Point{X: max(1, src.X*side/src.Y), Y: side}
+}
+
+// downscale averages the source pixels under each destination pixel. The source is
+// converted a strip of rows at a time, so large images don't need a full RGBA copy.
+func downscale(src image.Image, size image.Point) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+
+	stripHeight := (sh + size.Y - 1) / size.Y
+	strip := image.NewRGBA(image.Rect(0, 0, sw, stripHeight))
+	white := image.NewUniform(color.White)
+
+	for dy := 0; dy < size.Y; dy++ {
+		y0 := dy * sh / size.Y
+		y1 := max(y0+1, (dy+1)*sh/size.Y)
+		rows := image.Rect(0, 0, sw, y1-y0)
+
+		draw.Draw(strip, rows, white, image.Point{}, draw.Src)
+		draw.Draw(strip, rows, src, image.Point{X: bounds.Min.X, Y: bounds.Min.Y + y0}, draw.Over)
+
+		for dx := 0; dx < size.X; dx++ {
+			x0 := dx * sw / size.X
+			x1 := max(x0+1, (dx+1)*sw/size.X)
+
+			var r, g, b, n int
+			for y := 0; y < y1-y0; y++ {
+				row := strip.Pix[y*strip.Stride:]
+				for x := x0; x < x1; x++ {
+					r += int(row[x*4])
+					g += int(row[x*4+1])
+					b += int(row[x*4+2])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(dx, dy)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	return dst
+}
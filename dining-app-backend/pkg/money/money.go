@@ -0,0 +1,69 @@
+// Package money converts between decimal prices and integer minor units, so that
+// amounts are never held in floating point
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minorUnitExponents lists the ISO 4217 currencies that don't use two decimal places
+var minorUnitExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Exponent returns the number of decimal places used by the currency
+func Exponent(currency string) int {
+	if exponent, ok := minorUnitExponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Parse converts a decimal amount such as "12.50" into minor units of the currency.
+// Negative amounts and more decimal places than the currency has are rejected.
+func Parse(amount, currency string) (int64, error) {
+	exponent := Exponent(currency)
+
+	whole, fraction, hasFraction := strings.Cut(strings.TrimSpace(amount), ".")
+	if whole == "" || (hasFraction && fraction == "") {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fraction) > exponent {
+		return 0, fmt.Errorf("amount %q has more than %d decimal places for %s", amount, exponent, currency)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", exponent-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+
+	return minor, nil
+}
+
+// Format renders minor units of the currency as a decimal amount
+func Format(minor int64, currency string) string {
+	exponent := Exponent(currency)
+	if exponent == 0 {
+		return strconv.FormatInt(minor, 10)
+	}
+
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+
+	digits := fmt.Sprintf("%0*d", exponent+1, minor)
+	split := len(digits) - exponent
+	return sign + digits[:split] + "." + digits[split:]
+}